@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// gnuCases are the cases of the GNU echo documentation, and the ones of
+// its parsing of the options, with the output of GNU echo.
+var gnuCases = []struct {
+	args []string
+	want string
+}{
+	{nil, "\n"},
+	{[]string{"hello", "world"}, "hello world\n"},
+	{[]string{"-n", "hello"}, "hello"},
+	{[]string{"-n", "-"}, "-"},
+	{[]string{"-"}, "-\n"},
+	{[]string{"-en"}, ""},
+	// Escapes are kept without -e, and after -E.
+	{[]string{`a\tb`}, `a\tb` + "\n"},
+	{[]string{"-E", `a\tb`}, `a\tb` + "\n"},
+	{[]string{"-e", `a\tb`}, "a\tb\n"},
+	{[]string{"-e", `\a\b\e\f\v\r`}, "\a\b\x1b\f\v\r\n"},
+	{[]string{"-e", `a\\b`}, `a\b` + "\n"},
+	{[]string{"-e", `\q`}, `\q` + "\n"},
+	{[]string{"-e", `x\`}, `x\` + "\n"},
+	// \c stops the output, newline and arguments included.
+	{[]string{"-e", `a\cb`, "c"}, "a"},
+	{[]string{"-e", "x", `y\c`, "z"}, "x y"},
+	// Octal, with \0 and up to three digits after it, or without.
+	{[]string{"-e", `\0101`}, "A\n"},
+	{[]string{"-e", `\101`}, "A\n"},
+	{[]string{"-e", `\01234`}, "S4\n"},
+	{[]string{"-e", `\1234`}, "S4\n"},
+	{[]string{"-e", `\0`}, "\x00\n"},
+	// Hexadecimal, with one or two digits.
+	{[]string{"-e", `\x41\x4g`}, "A\x04g\n"},
+	{[]string{"-e", `\x`}, `\x` + "\n"},
+	// The options are the leading arguments made of n, e and E only.
+	{[]string{"-ne", `a\n`}, "a\n"},
+	{[]string{"-nE", `a\n`}, `a\n`},
+	{[]string{"-n", "-e", `a\n`}, "a\n"},
+	{[]string{"-e", "-E", `a\n`}, `a\n` + "\n"},
+	{[]string{"--", "-n"}, "-- -n\n"},
+	{[]string{"-x", "-n"}, "-x -n\n"},
+	{[]string{"a", "-n"}, "a -n\n"},
+	{[]string{"-nx"}, "-nx\n"},
+}
+
+func echo(args ...string) (stdout, stderr string, status int) {
+	var out, errOut bytes.Buffer
+	status = run(args, &out, &errOut)
+	return out.String(), errOut.String(), status
+}
+
+func TestGNUCases(t *testing.T) {
+	for _, tt := range gnuCases {
+		if got, _, status := echo(tt.args...); got != tt.want || status != 0 {
+			t.Errorf("echo %q = %q, %d, want %q", tt.args, got, status, tt.want)
+		}
+	}
+}
+
+// TestGNUEcho compares the cases with the GNU echo of the system, if any.
+func TestGNUEcho(t *testing.T) {
+	version, err := exec.Command("/bin/echo", "--version").Output()
+	if err != nil || !strings.Contains(string(version), "GNU coreutils") {
+		t.Skip("no GNU echo")
+	}
+	for _, tt := range gnuCases {
+		want, err := exec.Command("/bin/echo", tt.args...).Output()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, _, _ := echo(tt.args...); got != string(want) {
+			t.Errorf("echo %q = %q, GNU echo %q", tt.args, got, want)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--json"}, "[]\n"},
+		{[]string{"--json", "a b", `"q"`}, `["a b","\"q\""]` + "\n"},
+		{[]string{"--json", "-n", "x"}, `["x"]`},
+		{[]string{"--json", "-e", `a\tb`, `c\cd`, "e"}, `["a\tb","c"]` + "\n"},
+	} {
+		if got, _, status := echo(tt.args...); got != tt.want || status != 0 {
+			t.Errorf("echo %q = %q, %d, want %q", tt.args, got, status, tt.want)
+		}
+	}
+	if _, errOut, status := echo("--json", "--format=%s", "x"); status != 2 || errOut == "" {
+		t.Errorf("--json with --format: status %d, %q, want 2 and an error", status, errOut)
+	}
+}
+
+func TestPrintf(t *testing.T) {
+	for _, tt := range []struct {
+		format string
+		args   []string
+		want   string
+	}{
+		{`%s-%s\n`, []string{"a", "b", "c"}, "a-b\nc-\n"},
+		{`%5s|%-5s|`, []string{"ab", "cd"}, "   ab|cd   |"},
+		{`%d %i %u %x %o`, []string{"10", "0x10", "'A", "255", "8"}, "10 16 65 ff 10"},
+		{`%.2f %e`, []string{"3.14159", "1500"}, "3.14 1.500000e+03"},
+		{`%c%c|`, []string{"héllo", "é"}, "hé|"},
+		{`%c|%3c|`, []string{"", ""}, "|   |"},
+		{`%c|`, nil, "|"},
+		{`%b|%s`, []string{`a\tb`, `a\tb`}, "a\tb|a\\tb"},
+		{`%b|never`, []string{`a\cb`}, "a"},
+		{`100%%\101\0102`, nil, "100%A\b2"}, // \nnn counts the 0 in a format
+		{`%s%d`, nil, "0"},
+		{`no verbs`, []string{"a", "b"}, "no verbs"},
+	} {
+		stdout, errOut, status := echo(append([]string{"-n", "--format=" + tt.format}, tt.args...)...)
+		if stdout != tt.want || status != 0 || errOut != "" {
+			t.Errorf("printf %q %q = %q, %d, %q, want %q", tt.format, tt.args, stdout, status, errOut, tt.want)
+		}
+	}
+	if out, errOut, status := echo("-n", "--format=%d|%d", "x", "2"); out != "0|2" || status != 1 || !strings.Contains(errOut, `"x": invalid number`) {
+		t.Errorf("invalid number: %q, %d, %q", out, status, errOut)
+	}
+	if _, errOut, status := echo("--format=%z", "x"); status != 1 || !strings.Contains(errOut, "invalid directive") {
+		t.Errorf("invalid directive: %d, %q", status, errOut)
+	}
+}
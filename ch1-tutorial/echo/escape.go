@@ -0,0 +1,93 @@
+package main
+
+import "strings"
+
+// unescape returns s with the backslash escapes of echo -e replaced:
+//
+//	\\ \a \b \e \f \n \r \t \v
+//	\0nnn  the byte of octal value nnn (zero to three digits), also \nnn
+//	\xHH   the byte of hexadecimal value HH (one or two digits)
+//	\c     produce no further output
+//
+// Other backslashes are kept as they are. stop reports a \c,
+// s being cut before it.
+func unescape(s string) (out string, stop bool) {
+	return unescapeOctal(s, true)
+}
+
+// unescapeOctal is unescape, with the octal escapes \0nnn taking up to
+// three digits after the 0 if zero is set, or up to three with it
+// otherwise, as in the format of printf(1).
+func unescapeOctal(s string, zero bool) (out string, stop bool) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch c := s[i]; c {
+		case '\\':
+			b.WriteByte('\\')
+		case 'a':
+			b.WriteByte('\a')
+		case 'b':
+			b.WriteByte('\b')
+		case 'e':
+			b.WriteByte(0x1b)
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'v':
+			b.WriteByte('\v')
+		case 'c':
+			return b.String(), true
+		case 'x':
+			n, v := digits(s[i+1:], 2, 16)
+			if n == 0 {
+				b.WriteString(`\x`)
+				continue
+			}
+			b.WriteByte(byte(v))
+			i += n
+		default:
+			if c < '0' || c > '7' {
+				b.WriteByte('\\')
+				b.WriteByte(c)
+				continue
+			}
+			if zero && c == '0' {
+				i++ // the 0 of \0nnn
+			}
+			n, v := digits(s[i:], 3, 8)
+			b.WriteByte(byte(v))
+			i += n - 1
+		}
+	}
+	return b.String(), false
+}
+
+// digits returns the number of digits in base at the start of s,
+// at most max, and their value.
+func digits(s string, max, base int) (n, v int) {
+	for ; n < len(s) && n < max; n++ {
+		d := strings.IndexByte("0123456789abcdef", lower(s[n]))
+		if d < 0 || d >= base {
+			break
+		}
+		v = v*base + d
+	}
+	return n, v
+}
+
+func lower(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
@@ -1,34 +1,109 @@
+// Echo prints its arguments, separated by spaces and followed by a
+// newline, like GNU echo. The leading arguments made of the options
+//
+//	-n  do not print the trailing newline
+//	-e  replace the backslash escapes of the arguments (see unescape)
+//	-E  do not replace them, the default
+//
+// are options, like -n or -ne; the first other argument and those after
+// it are printed, even if they look like options. So are -- and unknown
+// options like -x. Two long options, which GNU echo does not have, are
+// also options:
+//
+//	--json          print the arguments as a JSON array of strings
+//	--format=FMT    print the arguments formatted like printf(1) (see printf)
 package main
 
 import (
-	"os"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 )
 
-/* This program prints its command line arguments */
-
 func main() {
-	var s, sep string
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
 
-	/* Implementation 1
-	Each time in the loop, the string s gets completely new contents.
-	The += operator makes a new string by concatenating to the old string,
-	a space character, and the next argument, then assigns the new string
-	to s. The old contents of s are no longer in use,
-	so they will be garbage-collected.
-	This could be costly if the data involved is large
-	 */
-	for i:=1; i< len(os.Args); i++ {
-		s += sep + os.Args[i]
-		sep = " "
+// run echoes the arguments to stdout, and returns the exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	newline, escapes, asJSON := true, false, false
+	format, formatted := "", false
+	for ; len(args) > 0; args = args[1:] {
+		a := args[0]
+		if a == "--json" {
+			asJSON = true
+			continue
+		}
+		if f, ok := strings.CutPrefix(a, "--format="); ok {
+			format, formatted = f, true
+			continue
+		}
+		if len(a) < 2 || a[0] != '-' || strings.Trim(a[1:], "neE") != "" {
+			break
+		}
+		for _, c := range a[1:] {
+			switch c {
+			case 'n':
+				newline = false
+			case 'e':
+				escapes = true
+			case 'E':
+				escapes = false
+			}
+		}
+	}
+	if asJSON && formatted {
+		fmt.Fprintln(stderr, "echo: --json and --format exclude each other")
+		return 2
 	}
 
-	fmt.Println(s)
-
-	/* A simpler and more efficient solution is to use the Join function from
-	the strings package.
-	  */
-	fmt.Println(strings.Join(os.Args[1:], " "))
-
+	out, stop, status := "", false, 0
+	switch {
+	case formatted:
+		var err error
+		out, stop, err = printf(format, args)
+		if err != nil {
+			fmt.Fprintf(stderr, "echo: %v\n", err)
+			status = 1
+		}
+	case escapes:
+		// \c ends the output, with the arguments after it.
+		var words []string
+		for _, a := range args {
+			s, c := unescape(a)
+			words = append(words, s)
+			if c {
+				stop = true
+				break
+			}
+		}
+		args = words
+		out = strings.Join(args, " ")
+	default:
+		// strings.Join copies the arguments once, unlike s += sep + arg
+		// which copies s for each argument.
+		out = strings.Join(args, " ")
+	}
+	if asJSON {
+		if args == nil {
+			args = []string{}
+		}
+		b, err := json.Marshal(args)
+		if err != nil {
+			fmt.Fprintf(stderr, "echo: %v\n", err)
+			return 1
+		}
+		out = string(b)
+		stop = false
+	}
+	if newline && !stop {
+		out += "\n"
+	}
+	if _, err := io.WriteString(stdout, out); err != nil {
+		fmt.Fprintf(stderr, "echo: %v\n", err)
+		return 1
+	}
+	return status
 }
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// printf formats the arguments like printf(1): the format has the
+// backslash escapes of unescape (with \nnn for octal), and the verbs
+//
+//	%s %q      the argument, quoted by %q as a Go string
+//	%b         the argument, with its backslash escapes replaced
+//	%c         the first character of the argument, nothing if it is empty
+//	%d %i %u   the argument as an integer, also %o %x %X
+//	%f %e %g   the argument as a floating-point number, also %F %E %G
+//	%%         a percent sign
+//
+// with the flags, width and precision of package fmt. The format is
+// reused until the arguments are consumed; missing arguments are empty,
+// or zero. An integer argument starting with a quote is the code of the
+// character after it. The arguments which are not numbers are reported
+// in the error, as zero in the output. stop reports a \c.
+func printf(format string, args []string) (out string, stop bool, err error) {
+	var b strings.Builder
+	var errs []string
+	next := func() (string, bool) {
+		if len(args) == 0 {
+			return "", false
+		}
+		a := args[0]
+		args = args[1:]
+		return a, true
+	}
+	for {
+		used := false
+		lit := 0 // start of the literal text
+		for i := 0; i < len(format); i++ {
+			if format[i] != '%' {
+				if format[i] == '\\' {
+					i++ // an escaped % is not a verb
+				}
+				continue
+			}
+			s, stop := unescapeOctal(format[lit:i], false)
+			b.WriteString(s)
+			if stop {
+				return b.String(), true, joinErrors(errs)
+			}
+			// The flags, width and precision, up to the verb.
+			j := i + 1
+			for j < len(format) && strings.IndexByte("-+ #0123456789.", format[j]) >= 0 {
+				j++
+			}
+			if j == len(format) {
+				b.WriteString(format[i:])
+				lit = len(format)
+				break
+			}
+			spec, verb := format[i:j], format[j]
+			i, lit = j, j+1
+			if verb == '%' {
+				b.WriteByte('%')
+				continue
+			}
+			arg, ok := next()
+			used = used || ok
+			switch verb {
+			case 's', 'q':
+				fmt.Fprintf(&b, spec+string(verb), arg)
+			case 'b':
+				s, stop := unescape(arg)
+				fmt.Fprintf(&b, spec+"s", s)
+				if stop {
+					return b.String(), true, joinErrors(errs)
+				}
+			case 'c':
+				if arg == "" {
+					// Only the padding of the width, if any.
+					fmt.Fprintf(&b, spec+"s", "")
+					break
+				}
+				r, _ := utf8.DecodeRuneInString(arg)
+				fmt.Fprintf(&b, spec+"c", r)
+			case 'd', 'i', 'u', 'o', 'x', 'X':
+				n, err := parseInt(arg)
+				if err != nil {
+					errs = append(errs, err.Error())
+				}
+				if verb == 'i' || verb == 'u' {
+					verb = 'd'
+				}
+				fmt.Fprintf(&b, spec+string(verb), n)
+			case 'f', 'F', 'e', 'E', 'g', 'G':
+				f, err := parseFloat(arg)
+				if err != nil {
+					errs = append(errs, err.Error())
+				}
+				if verb == 'F' {
+					verb = 'f'
+				}
+				fmt.Fprintf(&b, spec+string(verb), f)
+			default:
+				return b.String(), false, fmt.Errorf("%%%c: invalid directive", verb)
+			}
+		}
+		if lit < len(format) {
+			s, stop := unescapeOctal(format[lit:], false)
+			b.WriteString(s)
+			if stop {
+				return b.String(), true, joinErrors(errs)
+			}
+		}
+		if len(args) == 0 || !used {
+			break
+		}
+	}
+	return b.String(), false, joinErrors(errs)
+}
+
+func parseInt(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if s[0] == '\'' || s[0] == '"' {
+		r, _ := utf8.DecodeRuneInString(s[1:])
+		return int64(r), nil
+	}
+	n, err := strconv.ParseInt(s, 0, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q: invalid number", s)
+	}
+	return n, nil
+}
+
+func parseFloat(s string) (float64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if s[0] == '\'' || s[0] == '"' {
+		r, _ := utf8.DecodeRuneInString(s[1:])
+		return float64(r), nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q: invalid number", s)
+	}
+	return f, nil
+}
+
+func joinErrors(errs []string) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(errs, "; "))
+}
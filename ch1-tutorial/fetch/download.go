@@ -0,0 +1,348 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// A download is written to path.part and renamed to path once complete
+// and verified. If the server accepts Range requests, the file is split
+// in byte ranges fetched in parallel, and their progress is saved to
+// path.part.json, so that an interrupted download is resumed from where
+// each range stopped by the next run.
+type download struct {
+	client   *http.Client
+	url      string
+	path     string
+	parallel int
+
+	mu      sync.Mutex
+	state   partState
+	written int64 // bytes of the file on disk, updated atomically
+}
+
+// partState is the progress of a download, saved next to the partial file.
+type partState struct {
+	URL    string
+	Size   int64
+	ETag   string
+	Ranges []*byteRange
+}
+
+// byteRange is a part of the file, from Start to End excluded.
+// The bytes before Done are written.
+type byteRange struct {
+	Start, End, Done int64
+}
+
+// minPart is the smallest range worth a connection of its own.
+const minPart = 1 << 20
+
+// fetchFile downloads url to path with up to parallel connections, and
+// verifies its SHA-256 checksum if sum is not "". It shows the progress
+// on stderr.
+func fetchFile(client *http.Client, url, path string, parallel int, sum string) error {
+	d := &download{client: client, url: url, path: path, parallel: parallel}
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		d.progress(done)
+	}()
+	err := d.run()
+	close(done)
+	wg.Wait()
+	if err != nil {
+		return err
+	}
+
+	if sum != "" {
+		got, err := sha256File(d.path + ".part")
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(got, sum) {
+			// The file is complete, and would be resumed as is.
+			os.Remove(d.path + ".part")
+			os.Remove(d.path + ".part.json")
+			return fmt.Errorf("%s: checksum mismatch: got %s, want %s", url, got, sum)
+		}
+	}
+	if err := os.Rename(d.path+".part", d.path); err != nil {
+		return err
+	}
+	os.Remove(d.path + ".part.json")
+	return nil
+}
+
+// run downloads the file to path.part.
+func (d *download) run() error {
+	resp, err := d.client.Head(d.url)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.ContentLength < 0 || resp.Header.Get("Accept-Ranges") != "bytes" {
+		// The file must be downloaded at once.
+		return d.stream()
+	}
+	size, etag := resp.ContentLength, resp.Header.Get("ETag")
+
+	f, err := os.OpenFile(d.path+".part", os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if !d.resume(size, etag) {
+		d.mu.Lock()
+		d.state = partState{URL: d.url, Size: size, ETag: etag, Ranges: split(size, d.parallel)}
+		d.mu.Unlock()
+		if err := f.Truncate(size); err != nil {
+			return err
+		}
+	}
+	for _, r := range d.state.Ranges {
+		atomic.AddInt64(&d.written, r.Done-r.Start)
+	}
+
+	errs := make(chan error, len(d.state.Ranges))
+	for _, r := range d.state.Ranges {
+		go func(r *byteRange) {
+			errs <- d.fetchRange(f, r)
+		}(r)
+	}
+	for range d.state.Ranges {
+		if e := <-errs; e != nil && err == nil {
+			err = e
+		}
+	}
+	if serr := d.save(); err == nil {
+		err = serr
+	}
+	return err
+}
+
+// resume loads the progress of a previous download of the file, and
+// reports whether it can be resumed: the file must not have changed since.
+func (d *download) resume(size int64, etag string) bool {
+	b, err := os.ReadFile(d.path + ".part.json")
+	if err != nil {
+		return false
+	}
+	var s partState
+	if json.Unmarshal(b, &s) != nil || s.URL != d.url || s.Size != size || s.ETag != etag {
+		return false
+	}
+	if fi, err := os.Stat(d.path + ".part"); err != nil || fi.Size() != size {
+		return false
+	}
+	d.mu.Lock()
+	d.state = s
+	d.mu.Unlock()
+	return true
+}
+
+// split splits size bytes in at most n ranges of minPart bytes or more.
+func split(size int64, n int) []*byteRange {
+	if max := size / minPart; int64(n) > max {
+		n = int(max)
+	}
+	if n < 1 {
+		n = 1
+	}
+	var ranges []*byteRange
+	for i := 0; i < n; i++ {
+		start, end := size*int64(i)/int64(n), size*int64(i+1)/int64(n)
+		ranges = append(ranges, &byteRange{Start: start, End: end, Done: start})
+	}
+	return ranges
+}
+
+// fetchRange downloads the rest of the range r into f.
+func (d *download) fetchRange(f *os.File, r *byteRange) error {
+	d.mu.Lock()
+	offset, end := r.Done, r.End
+	d.mu.Unlock()
+	if offset >= end {
+		return nil
+	}
+	req, err := http.NewRequest(http.MethodGet, d.url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, end-1))
+	if etag := d.state.ETag; etag != "" && !strings.HasPrefix(etag, "W/") {
+		// The server sends the whole file if it has changed.
+		req.Header.Set("If-Range", d.state.ETag)
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("%s: range %d-%d: %s", d.url, offset, end-1, resp.Status)
+	}
+
+	buf := make([]byte, 32*1024)
+	for offset < end {
+		n, err := resp.Body.Read(buf)
+		if int64(n) > end-offset {
+			n = int(end - offset)
+		}
+		if n > 0 {
+			if _, err := f.WriteAt(buf[:n], offset); err != nil {
+				return err
+			}
+			offset += int64(n)
+			atomic.AddInt64(&d.written, int64(n))
+			d.mu.Lock()
+			r.Done = offset
+			d.mu.Unlock()
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if offset < end {
+		return fmt.Errorf("%s: range %d-%d: %w", d.url, offset, end-1, io.ErrUnexpectedEOF)
+	}
+	return nil
+}
+
+// stream downloads the file in a single request, from its start.
+func (d *download) stream() error {
+	resp, err := d.client.Get(d.url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", d.url, resp.Status)
+	}
+	d.mu.Lock()
+	d.state.Size = resp.ContentLength
+	d.mu.Unlock()
+	f, err := os.Create(d.path + ".part")
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, &counter{r: resp.Body, n: &d.written})
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// counter counts the bytes read from r in n.
+type counter struct {
+	r io.Reader
+	n *int64
+}
+
+func (c *counter) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	atomic.AddInt64(c.n, int64(n))
+	return n, err
+}
+
+// save writes the progress of the download, if it is made of ranges.
+func (d *download) save() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.state.Ranges == nil {
+		return nil
+	}
+	b, err := json.Marshal(&d.state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(d.path+".part.json", b, 0644)
+}
+
+// progress draws a progress bar on stderr, and saves the progress
+// of the download, until done is closed.
+func (d *download) progress(done <-chan struct{}) {
+	tick := time.NewTicker(200 * time.Millisecond)
+	defer tick.Stop()
+	start, first := time.Now(), int64(-1)
+	for {
+		select {
+		case <-done:
+			d.drawBar(start, first)
+			fmt.Fprintln(os.Stderr)
+			return
+		case <-tick.C:
+			if first < 0 {
+				// Do not count the bytes of a previous run in the rate.
+				first = atomic.LoadInt64(&d.written)
+				start = time.Now()
+			}
+			d.drawBar(start, first)
+			if err := d.save(); err != nil {
+				fmt.Fprintf(os.Stderr, "fetch: %v\n", err)
+			}
+		}
+	}
+}
+
+func (d *download) drawBar(start time.Time, first int64) {
+	const width = 30
+	n := atomic.LoadInt64(&d.written)
+	d.mu.Lock()
+	size := d.state.Size
+	d.mu.Unlock()
+	var rate float64
+	if secs := time.Since(start).Seconds(); first >= 0 && secs > 0 {
+		rate = float64(n-first) / secs
+	}
+	if size <= 0 {
+		fmt.Fprintf(os.Stderr, "\r%s  %s/s ", byteSize(n), byteSize(int64(rate)))
+		return
+	}
+	filled := int(int64(width) * n / size)
+	fmt.Fprintf(os.Stderr, "\r[%s%s] %3d%% %s/%s  %s/s ", strings.Repeat("=", filled),
+		strings.Repeat(" ", width-filled), 100*n/size, byteSize(n), byteSize(size), byteSize(int64(rate)))
+}
+
+// byteSize formats n bytes with a binary unit, like 1.5MiB.
+func byteSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// sha256File returns the SHA-256 checksum of the file, in hex, as printed
+// by ch4-composite-types/sha256. The file is hashed as it is read, rather
+// than with sha256.Sum256 on its whole content.
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
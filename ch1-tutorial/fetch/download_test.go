@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fileServer serves a file. It accepts Range requests if ranges is set,
+// and then cuts the responses to the ranges after cut bytes, if not 0.
+type fileServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	content  []byte
+	etag     string
+	ranges   bool
+	cut      int
+	requests []string
+	served   int
+	// onGet, if set, is called before a GET is served.
+	onGet func(s *fileServer)
+}
+
+func newFileServer(t *testing.T, content []byte, ranges bool) *fileServer {
+	s := &fileServer{content: content, etag: `"v1"`, ranges: ranges}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fileServer) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, strings.TrimSpace(r.Method+" "+r.Header.Get("Range")))
+	if r.Method == http.MethodGet && s.onGet != nil {
+		s.onGet(s)
+	}
+	content, etag, ranges, cut := s.content, s.etag, s.ranges, s.cut
+	s.mu.Unlock()
+	cw := &cutWriter{ResponseWriter: w, s: s, limit: -1}
+	if !ranges {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		if r.Method == http.MethodGet {
+			cw.Write(content)
+		}
+		return
+	}
+	if cut > 0 && r.Header.Get("Range") != "" {
+		cw.limit = cut
+	}
+	w.Header().Set("ETag", etag)
+	http.ServeContent(cw, r, "", time.Time{}, bytes.NewReader(content))
+}
+
+// cutWriter counts the bytes served, and drops the
+// connection after limit bytes if limit is not -1.
+type cutWriter struct {
+	http.ResponseWriter
+	s     *fileServer
+	limit int
+}
+
+func (w *cutWriter) Write(p []byte) (int, error) {
+	if w.limit >= 0 && len(p) > w.limit {
+		n, _ := w.ResponseWriter.Write(p[:w.limit])
+		w.count(n)
+		w.ResponseWriter.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}
+	n, err := w.ResponseWriter.Write(p)
+	w.count(n)
+	if w.limit >= 0 {
+		w.limit -= n
+	}
+	return n, err
+}
+
+func (w *cutWriter) count(n int) {
+	w.s.mu.Lock()
+	w.s.served += n
+	w.s.mu.Unlock()
+}
+
+// reset returns the requests and the bytes served so far, and forgets them.
+func (s *fileServer) reset() ([]string, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requests, served := s.requests, s.served
+	s.requests, s.served = nil, 0
+	return requests, served
+}
+
+func randomContent(n int) []byte {
+	b := make([]byte, n)
+	rand.New(rand.NewSource(1)).Read(b)
+	return b
+}
+
+func sha256Hex(b []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(b))
+}
+
+// checkDone checks that path holds content, and that the partial files are gone.
+func checkDone(t *testing.T, path string, content []byte) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("%s: %d bytes differing from the %d served", path, len(got), len(content))
+	}
+	for _, name := range []string{path + ".part", path + ".part.json"} {
+		if _, err := os.Stat(name); !os.IsNotExist(err) {
+			t.Errorf("%s left behind", name)
+		}
+	}
+}
+
+func TestFetchFileResume(t *testing.T) {
+	content := randomContent(4*minPart + 123)
+	s := newFileServer(t, content, true)
+	s.cut = 100 << 10
+	path := filepath.Join(t.TempDir(), "file")
+
+	// Each of the 4 ranges is cut after 100KiB.
+	if err := fetchFile(http.DefaultClient, s.URL, path, 4, ""); err == nil {
+		t.Fatal("interrupted download succeeded")
+	}
+	// rangeRequests returns the requests of the ranges of the file,
+	// skipping the first skip bytes of each, after the HEAD.
+	rangeRequests := func(skip int64) string {
+		want := []string{"HEAD"}
+		for _, r := range split(int64(len(content)), 4) {
+			want = append(want, fmt.Sprintf("GET bytes=%d-%d", r.Start+skip, r.End-1))
+		}
+		return fmt.Sprint(want)
+	}
+	requests, _ := s.reset()
+	sort.Strings(requests[1:])
+	if fmt.Sprint(requests) != rangeRequests(0) {
+		t.Errorf("requests = %q, want %s", requests, rangeRequests(0))
+	}
+	b, err := os.ReadFile(path + ".part.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var state partState
+	if err := json.Unmarshal(b, &state); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range state.Ranges {
+		if r.Done-r.Start != int64(s.cut) {
+			t.Errorf("range %d-%d done up to %d, want %d bytes", r.Start, r.End, r.Done, s.cut)
+		}
+	}
+
+	// The second run fetches the rest of each range only.
+	s.cut = 0
+	if err := fetchFile(http.DefaultClient, s.URL, path, 4, sha256Hex(content)); err != nil {
+		t.Fatal(err)
+	}
+	checkDone(t, path, content)
+	requests, served := s.reset()
+	sort.Strings(requests[1:])
+	if fmt.Sprint(requests) != rangeRequests(100<<10) {
+		t.Errorf("requests = %q, want %s", requests, rangeRequests(100<<10))
+	}
+	if want := len(content) - 4*(100<<10); served != want {
+		t.Errorf("%d bytes served again, want %d", served, want)
+	}
+}
+
+func TestFetchFileChanged(t *testing.T) {
+	content := randomContent(2 * minPart)
+	s := newFileServer(t, content, true)
+	path := filepath.Join(t.TempDir(), "file")
+	// The file changes between the HEAD and the GET: If-Range
+	// has the server send it whole, which fails the range.
+	changed := bytes.Repeat([]byte("v2"), minPart+1)
+	s.onGet = func(s *fileServer) {
+		s.content, s.etag, s.onGet = changed, `"v2"`, nil
+	}
+	err := fetchFile(http.DefaultClient, s.URL, path, 1, "")
+	if err == nil || !strings.Contains(err.Error(), "200 OK") {
+		t.Fatalf("fetchFile = %v, want the range failed with the whole file", err)
+	}
+	// The next run does not resume the file of the old ETag.
+	s.reset()
+	if err := fetchFile(http.DefaultClient, s.URL, path, 1, sha256Hex(changed)); err != nil {
+		t.Fatal(err)
+	}
+	checkDone(t, path, changed)
+	if requests, _ := s.reset(); fmt.Sprint(requests) != "[HEAD GET bytes=0-2097153]" {
+		t.Errorf("requests = %q, want the whole new file", requests)
+	}
+}
+
+func TestFetchFileChecksum(t *testing.T) {
+	content := randomContent(minPart)
+	for _, ranges := range []bool{true, false} {
+		s := newFileServer(t, content, ranges)
+		path := filepath.Join(t.TempDir(), "file")
+		err := fetchFile(http.DefaultClient, s.URL, path, 4, sha256Hex([]byte("other")))
+		if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+			t.Errorf("ranges %v: fetchFile = %v, want a checksum mismatch", ranges, err)
+		}
+		// Nothing is left to be resumed.
+		for _, name := range []string{path, path + ".part", path + ".part.json"} {
+			if _, err := os.Stat(name); !os.IsNotExist(err) {
+				t.Errorf("ranges %v: %s left behind", ranges, name)
+			}
+		}
+	}
+}
+
+func TestFetchFileNoRanges(t *testing.T) {
+	content := randomContent(3*minPart + 1)
+	s := newFileServer(t, content, false)
+	path := filepath.Join(t.TempDir(), "file")
+	if err := fetchFile(http.DefaultClient, s.URL, path, 4, sha256Hex(content)); err != nil {
+		t.Fatal(err)
+	}
+	checkDone(t, path, content)
+	// A single GET of the whole file, after the HEAD.
+	if requests, _ := s.reset(); fmt.Sprint(requests) != "[HEAD GET]" {
+		t.Errorf("requests = %q, want one GET without a range", requests)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	for _, tt := range []struct {
+		size int64
+		n    int
+		want string
+	}{
+		{10, 4, "[{0 10 0}]"},
+		{2 * minPart, 4, "[{0 1048576 0} {1048576 2097152 1048576}]"},
+		{3*minPart + 1, 2, "[{0 1572864 0} {1572864 3145729 1572864}]"},
+		{0, 4, "[{0 0 0}]"},
+	} {
+		var got []string
+		for _, r := range split(tt.size, tt.n) {
+			got = append(got, fmt.Sprint(*r))
+		}
+		if s := "[" + strings.Join(got, " ") + "]"; s != tt.want {
+			t.Errorf("split(%d, %d) = %s, want %s", tt.size, tt.n, s, tt.want)
+		}
+	}
+}
@@ -1,30 +1,52 @@
 package main
 
 import (
+	"flag"
 	"os"
 	"net/http"
 	"fmt"
 	"io"
-	"io/ioutil"
+)
+
+// With -o, fetch downloads the url to a file (go run .):
+//
+//	fetch -o go.tar.gz -parallel 4 -sha256 <sum> https://go.dev/dl/go1.21.0.src.tar.gz
+//
+// An interrupted download is resumed by running the same command again.
+var (
+	output   = flag.String("o", "", "file to download the url to")
+	parallel = flag.Int("parallel", 4, "number of connections of a download")
+	sum      = flag.String("sha256", "", "expected SHA-256 checksum of the download, in hex")
 )
 
 func main() {
-	for _, url := range os.Args[1:] {
+	flag.Parse()
+	if *output != "" {
+		if flag.NArg() != 1 {
+			fmt.Fprintln(os.Stderr, "fetch: -o takes a single url")
+			os.Exit(2)
+		}
+		if err := fetchFile(http.DefaultClient, flag.Arg(0), *output, *parallel, *sum); err != nil {
+			fmt.Fprintf(os.Stderr, "fetch: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	for _, url := range flag.Args() {
 		resp, err := http.Get(url)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "fetch: %v\n", err)
 			os.Exit(1)
 		}
 
-		b, err := ioutil.ReadAll(resp.Body)
+		// Stream the resp body to os.Stdout, rather than reading
+		// it all in memory with ioutil.ReadAll first.
+		_, err = io.Copy(os.Stdout, resp.Body)
 		resp.Body.Close()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "fetch: reading %s: %v\n", url, err)
 			os.Exit(1)
 		}
-		fmt.Printf("%s", b)
-
-		// Alternative to read the resp body and write to os.Stdout
-		io.Copy(os.Stdout, resp.Body)
 	}
 }
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// loadConfig is the configuration of a load test.
+type loadConfig struct {
+	Workers  int           // number of concurrent requests
+	Requests int           // total number of requests, if Duration is 0
+	Duration time.Duration // duration of the test, if not 0
+	Rate     float64       // target requests per second, unlimited if 0
+}
+
+// interval returns the interval between the requests at the target
+// rate, or 0 if the rate is unlimited, or too high for a ticker.
+func (cfg loadConfig) interval() time.Duration {
+	if cfg.Rate <= 0 {
+		return 0
+	}
+	return time.Duration(float64(time.Second) / cfg.Rate)
+}
+
+// result is the outcome of a request.
+type result struct {
+	status  int // 0 on error
+	latency time.Duration
+	bytes   int64
+}
+
+// Report summarizes a load test.
+type Report struct {
+	Requests   int            `json:"requests"`
+	Errors     int            `json:"errors"`
+	Bytes      int64          `json:"bytes"`
+	Elapsed    float64        `json:"elapsed_seconds"`
+	Throughput float64        `json:"requests_per_second"`
+	Latency    Latency        `json:"latency_ms"`
+	Status     map[string]int `json:"status"` // by status code, "error" for the failed requests
+}
+
+// Latency holds the latency percentiles of a load test, in milliseconds.
+type Latency struct {
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+// load sends requests to the urls, in turn, with cfg.Workers goroutines,
+// until cfg.Requests are sent or cfg.Duration has elapsed. The requests
+// that time out, after the Timeout of the client, are errors.
+func load(client *http.Client, urls []string, cfg loadConfig) *Report {
+	jobs := make(chan string)
+	results := make(chan result)
+
+	// The producer hands the urls to the workers, at the target rate.
+	go func() {
+		defer close(jobs)
+		var tick <-chan time.Time
+		if d := cfg.interval(); d > 0 {
+			t := time.NewTicker(d)
+			defer t.Stop()
+			tick = t.C
+		}
+		var deadline <-chan time.Time
+		if cfg.Duration > 0 {
+			deadline = time.After(cfg.Duration)
+		}
+		for i := 0; cfg.Duration > 0 || i < cfg.Requests; i++ {
+			if tick != nil {
+				select {
+				case <-tick:
+				case <-deadline:
+					return
+				}
+			}
+			select {
+			case jobs <- urls[i%len(urls)]:
+			case <-deadline:
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for url := range jobs {
+				results <- request(client, url)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	start := time.Now()
+	var all []result
+	for r := range results {
+		all = append(all, r)
+	}
+	return summarize(all, time.Since(start))
+}
+
+// request sends a GET request and reads the whole response.
+func request(client *http.Client, url string) result {
+	start := time.Now()
+	resp, err := client.Get(url)
+	if err != nil {
+		return result{latency: time.Since(start)}
+	}
+	nbytes, err := io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close() // don't leak resources
+	r := result{status: resp.StatusCode, latency: time.Since(start), bytes: nbytes}
+	if err != nil {
+		r.status = 0
+	}
+	return r
+}
+
+// summarize computes the report of the results of a load test.
+func summarize(results []result, elapsed time.Duration) *Report {
+	rep := &Report{
+		Requests: len(results),
+		Elapsed:  elapsed.Seconds(),
+		Status:   make(map[string]int),
+	}
+	latencies := make([]time.Duration, len(results))
+	var total time.Duration
+	for i, r := range results {
+		latencies[i] = r.latency
+		total += r.latency
+		rep.Bytes += r.bytes
+		if r.status == 0 {
+			rep.Errors++
+			rep.Status["error"]++
+		} else {
+			rep.Status[strconv.Itoa(r.status)]++
+		}
+	}
+	if len(results) == 0 {
+		return rep
+	}
+	rep.Throughput = float64(len(results)) / elapsed.Seconds()
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	rep.Latency = Latency{
+		Mean: ms(total / time.Duration(len(results))),
+		P50:  ms(percentile(latencies, 50)),
+		P90:  ms(percentile(latencies, 90)),
+		P99:  ms(percentile(latencies, 99)),
+		Max:  ms(latencies[len(latencies)-1]),
+	}
+	return rep
+}
+
+// percentile returns the pth percentile of the sorted durations,
+// by the nearest-rank method.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// statusCodes returns the status codes of the report, sorted.
+func (rep *Report) statusCodes() []string {
+	var codes []string
+	for code := range rep.Status {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// write writes the report as text, json or csv.
+func (rep *Report) write(w io.Writer, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rep)
+	case "csv":
+		header := []string{"requests", "errors", "bytes", "elapsed_seconds", "requests_per_second",
+			"mean_ms", "p50_ms", "p90_ms", "p99_ms", "max_ms"}
+		l := rep.Latency
+		row := []string{strconv.Itoa(rep.Requests), strconv.Itoa(rep.Errors), strconv.FormatInt(rep.Bytes, 10)}
+		for _, f := range []float64{rep.Elapsed, rep.Throughput, l.Mean, l.P50, l.P90, l.P99, l.Max} {
+			row = append(row, strconv.FormatFloat(f, 'f', 3, 64))
+		}
+		for _, code := range rep.statusCodes() {
+			header = append(header, "status_"+code)
+			row = append(row, strconv.Itoa(rep.Status[code]))
+		}
+		cw := csv.NewWriter(w)
+		cw.Write(header)
+		cw.Write(row)
+		cw.Flush()
+		return cw.Error()
+	case "text":
+		fmt.Fprintf(w, "%d requests, %d errors, %d bytes in %.2fs\n", rep.Requests, rep.Errors, rep.Bytes, rep.Elapsed)
+		fmt.Fprintf(w, "%.1f requests/s\n", rep.Throughput)
+		l := rep.Latency
+		fmt.Fprintf(w, "latency mean %.1fms  p50 %.1fms  p90 %.1fms  p99 %.1fms  max %.1fms\n", l.Mean, l.P50, l.P90, l.P99, l.Max)
+		for _, code := range rep.statusCodes() {
+			fmt.Fprintf(w, "%7s  %d\n", code, rep.Status[code])
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// slowServer answers every request after the latency, with a body of
+// 5 bytes, and counts the requests.
+func slowServer(t *testing.T, latency time.Duration, status int) (*httptest.Server, *int64) {
+	var n int64
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&n, 1)
+		time.Sleep(latency)
+		w.WriteHeader(status)
+		w.Write([]byte("hello"))
+	}))
+	t.Cleanup(s.Close)
+	return s, &n
+}
+
+func TestLoadRequests(t *testing.T) {
+	s, n := slowServer(t, 20*time.Millisecond, http.StatusOK)
+	rep := load(s.Client(), []string{s.URL}, loadConfig{Workers: 4, Requests: 20})
+	if rep.Requests != 20 || *n != 20 || rep.Errors != 0 || rep.Status["200"] != 20 {
+		t.Errorf("report = %+v after %d requests, want 20 OK", rep, *n)
+	}
+	if rep.Bytes != 100 {
+		t.Errorf("bytes = %d, want 100", rep.Bytes)
+	}
+	l := rep.Latency
+	if l.P50 < 20 || l.P90 < l.P50 || l.P99 < l.P90 || l.Max < l.P99 || l.Mean < 20 {
+		t.Errorf("latency = %+v, want at least 20ms, in order", l)
+	}
+	// 4 workers send the 20 requests in 5 rounds of 20ms.
+	if rep.Elapsed < 0.1 || rep.Throughput > 200 {
+		t.Errorf("%d requests in %.3fs, %.1f/s, want 5 rounds of 20ms", rep.Requests, rep.Elapsed, rep.Throughput)
+	}
+}
+
+func TestLoadRate(t *testing.T) {
+	s, _ := slowServer(t, 0, http.StatusOK)
+	start := time.Now()
+	rep := load(s.Client(), []string{s.URL}, loadConfig{Workers: 4, Requests: 10, Rate: 100})
+	if d := time.Since(start); rep.Requests != 10 || d < 90*time.Millisecond {
+		t.Errorf("%d requests in %v, want 10 in at least 90ms at 100/s", rep.Requests, d)
+	}
+
+	// A rate too high for a ticker is unlimited.
+	rep = load(s.Client(), []string{s.URL}, loadConfig{Workers: 4, Requests: 10, Rate: 1e10})
+	if rep.Requests != 10 {
+		t.Errorf("%d requests at 1e10/s, want 10", rep.Requests)
+	}
+}
+
+func TestLoadDuration(t *testing.T) {
+	s, _ := slowServer(t, 10*time.Millisecond, http.StatusOK)
+	start := time.Now()
+	rep := load(s.Client(), []string{s.URL}, loadConfig{Workers: 2, Duration: 100 * time.Millisecond})
+	if d := time.Since(start); d > time.Second || rep.Requests == 0 || rep.Requests > 30 {
+		t.Errorf("%d requests in %v, want about 20 in 100ms", rep.Requests, d)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	slow, _ := slowServer(t, 300*time.Millisecond, http.StatusOK)
+	failing, _ := slowServer(t, 0, http.StatusServiceUnavailable)
+	client := slow.Client()
+	client.Timeout = 50 * time.Millisecond
+	start := time.Now()
+	rep := load(client, []string{slow.URL, failing.URL}, loadConfig{Workers: 2, Requests: 4})
+	if rep.Errors != 2 || rep.Status["error"] != 2 || rep.Status["503"] != 2 {
+		t.Errorf("status = %v, want 2 timeouts and 2 503s", rep.Status)
+	}
+	if d := time.Since(start); d > 250*time.Millisecond {
+		t.Errorf("took %v, want the slow requests to time out", d)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	var d []time.Duration
+	for i := 1; i <= 100; i++ {
+		d = append(d, time.Duration(i)*time.Millisecond)
+	}
+	for p, want := range map[float64]time.Duration{0: 1, 50: 50, 90: 90, 99: 99, 100: 100} {
+		if got := percentile(d, p); got != want*time.Millisecond {
+			t.Errorf("percentile(%v) = %v, want %v", p, got, want*time.Millisecond)
+		}
+	}
+}
+
+func TestReportWrite(t *testing.T) {
+	rep := summarize([]result{
+		{status: 200, latency: 10 * time.Millisecond, bytes: 5},
+		{status: 200, latency: 30 * time.Millisecond, bytes: 5},
+		{latency: 20 * time.Millisecond},
+	}, time.Second)
+
+	var buf bytes.Buffer
+	if err := rep.write(&buf, "json"); err != nil {
+		t.Fatal(err)
+	}
+	var got Report
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Requests != 3 || got.Errors != 1 || got.Latency.Mean != 20 || got.Latency.Max != 30 || got.Status["200"] != 2 {
+		t.Errorf("json report = %+v", got)
+	}
+
+	buf.Reset()
+	if err := rep.write(&buf, "csv"); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil || len(rows) != 2 {
+		t.Fatalf("csv report = %q, %v", rows, err)
+	}
+	if h := strings.Join(rows[0], ","); !strings.HasSuffix(h, "max_ms,status_200,status_error") {
+		t.Errorf("csv header = %s", h)
+	}
+
+	buf.Reset()
+	if err := rep.write(&buf, "text"); err != nil || !strings.Contains(buf.String(), "3 requests, 1 errors, 10 bytes") {
+		t.Errorf("text report = %q, %v", buf.String(), err)
+	}
+	if err := rep.write(&buf, "xml"); err == nil {
+		t.Error("wrote an xml report")
+	}
+}
@@ -0,0 +1,82 @@
+package main
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"time"
+)
+
+// With -n or -d, fetchAll load tests the urls instead of fetching each
+// once:
+//
+//	fetchAll -c 10 -n 1000 -rate 200 -format json http://localhost:8000/
+var (
+	workers  = flag.Int("c", 10, "number of concurrent requests of a load test")
+	requests = flag.Int("n", 0, "total number of requests of a load test")
+	duration = flag.Duration("d", 0, "duration of a load test, instead of -n")
+	rate     = flag.Float64("rate", 0, "target requests per second of a load test, unlimited if 0")
+	format   = flag.String("format", "text", "format of the load test report: text, json or csv")
+	timeout  = flag.Duration("timeout", 10*time.Second, "timeout of the requests of a load test")
+)
+
+func main() {
+	flag.Parse()
+	if *requests > 0 || *duration > 0 {
+		if *workers < 1 || flag.NArg() == 0 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		if *format != "text" && *format != "json" && *format != "csv" {
+			log.Fatalf("fetchAll: unknown format %q", *format)
+		}
+		// Keep a connection per worker open, rather than the default 2.
+		// A request hanging past the timeout counts as an error,
+		// instead of holding its worker until the end of the test.
+		client := &http.Client{
+			Timeout:   *timeout,
+			Transport: &http.Transport{MaxIdleConnsPerHost: *workers},
+		}
+		rep := load(client, flag.Args(), loadConfig{
+			Workers:  *workers,
+			Requests: *requests,
+			Duration: *duration,
+			Rate:     *rate,
+		})
+		if err := rep.write(os.Stdout, *format); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	start := time.Now()
+	ch := make(chan string)
+	for _, url := range flag.Args() {
+		go fetch(url, ch) // start a goroutine
+	}
+
+
+	for range flag.Args() {
+		fmt.Println(<-ch) // receive from channel ch
+	}
+	fmt.Printf("%.2fs elapsed\n", time.Since(start).Seconds())
+}
+func fetch(url string, ch chan<-string) {
+	start := time.Now()
+	resp, err := http.Get(url)
+	if err != nil {
+		ch <- fmt.Sprint(err) // send to channel ch
+		return
+	}
+	nbytes, err := io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close() // don't leak resources
+	if err != nil {
+		ch <- fmt.Sprintf("while reading %s: %v", url, err)
+		return
+	}
+	secs := time.Since(start).Seconds()
+	ch <- fmt.Sprintf("%.2fs  %7d  %s", secs, nbytes, url)
+}
\ No newline at end of file
@@ -0,0 +1,3 @@
+module github.com/anjanashankar9/go-learning/ch1-tutorial
+
+go 1.24
@@ -0,0 +1,44 @@
+// Lissajous generates GIF animations of random Lissajous figures.
+//
+// With -http, it serves them instead, with the parameters of the
+// query string of lissajous.ParseQuery:
+//
+//	lissajous -http localhost:8000
+//	curl 'localhost:8000/lissajous?cycles=7&palette=rainbow&seed=42&format=apng' > l.png
+//
+// The server2 server serves them at /lissajous too.
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/anjanashankar9/go-learning/ch1-tutorial/lissajous"
+	"github.com/anjanashankar9/go-learning/ch1-tutorial/server/httpserver"
+)
+
+var addr = flag.String("http", "", "address to serve the animations on, at /lissajous")
+
+func main() {
+	flag.Parse()
+	if *addr != "" {
+		srv := httpserver.New(httpserver.Config{Addr: *addr})
+		srv.HandleFunc("/lissajous", lissajous.Handler)
+		if err := srv.ListenAndServe(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	w := bufio.NewWriter(os.Stdout)
+	p := lissajous.DefaultParams(time.Now().UnixNano())
+	if err := p.Render(context.Background(), w); err != nil {
+		log.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,142 @@
+package lissajous
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+)
+
+// encodeGIF writes the frames as an animated GIF.
+func encodeGIF(out io.Writer, frames []*image.Paletted, delay int) error {
+	anim := gif.GIF{LoopCount: len(frames)}
+	for _, img := range frames {
+		anim.Delay = append(anim.Delay, delay)
+		anim.Image = append(anim.Image, img)
+	}
+	return gif.EncodeAll(out, &anim)
+}
+
+// encodeSprite writes the frames side by side in a PNG image,
+// a sprite sheet to be animated by CSS.
+func encodeSprite(out io.Writer, frames []*image.Paletted) error {
+	b := frames[0].Bounds()
+	sheet := image.NewPaletted(image.Rect(0, 0, b.Dx()*len(frames), b.Dy()), frames[0].Palette)
+	for i, img := range frames {
+		r := image.Rect(i*b.Dx(), 0, (i+1)*b.Dx(), b.Dy())
+		draw.Draw(sheet, r, img, b.Min, draw.Src)
+	}
+	return png.Encode(out, sheet)
+}
+
+// encodeAPNG writes the frames as an animated PNG. The APNG format adds
+// chunks to PNG, which image/png does not write: acTL, the number of frames,
+// and before each frame an fcTL, its size and delay. The first frame is the
+// IDAT chunks of a PNG image, and the others are the same data in fdAT
+// chunks. The frames share the palette, so they are encoded alike.
+func encodeAPNG(out io.Writer, frames []*image.Paletted, delay int) error {
+	w := &chunkWriter{w: out}
+	w.write([]byte("\x89PNG\r\n\x1a\n"))
+	var seq uint32 // of the fcTL and fdAT chunks
+	for i, img := range frames {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			return err
+		}
+		chunks, err := readChunks(buf.Bytes())
+		if err != nil {
+			return err
+		}
+		fctl := false
+		for _, c := range chunks {
+			switch c.typ {
+			case "IHDR", "PLTE", "tRNS":
+				if i > 0 {
+					continue
+				}
+				w.chunk(c.typ, c.data)
+				if c.typ == "IHDR" {
+					w.chunk("acTL", be32(uint32(len(frames)), 0)) // played forever
+				}
+			case "IDAT":
+				if !fctl {
+					b := img.Bounds()
+					data := be32(seq, uint32(b.Dx()), uint32(b.Dy()), 0, 0)
+					// The delay is delay/100 seconds, with no dispose and blend ops.
+					data = append(data, byte(delay>>8), byte(delay), 0, 100, 0, 0)
+					w.chunk("fcTL", data)
+					seq++
+					fctl = true
+				}
+				if i == 0 {
+					w.chunk("IDAT", c.data)
+				} else {
+					w.chunk("fdAT", append(be32(seq), c.data...))
+					seq++
+				}
+			}
+		}
+	}
+	w.chunk("IEND", nil)
+	return w.err
+}
+
+// chunkWriter writes PNG chunks, and keeps the first error.
+type chunkWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (w *chunkWriter) write(b []byte) {
+	if w.err == nil {
+		_, w.err = w.w.Write(b)
+	}
+}
+
+// chunk writes a chunk: its length, type, data and CRC.
+func (w *chunkWriter) chunk(typ string, data []byte) {
+	crc := crc32.NewIEEE()
+	crc.Write([]byte(typ))
+	crc.Write(data)
+	w.write(be32(uint32(len(data))))
+	w.write([]byte(typ))
+	w.write(data)
+	w.write(be32(crc.Sum32()))
+}
+
+type pngChunk struct {
+	typ  string
+	data []byte
+}
+
+// readChunks returns the chunks of a PNG image.
+func readChunks(b []byte) ([]pngChunk, error) {
+	if len(b) < 8 {
+		return nil, errors.New("apng: short PNG image")
+	}
+	b = b[8:] // the signature
+	var chunks []pngChunk
+	for len(b) >= 12 {
+		n := binary.BigEndian.Uint32(b)
+		if uint64(len(b)) < 12+uint64(n) {
+			return nil, errors.New("apng: truncated PNG chunk")
+		}
+		chunks = append(chunks, pngChunk{typ: string(b[4:8]), data: b[8 : 8+n]})
+		b = b[12+n:]
+	}
+	return chunks, nil
+}
+
+// be32 returns the numbers in big-endian order.
+func be32(vs ...uint32) []byte {
+	b := make([]byte, 4*len(vs))
+	for i, v := range vs {
+		binary.BigEndian.PutUint32(b[4*i:], v)
+	}
+	return b
+}
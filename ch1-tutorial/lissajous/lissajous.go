@@ -0,0 +1,81 @@
+// Package lissajous renders animations of random Lissajous figures, to
+// animated GIF or PNG images or to PNG sprite sheets.
+//
+// The Params of an animation are parsed from a query string, so that the
+// lissajous command and its HTTP handler render the same:
+//
+//	cycles=7&palette=rainbow&seed=42&format=apng
+package lissajous
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"log"
+	"math"
+	"net/http"
+)
+
+// Handler serves the animations of the parameters of the query strings.
+func Handler(w http.ResponseWriter, r *http.Request) {
+	p, err := ParseQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", p.ContentType())
+	// The seed lets the animation be served again.
+	w.Header().Set("X-Lissajous-Seed", fmt.Sprint(p.seed))
+	if err := p.Render(r.Context(), w); err != nil {
+		log.Print(err)
+	}
+}
+
+// ContentType returns the MIME type of the format of the animation.
+func (p Params) ContentType() string {
+	if p.format == "gif" {
+		return "image/gif"
+	}
+	return "image/png"
+}
+
+// Render writes the animation of the parameters to out. It stops with
+// the error of the context once it is done, like when the client of the
+// Handler is gone.
+func (p Params) Render(ctx context.Context, out io.Writer) error {
+	const res = 0.001 // angular resolution
+	palette := palettes[p.palette]
+	ncolors := len(palette) - 1 // of the curve, after the background
+	var frames []*image.Paletted
+	phase := 0.0 // phase difference
+	for i := 0; i < p.nframes; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rect := image.Rect(0, 0, 2*p.size+1, 2*p.size+1)
+		img := image.NewPaletted(rect, palette)
+		end := float64(p.cycles) * 2 * math.Pi
+		for t := 0.0; t < end; t += res {
+			x := math.Sin(t)
+			// The conversions keep the multiplications from being fused
+			// with the additions, which would change some pixels on
+			// the architectures with an FMA instruction.
+			y := math.Sin(float64(t*p.freq) + phase)
+			// The colors follow one another along the curve.
+			index := 1 + uint8(float64(ncolors)*t/end)
+			img.SetColorIndex(p.size+int(float64(x*float64(p.size))+0.5), p.size+int(float64(y*float64(p.size))+0.5),
+				index)
+		}
+		phase += 0.1
+		frames = append(frames, img)
+	}
+	switch p.format {
+	case "png":
+		return encodeSprite(out, frames)
+	case "apng":
+		return encodeAPNG(out, frames, p.delay)
+	default:
+		return encodeGIF(out, frames, p.delay)
+	}
+}
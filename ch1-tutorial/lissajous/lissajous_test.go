@@ -0,0 +1,325 @@
+package lissajous
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"flag"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/gif"
+	"image/png"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "write the golden images of testdata")
+
+// testParams are the parameters of the golden images: small, and with
+// no randomness.
+func testParams(format, palette string) Params {
+	return Params{cycles: 2, size: 20, nframes: 4, delay: 5, freq: 1.5, palette: palette, format: format}
+}
+
+// decode returns the frames of an image of the format, and their delays.
+func decode(t *testing.T, b []byte, format string) ([]image.Image, []int) {
+	t.Helper()
+	switch format {
+	case "gif":
+		anim, err := gif.DecodeAll(bytes.NewReader(b))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var frames []image.Image
+		for _, img := range anim.Image {
+			frames = append(frames, img)
+		}
+		return frames, anim.Delay
+	case "png":
+		img, err := png.Decode(bytes.NewReader(b))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return []image.Image{img}, nil
+	default:
+		frames, delays, err := decodeAPNG(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return frames, delays
+	}
+}
+
+// decodeAPNG returns the frames of an APNG image, and their delays in
+// 10ms units. It makes a PNG image of each frame, from the chunks of
+// the image and the ones of the frame, and checks the sequence numbers.
+func decodeAPNG(b []byte) ([]image.Image, []int, error) {
+	chunks, err := readChunks(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		header []pngChunk // IHDR, PLTE and tRNS
+		frames [][]pngChunk
+		delays []int
+		seq    uint32
+		n      = -1 // of acTL
+	)
+	for _, c := range chunks {
+		switch c.typ {
+		case "IHDR", "PLTE", "tRNS":
+			header = append(header, c)
+		case "acTL":
+			n = int(binary.BigEndian.Uint32(c.data))
+		case "fcTL", "fdAT":
+			if s := binary.BigEndian.Uint32(c.data); s != seq {
+				return nil, nil, fmt.Errorf("%s: sequence number %d, want %d", c.typ, s, seq)
+			}
+			seq++
+			if c.typ == "fcTL" {
+				num, den := binary.BigEndian.Uint16(c.data[20:]), binary.BigEndian.Uint16(c.data[22:])
+				delays = append(delays, int(num)*100/int(den))
+				frames = append(frames, nil)
+			} else {
+				frames[len(frames)-1] = append(frames[len(frames)-1], pngChunk{"IDAT", c.data[4:]})
+			}
+		case "IDAT":
+			if len(frames) != 1 {
+				return nil, nil, errors.New("IDAT chunk of a frame but the first")
+			}
+			frames[0] = append(frames[0], c)
+		}
+	}
+	if n != len(frames) {
+		return nil, nil, fmt.Errorf("acTL of %d frames, want %d", n, len(frames))
+	}
+	var images []image.Image
+	for _, frame := range frames {
+		var buf bytes.Buffer
+		w := &chunkWriter{w: &buf}
+		w.write([]byte("\x89PNG\r\n\x1a\n"))
+		for _, c := range append(header, frame...) {
+			w.chunk(c.typ, c.data)
+		}
+		w.chunk("IEND", nil)
+		img, err := png.Decode(&buf)
+		if err != nil {
+			return nil, nil, err
+		}
+		images = append(images, img)
+	}
+	return images, delays, nil
+}
+
+// samePixels reports whether the images have the same size and colors.
+func samePixels(a, b image.Image) bool {
+	ra, rb := a.Bounds(), b.Bounds()
+	if ra.Size() != rb.Size() {
+		return false
+	}
+	for y := 0; y < ra.Dy(); y++ {
+		for x := 0; x < ra.Dx(); x++ {
+			r0, g0, b0, a0 := a.At(ra.Min.X+x, ra.Min.Y+y).RGBA()
+			r1, g1, b1, a1 := b.At(rb.Min.X+x, rb.Min.Y+y).RGBA()
+			if r0 != r1 || g0 != g1 || b0 != b1 || a0 != a1 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// TestGolden compares the animations with the images of testdata. The
+// images are decoded, so that a change of the compression of the
+// encoders does not fail the test. Run go test -update after a change
+// of the drawing.
+func TestGolden(t *testing.T) {
+	for _, tt := range []struct {
+		format, palette, file string
+		frames                int
+	}{
+		{"gif", "bw", "lissajous.gif", 4},
+		{"png", "rainbow", "sprite.png", 1},
+		{"apng", "rainbow", "lissajous.apng", 4},
+	} {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := testParams(tt.format, tt.palette).Render(context.Background(), &buf); err != nil {
+				t.Fatal(err)
+			}
+			golden := filepath.Join("testdata", tt.file)
+			if *update {
+				if err := os.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, delays := decode(t, buf.Bytes(), tt.format)
+			wantFrames, _ := decode(t, want, tt.format)
+			if len(got) != tt.frames || len(wantFrames) != tt.frames {
+				t.Fatalf("%d frames, and %d in %s, want %d", len(got), len(wantFrames), golden, tt.frames)
+			}
+			for i := range got {
+				if !samePixels(got[i], wantFrames[i]) {
+					t.Errorf("frame %d differs from %s", i, golden)
+				}
+			}
+			for i, d := range delays {
+				if d != 5 {
+					t.Errorf("frame %d: delay %d, want 5", i, d)
+				}
+			}
+		})
+	}
+}
+
+func TestSprite(t *testing.T) {
+	var anim, sprite bytes.Buffer
+	if err := testParams("apng", "rainbow").Render(context.Background(), &anim); err != nil {
+		t.Fatal(err)
+	}
+	if err := testParams("png", "rainbow").Render(context.Background(), &sprite); err != nil {
+		t.Fatal(err)
+	}
+	frames, _ := decode(t, anim.Bytes(), "apng")
+	sheet, _ := decode(t, sprite.Bytes(), "png")
+	for i, frame := range frames {
+		r := frame.Bounds().Add(image.Pt(i*frame.Bounds().Dx(), 0))
+		if !samePixels(frame, sheet[0].(*image.Paletted).SubImage(r)) {
+			t.Errorf("sprite %d differs from frame %d", i, i)
+		}
+	}
+	if w := sheet[0].Bounds().Dx(); w != 4*41 {
+		t.Errorf("sprite sheet %d pixels wide, want 4 frames of 41", w)
+	}
+}
+
+// TestHandlerCanceled checks that the animation of a request whose
+// client is gone is not rendered.
+func TestHandlerCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r := httptest.NewRequest("GET", "/lissajous?size=250&nframes=30&cycles=20", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+	start := time.Now()
+	Handler(w, r)
+	if w.Body.Len() != 0 {
+		t.Errorf("%d bytes served after the cancellation", w.Body.Len())
+	}
+	if d := time.Since(start); d > 100*time.Millisecond {
+		t.Errorf("rendered for %v after the cancellation", d)
+	}
+	if err := testParams("gif", "bw").Render(ctx, io.Discard); !errors.Is(err, context.Canceled) {
+		t.Errorf("Render = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestAPNGChunks(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testParams("apng", "rainbow").Render(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+	if !bytes.HasPrefix(b, []byte("\x89PNG\r\n\x1a\n")) {
+		t.Fatalf("signature = %q", b[:8])
+	}
+	chunks, err := readChunks(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The IDAT and fdAT chunks are counted once per frame.
+	var order []string
+	for _, c := range chunks {
+		if n := len(order); n > 0 && c.typ == order[n-1] && (c.typ == "IDAT" || c.typ == "fdAT") {
+			continue
+		}
+		order = append(order, c.typ)
+	}
+	want := "[IHDR acTL PLTE fcTL IDAT fcTL fdAT fcTL fdAT fcTL fdAT IEND]"
+	if fmt.Sprint(order) != want {
+		t.Errorf("chunks = %v, want %v", order, want)
+	}
+	if actl := chunks[1].data; !bytes.Equal(actl, be32(4, 0)) {
+		t.Errorf("acTL = %x, want 4 frames played forever", actl)
+	}
+	// Each chunk ends with the CRC of its type and data.
+	for rest := b[8:]; len(rest) > 0; {
+		n := binary.BigEndian.Uint32(rest)
+		if got, want := binary.BigEndian.Uint32(rest[8+n:]), crc32.ChecksumIEEE(rest[4:8+n]); got != want {
+			t.Errorf("%s: CRC %08x, want %08x", rest[4:8], got, want)
+		}
+		rest = rest[12+n:]
+	}
+	// image/png skips the APNG chunks, and decodes the first frame.
+	img, err := png.Decode(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	frames, _ := decode(t, b, "apng")
+	if !samePixels(img, frames[0]) {
+		t.Error("image/png decodes another image than the first frame")
+	}
+}
+
+// failWriter fails its writes after n bytes.
+type failWriter struct{ n int }
+
+var errFull = errors.New("full")
+
+func (w *failWriter) Write(b []byte) (int, error) {
+	if len(b) > w.n {
+		return 0, errFull
+	}
+	w.n -= len(b)
+	return len(b), nil
+}
+
+func TestChunkWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := &chunkWriter{w: &buf}
+	w.chunk("tEXt", []byte("a"))
+	w.chunk("IEND", nil)
+	// The CRC of IEND with no data is the one of every PNG image.
+	want := "\x00\x00\x00\x01tEXta\x75\xf3\x8b\x29\x00\x00\x00\x00IEND\xae\x42\x60\x82"
+	if w.err != nil || buf.String() != want {
+		t.Errorf("chunks = %q, %v, want %q", buf.String(), w.err, want)
+	}
+
+	fw := &failWriter{n: 10}
+	w = &chunkWriter{w: fw}
+	w.chunk("tEXt", []byte("abc"))
+	w.chunk("IEND", nil)
+	if w.err != errFull || fw.n != 2 {
+		t.Errorf("err = %v after %d bytes, want %v after the length and the type", w.err, 10-fw.n, errFull)
+	}
+	if err := encodeAPNG(&failWriter{n: 100}, []*image.Paletted{image.NewPaletted(image.Rect(0, 0, 2, 2), palettes["bw"])}, 1); err != errFull {
+		t.Errorf("encodeAPNG = %v, want %v", err, errFull)
+	}
+}
+
+func TestReadChunks(t *testing.T) {
+	var buf bytes.Buffer
+	w := &chunkWriter{w: &buf}
+	w.write([]byte("\x89PNG\r\n\x1a\n"))
+	w.chunk("IHDR", []byte("header"))
+	w.chunk("IEND", nil)
+	b := buf.Bytes()
+	chunks, err := readChunks(b)
+	if err != nil || len(chunks) != 2 || chunks[0].typ != "IHDR" || string(chunks[0].data) != "header" || chunks[1].typ != "IEND" {
+		t.Errorf("chunks = %q, %v", chunks, err)
+	}
+	for _, b := range [][]byte{b[:4], b[:20]} {
+		if _, err := readChunks(b); err == nil {
+			t.Errorf("read the chunks of %d bytes", len(b))
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package lissajous
+
+import (
+	"image/color"
+	"math"
+)
+
+// palettes are the palettes of the animations, by name. The first color
+// is the background, and the curve is drawn with the others, in turn
+// along its length.
+var palettes = map[string]color.Palette{
+	"bw":      {color.White, color.Black},
+	"green":   {color.Black, color.RGBA{0x00, 0xff, 0x00, 0xff}},
+	"rainbow": append(color.Palette{color.Black}, hues(12)...),
+	"fire":    append(color.Palette{color.Black}, gradient(16, 0x80, 0x00, 0x00, 0xff, 0xff, 0x80)...),
+}
+
+// hues returns n colors of full saturation, around the color wheel.
+func hues(n int) []color.Color {
+	var cs []color.Color
+	for i := 0; i < n; i++ {
+		h := float64(i) / float64(n) * 6
+		x := uint8(255 * (1 - math.Abs(math.Mod(h, 2)-1)))
+		switch int(h) {
+		case 0:
+			cs = append(cs, color.RGBA{255, x, 0, 255})
+		case 1:
+			cs = append(cs, color.RGBA{x, 255, 0, 255})
+		case 2:
+			cs = append(cs, color.RGBA{0, 255, x, 255})
+		case 3:
+			cs = append(cs, color.RGBA{0, x, 255, 255})
+		case 4:
+			cs = append(cs, color.RGBA{x, 0, 255, 255})
+		default:
+			cs = append(cs, color.RGBA{255, 0, x, 255})
+		}
+	}
+	return cs
+}
+
+// gradient returns n colors going from r0, g0, b0 to r1, g1, b1.
+func gradient(n int, r0, g0, b0, r1, g1, b1 uint8) []color.Color {
+	lerp := func(a, b uint8, t float64) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*t + 0.5)
+	}
+	var cs []color.Color
+	for i := 0; i < n; i++ {
+		t := float64(i) / float64(n-1)
+		cs = append(cs, color.RGBA{lerp(r0, r1, t), lerp(g0, g1, t), lerp(b0, b1, t), 255})
+	}
+	return cs
+}
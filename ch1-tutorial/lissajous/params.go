@@ -0,0 +1,104 @@
+package lissajous
+
+import (
+	"fmt"
+	"math/rand"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Params are the parameters of an animation.
+type Params struct {
+	cycles  int     // number of complete x oscillator revolutions
+	size    int     // image canvas covers [-size..+size]
+	nframes int     // number of animation frames
+	delay   int     // delay between frames in 10ms units
+	freq    float64 // relative frequency of y oscillator
+	palette string  // name of the palette, in palettes
+	seed    int64   // seed of the random frequency
+	format  string  // gif, png (a sprite of the frames) or apng
+}
+
+// maxPixels bounds the pixels of all the frames of an animation.
+const maxPixels = 10 << 20
+
+// DefaultParams returns the parameters of the original program. The
+// frequency is chosen at random, from the seed.
+func DefaultParams(seed int64) Params {
+	return Params{
+		cycles:  5,
+		size:    100,
+		nframes: 64,
+		delay:   8,
+		freq:    rand.New(rand.NewSource(seed)).Float64() * 3.0,
+		palette: "bw",
+		seed:    seed,
+		format:  "gif",
+	}
+}
+
+// ParseQuery returns the parameters of the query string, like
+//
+//	cycles=5&size=100&nframes=64&delay=8&freq=1.5&palette=rainbow&seed=42&format=apng
+//
+// The parameters missing have the default values. Without freq, the
+// frequency is chosen from the seed, so that the same seed gives the
+// same animation.
+func ParseQuery(q url.Values) (Params, error) {
+	seed := time.Now().UnixNano()
+	if s := q.Get("seed"); s != "" {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return Params{}, fmt.Errorf("seed: %q is not an integer", s)
+		}
+		seed = n
+	}
+	p := DefaultParams(seed)
+	for _, f := range []struct {
+		name     string
+		v        *int
+		min, max int
+	}{
+		{"cycles", &p.cycles, 1, 20},
+		{"size", &p.size, 10, 500},
+		{"nframes", &p.nframes, 1, 200},
+		{"delay", &p.delay, 0, 1000},
+	} {
+		s := q.Get(f.name)
+		if s == "" {
+			continue
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return Params{}, fmt.Errorf("%s: %q is not an integer", f.name, s)
+		}
+		if n < f.min || n > f.max {
+			return Params{}, fmt.Errorf("%s: %d is not in [%d, %d]", f.name, n, f.min, f.max)
+		}
+		*f.v = n
+	}
+	if s := q.Get("freq"); s != "" {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil || f < 0 || f > 10 {
+			return Params{}, fmt.Errorf("freq: %q is not a number in [0, 10]", s)
+		}
+		p.freq = f
+	}
+	if s := q.Get("palette"); s != "" {
+		if _, ok := palettes[s]; !ok {
+			return Params{}, fmt.Errorf("palette: unknown palette %q", s)
+		}
+		p.palette = s
+	}
+	if s := q.Get("format"); s != "" {
+		if s != "gif" && s != "png" && s != "apng" {
+			return Params{}, fmt.Errorf("format: unknown format %q", s)
+		}
+		p.format = s
+	}
+	if side := 2*p.size + 1; side*side*p.nframes > maxPixels {
+		return Params{}, fmt.Errorf("%d frames of size %d are too many pixels", p.nframes, p.size)
+	}
+	return p, nil
+}
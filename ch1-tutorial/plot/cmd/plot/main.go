@@ -0,0 +1,58 @@
+// Plot renders a curve or a fractal of the plot package to the
+// standard output. Its arguments are the parameters of the query
+// string of plot.ParseQuery:
+//
+//	plot kind=julia cr=-0.4 ci=0.6 colormap=ocean > julia.png
+//	plot kind=rose k=2.5 turns=2 format=svg > rose.svg
+//
+// With -http, it serves the images instead, at /plot:
+//
+//	plot -http localhost:8000
+//	curl 'localhost:8000/plot?kind=mandelbrot&zoom=20&x=-0.75&y=0.1' > m.png
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/anjanashankar9/go-learning/ch1-tutorial/plot"
+	"github.com/anjanashankar9/go-learning/ch1-tutorial/server/httpserver"
+)
+
+var addr = flag.String("http", "", "address to serve the images on, at /plot")
+
+func main() {
+	flag.Parse()
+	if *addr != "" {
+		srv := httpserver.New(httpserver.Config{Addr: *addr})
+		srv.HandleFunc("/plot", plot.Handler)
+		if err := srv.ListenAndServe(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	q := url.Values{}
+	for _, arg := range flag.Args() {
+		name, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			log.Fatalf("plot: %q is not name=value", arg)
+		}
+		q.Set(name, value)
+	}
+	s, err := plot.ParseQuery(q)
+	if err != nil {
+		log.Fatalf("plot: %v", err)
+	}
+	w := bufio.NewWriter(os.Stdout)
+	if err := s.Render(context.Background(), w); err != nil {
+		log.Fatalf("plot: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		log.Fatalf("plot: %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package plot
+
+import (
+	"image/color"
+	"math"
+)
+
+// A ColorMap maps a value in [0, 1] to a color.
+type ColorMap func(v float64) color.RGBA
+
+// ColorMaps are the color maps, by name.
+var ColorMaps = map[string]ColorMap{
+	"grey":    Gradient(color.RGBA{0x20, 0x20, 0x20, 0xff}, color.RGBA{0xff, 0xff, 0xff, 0xff}),
+	"fire":    Gradient(color.RGBA{0x00, 0x00, 0x00, 0xff}, color.RGBA{0xc0, 0x10, 0x00, 0xff}, color.RGBA{0xff, 0xc0, 0x00, 0xff}, color.RGBA{0xff, 0xff, 0xff, 0xff}),
+	"ocean":   Gradient(color.RGBA{0x00, 0x08, 0x30, 0xff}, color.RGBA{0x00, 0x60, 0xc0, 0xff}, color.RGBA{0x40, 0xe0, 0xff, 0xff}, color.RGBA{0xff, 0xff, 0xff, 0xff}),
+	"rainbow": Rainbow,
+}
+
+// Gradient returns a color map going through the colors, evenly spaced.
+func Gradient(stops ...color.RGBA) ColorMap {
+	return func(v float64) color.RGBA {
+		v = clamp(v) * float64(len(stops)-1)
+		i := int(v)
+		if i >= len(stops)-1 {
+			return stops[len(stops)-1]
+		}
+		return lerp(stops[i], stops[i+1], v-float64(i))
+	}
+}
+
+// Rainbow maps the values around the color wheel.
+func Rainbow(v float64) color.RGBA {
+	h := clamp(v) * 6
+	x := uint8(255 * (1 - math.Abs(math.Mod(h, 2)-1)))
+	switch int(h) {
+	case 0:
+		return color.RGBA{255, x, 0, 255}
+	case 1:
+		return color.RGBA{x, 255, 0, 255}
+	case 2:
+		return color.RGBA{0, 255, x, 255}
+	case 3:
+		return color.RGBA{0, x, 255, 255}
+	case 4:
+		return color.RGBA{x, 0, 255, 255}
+	default:
+		return color.RGBA{255, 0, x, 255}
+	}
+}
+
+func lerp(a, b color.RGBA, t float64) color.RGBA {
+	f := func(x, y uint8) uint8 {
+		return uint8(float64(x) + (float64(y)-float64(x))*t + 0.5)
+	}
+	return color.RGBA{f(a.R, b.R), f(a.G, b.G), f(a.B, b.B), f(a.A, b.A)}
+}
+
+func clamp(v float64) float64 {
+	switch {
+	case v < 0 || math.IsNaN(v):
+		return 0
+	case v > 1:
+		return 1
+	}
+	return v
+}
@@ -0,0 +1,181 @@
+package plot
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"math"
+)
+
+// A Curve is a parametric curve, the points Point(t) for t in [0, T).
+// The points are in [-1, 1]×[-1, 1], y going up.
+type Curve struct {
+	Point func(t float64) (x, y float64)
+	T     float64
+}
+
+// Lissajous returns the curve of the lissajous program:
+// x = sin(t), y = sin(freq·t + phase), for the number of cycles.
+func Lissajous(freq, phase float64, cycles int) Curve {
+	return Curve{
+		Point: func(t float64) (float64, float64) {
+			return math.Sin(t), math.Sin(t*freq + phase)
+		},
+		T: float64(cycles) * 2 * math.Pi,
+	}
+}
+
+// Rose returns the rose r = cos(k·θ), for the number of turns.
+func Rose(k float64, turns int) Curve {
+	return Curve{
+		Point: func(t float64) (float64, float64) {
+			r := math.Cos(k * t)
+			return r * math.Cos(t), r * math.Sin(t)
+		},
+		T: float64(turns) * 2 * math.Pi,
+	}
+}
+
+// Hypotrochoid returns the curve drawn by a spirograph: a point at
+// distance d of the center of a circle of radius r, rolling inside
+// a circle of radius R. The curve closes after r/gcd(R, r) turns.
+func Hypotrochoid(R, r, d int) Curve {
+	k := float64(R-r) / float64(r)
+	scale := float64(abs(R-r) + abs(d))
+	return Curve{
+		Point: func(t float64) (float64, float64) {
+			x := float64(R-r)*math.Cos(t) + float64(d)*math.Cos(k*t)
+			y := float64(R-r)*math.Sin(t) - float64(d)*math.Sin(k*t)
+			return x / scale, y / scale
+		},
+		T: float64(r/gcd(R, r)) * 2 * math.Pi,
+	}
+}
+
+// points calls f with points of the curve in pixels of an image of
+// width w and height h, close enough to draw it as a line: successive
+// points are less than step pixels apart. The curve fills the image
+// but for a margin, with its aspect ratio kept. The value passed to f
+// is t/T. It stops with the error of the context once it is done.
+func (c Curve) points(ctx context.Context, w, h int, step float64, f func(x, y, v float64)) error {
+	half := float64(min(w, h))/2 - 1
+	cx, cy := float64(w)/2, float64(h)/2
+	toPixel := func(t float64) (float64, float64) {
+		x, y := c.Point(t)
+		return cx + x*half, cy - y*half
+	}
+	// The step of t is adapted to the speed of the curve.
+	dt := c.T / 1000
+	x, y := toPixel(0)
+	f(x, y, 0)
+	for t, n := 0.0, 0; t < c.T; n++ {
+		if n%4096 == 0 && ctx.Err() != nil {
+			return ctx.Err()
+		}
+		nx, ny := toPixel(t + dt)
+		d := math.Hypot(nx-x, ny-y)
+		if d > step && dt > 1e-9 {
+			dt /= 2
+			continue
+		}
+		t += dt
+		x, y = nx, ny
+		f(x, y, t/c.T)
+		if d < step/4 {
+			dt *= 2
+		}
+	}
+	return nil
+}
+
+// RenderCurve draws the curve on an image, colored by its color map along
+// its length. With Samples, it is drawn on a larger image, averaged to
+// the size of the options for anti-aliasing. It stops with the error
+// of the context once it is done.
+func RenderCurve(ctx context.Context, c Curve, o Options) (*image.RGBA, error) {
+	o = o.withDefaults()
+	s := o.Samples
+	w, h := o.Width*s, o.Height*s
+	// The value of the color map of each subpixel the curve goes through.
+	values := make([]float32, w*h)
+	for i := range values {
+		values[i] = -1
+	}
+	err := c.points(ctx, w, h, 0.5, func(x, y, v float64) {
+		// A brush of a pixel, once averaged.
+		for dy := 0; dy < s; dy++ {
+			for dx := 0; dx < s; dx++ {
+				px, py := int(x)+dx-s/2, int(y)+dy-s/2
+				if px >= 0 && px < w && py >= 0 && py < h {
+					values[py*w+px] = float32(v)
+				}
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, o.Width, o.Height))
+	bg := rgba(o.Background)
+	err = tiles(ctx, img.Bounds(), o, func(r image.Rectangle) {
+		for py := r.Min.Y; py < r.Max.Y; py++ {
+			for px := r.Min.X; px < r.Max.X; px++ {
+				var acc [4]float64
+				for sy := 0; sy < s; sy++ {
+					for sx := 0; sx < s; sx++ {
+						col := bg
+						if v := values[(py*s+sy)*w+px*s+sx]; v >= 0 {
+							col = o.ColorMap(float64(v))
+						}
+						add(&acc, col)
+					}
+				}
+				img.SetRGBA(px, py, average(acc, s*s))
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+func rgba(c color.Color) color.RGBA {
+	r, g, b, a := c.RGBA()
+	return color.RGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), uint8(a >> 8)}
+}
+
+func add(acc *[4]float64, c color.RGBA) {
+	acc[0] += float64(c.R)
+	acc[1] += float64(c.G)
+	acc[2] += float64(c.B)
+	acc[3] += float64(c.A)
+}
+
+func average(acc [4]float64, n int) color.RGBA {
+	f := func(v float64) uint8 { return uint8(v/float64(n) + 0.5) }
+	return color.RGBA{f(acc[0]), f(acc[1]), f(acc[2]), f(acc[3])}
+}
+
+func gcd(a, b int) int {
+	a, b = abs(a), abs(b)
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,78 @@
+package plot
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+)
+
+// Encode writes the image in the format: gif, png or svg. The GIF image
+// is dithered to the Plan 9 palette, and the SVG image embeds the PNG.
+func Encode(out io.Writer, img image.Image, format string) error {
+	switch format {
+	case "gif":
+		return gif.Encode(out, img, &gif.Options{NumColors: 256, Drawer: draw.FloydSteinberg})
+	case "png":
+		return png.Encode(out, img)
+	case "svg":
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			return err
+		}
+		b := img.Bounds()
+		_, err := fmt.Fprintf(out, "<svg xmlns='http://www.w3.org/2000/svg' width='%d' height='%d'>\n"+
+			"<image width='%d' height='%d' href='data:image/png;base64,%s'/>\n</svg>\n",
+			b.Dx(), b.Dy(), b.Dx(), b.Dy(), base64.StdEncoding.EncodeToString(buf.Bytes()))
+		return err
+	}
+	return fmt.Errorf("plot: unknown format %q", format)
+}
+
+// svgBands is the number of colors of a curve in SVG,
+// each a polyline of a part of the curve.
+const svgBands = 64
+
+// EncodeCurveSVG writes the curve as SVG polylines, colored by the color
+// map along its length. Unlike Encode, the curve is drawn as vectors.
+// It stops with the error of the context once it is done.
+func EncodeCurveSVG(ctx context.Context, out io.Writer, c Curve, o Options) error {
+	o = o.withDefaults()
+	w := bufio.NewWriter(out)
+	bg := rgba(o.Background)
+	fmt.Fprintf(w, "<svg xmlns='http://www.w3.org/2000/svg' width='%d' height='%d'>\n", o.Width, o.Height)
+	fmt.Fprintf(w, "<rect width='100%%' height='100%%' fill='#%02x%02x%02x'/>\n", bg.R, bg.G, bg.B)
+	band := -1
+	var lastX, lastY float64
+	err := c.points(ctx, o.Width, o.Height, 1, func(x, y, v float64) {
+		b := int(v * svgBands)
+		if b >= svgBands {
+			b = svgBands - 1
+		}
+		if b != band {
+			if band >= 0 {
+				fmt.Fprint(w, "'/>\n")
+			}
+			col := o.ColorMap((float64(b) + 0.5) / svgBands)
+			fmt.Fprintf(w, "<polyline fill='none' stroke='#%02x%02x%02x' points='", col.R, col.G, col.B)
+			// Each band starts where the last one ended.
+			if band >= 0 {
+				fmt.Fprintf(w, "%.1f,%.1f ", lastX, lastY)
+			}
+			band = b
+		}
+		fmt.Fprintf(w, "%.1f,%.1f ", x, y)
+		lastX, lastY = x, y
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(w, "'/>\n</svg>\n")
+	return w.Flush()
+}
@@ -0,0 +1,97 @@
+package plot
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"math"
+	"math/cmplx"
+)
+
+// A Fractal is an escape-time fractal: the points whose sequence z
+// stays bounded. Escape returns, for a point, how fast it escapes,
+// in [0, 1], or -1 if it does not.
+type Fractal struct {
+	Escape func(p complex128) float64
+	Center complex128 // of the default view
+	Width  float64    // of the default view
+}
+
+// escapeRadius is large for the smooth coloring to be smooth.
+const escapeRadius = 256
+
+// Mandelbrot returns the Mandelbrot set: the points c for
+// which z = z² + c stays bounded, starting from 0.
+func Mandelbrot(maxIter int) Fractal {
+	return Fractal{
+		Escape: func(c complex128) float64 { return escape(0, c, maxIter) },
+		Center: -0.5,
+		Width:  3,
+	}
+}
+
+// Julia returns the Julia set of c: the points z for
+// which z = z² + c stays bounded.
+func Julia(c complex128, maxIter int) Fractal {
+	return Fractal{
+		Escape: func(z complex128) float64 { return escape(z, c, maxIter) },
+		Center: 0,
+		Width:  3.2,
+	}
+}
+
+// escape iterates z = z² + c, and returns the smoothed number of
+// iterations before |z| exceeds escapeRadius, scaled to [0, 1].
+func escape(z, c complex128, maxIter int) float64 {
+	for n := 0; n < maxIter; n++ {
+		z = z*z + c
+		if r := cmplx.Abs(z); r > escapeRadius {
+			// The fractional part makes the bands of colors continuous.
+			nu := float64(n) + 1 - math.Log2(math.Log(r))
+			return clamp(nu / float64(maxIter))
+		}
+	}
+	return -1
+}
+
+// RenderFractal renders the fractal, with each pixel averaged over
+// Samples×Samples points. The points that do not escape are black, and
+// the others are colored by the color map. The view is centered on
+// center, and is width wide; the default view of the fractal is
+// used if width is 0. It stops with the error of the context once it
+// is done.
+func RenderFractal(ctx context.Context, f Fractal, center complex128, width float64, o Options) (*image.RGBA, error) {
+	o = o.withDefaults()
+	if width == 0 {
+		center, width = f.Center, f.Width
+	}
+	img := image.NewRGBA(image.Rect(0, 0, o.Width, o.Height))
+	s := o.Samples
+	pixel := width / float64(o.Width) // size of a pixel in the plane
+	x0 := real(center) - width/2
+	y0 := imag(center) + pixel*float64(o.Height)/2
+	black := color.RGBA{0, 0, 0, 0xff}
+	err := tiles(ctx, img.Bounds(), o, func(r image.Rectangle) {
+		for py := r.Min.Y; py < r.Max.Y; py++ {
+			for px := r.Min.X; px < r.Max.X; px++ {
+				var acc [4]float64
+				for sy := 0; sy < s; sy++ {
+					for sx := 0; sx < s; sx++ {
+						x := x0 + (float64(px)+(float64(sx)+0.5)/float64(s))*pixel
+						y := y0 - (float64(py)+(float64(sy)+0.5)/float64(s))*pixel
+						col := black
+						if v := f.Escape(complex(x, y)); v >= 0 {
+							col = o.ColorMap(v)
+						}
+						add(&acc, col)
+					}
+				}
+				img.SetRGBA(px, py, average(acc, s*s))
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return img, nil
+}
@@ -0,0 +1,250 @@
+// Package plot renders parametric curves (Lissajous figures, roses and
+// hypotrochoids) and escape-time fractals (the Mandelbrot and Julia sets)
+// to GIF, PNG or SVG images.
+//
+// The images are supersampled for anti-aliasing, and rendered in tiles
+// by concurrent goroutines. A Spec is what to render, parsed from a query
+// string, so that the plot command and its HTTP handler render the same:
+//
+//	kind=mandelbrot&x=-0.745&y=0.11&zoom=50&iter=500&colormap=ocean
+//	kind=hypotrochoid&R=7&r=4&d=6&format=svg
+package plot
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// A Spec is an image to render.
+type Spec struct {
+	Kind    string // lissajous, rose, hypotrochoid, mandelbrot or julia
+	Format  string // gif, png or svg
+	Options Options
+
+	curve   Curve
+	fractal Fractal
+	center  complex128 // of the view of the fractal
+	width   float64    // of the view of the fractal, 0 for the default
+}
+
+// maxSamples bounds the samples of an image, Width×Height×Samples²,
+// and maxIterations the iterations of a fractal, the samples times iter.
+const (
+	maxSamples    = 4 << 20
+	maxIterations = 2 << 30
+)
+
+// ParseQuery returns the spec of the query string. The parameters are
+//
+//	kind      lissajous, rose, hypotrochoid, mandelbrot (default) or julia
+//	width     of the image, in pixels (512)
+//	height    of the image, in pixels (width)
+//	samples   in each direction of a pixel, for anti-aliasing (2)
+//	colormap  grey, fire (default), ocean or rainbow
+//	format    gif, png (default) or svg
+//
+// and for the kinds
+//
+//	lissajous     freq (1.5), phase (0) and cycles (5)
+//	rose          k (4) and turns (1)
+//	hypotrochoid  R (5), r (3) and d (5)
+//	mandelbrot    iter (200), and the view: x, y (its center) and zoom (1)
+//	julia         cr and ci (-0.8, 0.156), the real and imaginary parts
+//	              of c, iter and the view
+//
+// The parameters missing have the default values.
+func ParseQuery(q url.Values) (*Spec, error) {
+	s := &Spec{Kind: "mandelbrot", Format: "png"}
+	if k := q.Get("kind"); k != "" {
+		s.Kind = k
+	}
+	if f := q.Get("format"); f != "" {
+		s.Format = f
+	}
+	switch s.Format {
+	case "gif", "png", "svg":
+	default:
+		return nil, fmt.Errorf("format: %q is not gif, png or svg", s.Format)
+	}
+
+	o := Options{Width: 512, Samples: 2}
+	cycles, turns, iter := 5, 1, 200
+	R, r, d := 5, 3, 5
+	for _, f := range []struct {
+		name     string
+		v        *int
+		min, max int
+	}{
+		{"width", &o.Width, 16, 2048},
+		{"height", &o.Height, 16, 2048},
+		{"samples", &o.Samples, 1, 8},
+		{"cycles", &cycles, 1, 20},
+		{"turns", &turns, 1, 100},
+		{"iter", &iter, 1, 2000},
+		{"R", &R, 1, 100},
+		{"r", &r, 1, 100},
+		{"d", &d, 0, 100},
+	} {
+		if err := parseInt(q, f.name, f.v, f.min, f.max); err != nil {
+			return nil, err
+		}
+	}
+	if o.Height == 0 {
+		o.Height = o.Width
+	}
+	if o.Width*o.Height*o.Samples*o.Samples > maxSamples {
+		return nil, fmt.Errorf("%d×%d pixels of %d² samples is too large", o.Width, o.Height, o.Samples)
+	}
+	cm := "fire"
+	if name := q.Get("colormap"); name != "" {
+		cm = name
+	}
+	if o.ColorMap = ColorMaps[cm]; o.ColorMap == nil {
+		return nil, fmt.Errorf("colormap: %q is not one of %v", cm, colorMapNames())
+	}
+
+	freq, phase, k := 1.5, 0.0, 4.0
+	x, y, zoom := 0.0, 0.0, 1.0
+	cr, ci := -0.8, 0.156
+	for _, f := range []struct {
+		name     string
+		v        *float64
+		min, max float64
+	}{
+		{"freq", &freq, 0, 10},
+		{"phase", &phase, -100, 100},
+		{"k", &k, 0, 100},
+		{"x", &x, -4, 4},
+		{"y", &y, -4, 4},
+		{"zoom", &zoom, 1e-3, 1e13},
+		{"cr", &cr, -4, 4},
+		{"ci", &ci, -4, 4},
+	} {
+		if err := parseFloat(q, f.name, f.v, f.min, f.max); err != nil {
+			return nil, err
+		}
+	}
+	s.Options = o
+
+	switch s.Kind {
+	case "lissajous":
+		s.curve = Lissajous(freq, phase, cycles)
+	case "rose":
+		s.curve = Rose(k, turns)
+	case "hypotrochoid":
+		if R == r {
+			return nil, fmt.Errorf("R and r are both %d: the point does not move", R)
+		}
+		s.curve = Hypotrochoid(R, r, d)
+	case "mandelbrot", "julia":
+		if samples := o.Width * o.Height * o.Samples * o.Samples; samples*iter > maxIterations {
+			return nil, fmt.Errorf("%d samples of %d iterations is too many", samples, iter)
+		}
+		if s.Kind == "mandelbrot" {
+			s.fractal = Mandelbrot(iter)
+		} else {
+			s.fractal = Julia(complex(cr, ci), iter)
+		}
+		// The view is the default one, unless moved or zoomed.
+		if q.Get("x") != "" || q.Get("y") != "" || q.Get("zoom") != "" {
+			if q.Get("x") == "" && q.Get("y") == "" {
+				x, y = real(s.fractal.Center), imag(s.fractal.Center)
+			}
+			s.center, s.width = complex(x, y), s.fractal.Width/zoom
+		}
+	default:
+		return nil, fmt.Errorf("kind: %q is not lissajous, rose, hypotrochoid, mandelbrot or julia", s.Kind)
+	}
+	return s, nil
+}
+
+func parseInt(q url.Values, name string, v *int, min, max int) error {
+	s := q.Get(name)
+	if s == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("%s: %q is not an integer", name, s)
+	}
+	if n < min || n > max {
+		return fmt.Errorf("%s: %d is not in [%d, %d]", name, n, min, max)
+	}
+	*v = n
+	return nil
+}
+
+func parseFloat(q url.Values, name string, v *float64, min, max float64) error {
+	s := q.Get(name)
+	if s == "" {
+		return nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("%s: %q is not a number", name, s)
+	}
+	if !(f >= min && f <= max) {
+		return fmt.Errorf("%s: %g is not in [%g, %g]", name, f, min, max)
+	}
+	*v = f
+	return nil
+}
+
+func colorMapNames() []string {
+	var names []string
+	for name := range ColorMaps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ContentType returns the MIME type of the format of the spec.
+func (s *Spec) ContentType() string {
+	if s.Format == "svg" {
+		return "image/svg+xml"
+	}
+	return "image/" + s.Format
+}
+
+// Render writes the image of the spec to out. It stops with the error
+// of the context once it is done, like when the client of the Handler
+// is gone.
+func (s *Spec) Render(ctx context.Context, out io.Writer) error {
+	var (
+		img image.Image
+		err error
+	)
+	switch {
+	case s.curve.Point != nil && s.Format == "svg":
+		return EncodeCurveSVG(ctx, out, s.curve, s.Options)
+	case s.curve.Point != nil:
+		img, err = RenderCurve(ctx, s.curve, s.Options)
+	default:
+		img, err = RenderFractal(ctx, s.fractal, s.center, s.width, s.Options)
+	}
+	if err != nil {
+		return err
+	}
+	return Encode(out, img, s.Format)
+}
+
+// Handler serves the images of the specs of the query strings.
+func Handler(w http.ResponseWriter, r *http.Request) {
+	s, err := ParseQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", s.ContentType())
+	if err := s.Render(r.Context(), w); err != nil {
+		log.Print(err)
+	}
+}
@@ -0,0 +1,202 @@
+package plot
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseQueryLimits(t *testing.T) {
+	for _, q := range []string{
+		"width=4096",
+		"width=2048&samples=2",
+		"iter=5000",
+		"width=1024&samples=2&iter=2000",
+		"kind=julia&width=1024&samples=2&iter=600",
+	} {
+		v, _ := url.ParseQuery(q)
+		if _, err := ParseQuery(v); err == nil {
+			t.Errorf("parsed %s, too large", q)
+		}
+	}
+	for _, q := range []string{"", "width=2048&samples=1", "width=512&iter=2000", "kind=rose&width=1024&samples=2&turns=100"} {
+		v, _ := url.ParseQuery(q)
+		if _, err := ParseQuery(v); err != nil {
+			t.Errorf("%s: %v", q, err)
+		}
+	}
+}
+
+func TestRenderCanceled(t *testing.T) {
+	for _, q := range []string{
+		"kind=mandelbrot&width=512&samples=2&iter=1000",
+		"kind=rose&width=1024&samples=2&turns=100",
+		"kind=rose&width=1024&turns=100&format=svg",
+	} {
+		v, _ := url.ParseQuery(q)
+		s, err := ParseQuery(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		start := time.Now()
+		if err := s.Render(ctx, io.Discard); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: Render = %v, want %v", q, err, context.Canceled)
+		}
+		if d := time.Since(start); d > 100*time.Millisecond {
+			t.Errorf("%s: rendered for %v after the cancellation", q, d)
+		}
+	}
+}
+
+func TestTilesCanceled(t *testing.T) {
+	o := Options{Tile: 16, Workers: 1}.withDefaults()
+	ctx, cancel := context.WithCancel(context.Background())
+	n := 0
+	err := tiles(ctx, image.Rect(0, 0, 256, 256), o, func(r image.Rectangle) {
+		n++
+		if n == 4 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) || n >= 256 {
+		t.Errorf("tiles = %v after %d of 256 tiles, want to stop when canceled", err, n)
+	}
+}
+
+// TestWorkersSamePixels checks that the tiles rendered concurrently
+// make the image rendered by a single goroutine.
+func TestWorkersSamePixels(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		render func(o Options) (*image.RGBA, error)
+	}{
+		{"mandelbrot", func(o Options) (*image.RGBA, error) {
+			return RenderFractal(context.Background(), Mandelbrot(100), -0.75+0.1i, 0.5, o)
+		}},
+		{"julia", func(o Options) (*image.RGBA, error) {
+			return RenderFractal(context.Background(), Julia(-0.8+0.156i, 100), 0, 0, o)
+		}},
+		{"rose", func(o Options) (*image.RGBA, error) {
+			return RenderCurve(context.Background(), Rose(2.5, 2), o)
+		}},
+	} {
+		o := Options{Width: 150, Height: 100, Samples: 2, ColorMap: Rainbow, Tile: 16}
+		o.Workers = 1
+		want, err := tt.render(o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		o.Workers = 8
+		got, err := tt.render(o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got.Pix, want.Pix) || got.Rect != want.Rect {
+			t.Errorf("%s: the image of 8 workers differs from the one of 1", tt.name)
+		}
+	}
+}
+
+func TestFractalInSet(t *testing.T) {
+	black := color.RGBA{0, 0, 0, 0xff}
+	for _, samples := range []int{1, 3} {
+		// The default view is 3 wide, centered on -0.5: with an odd width,
+		// the center of the middle pixel is -0.5, in the main cardioid.
+		o := Options{Width: 129, Height: 129, Samples: samples, ColorMap: Rainbow}
+		img, err := RenderFractal(context.Background(), Mandelbrot(200), 0, 0, o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c := img.RGBAAt(64, 64); c != black {
+			t.Errorf("samples %d: -0.5 rendered %v, want %v", samples, c, black)
+		}
+		// -2+1.5i, of the corner, escapes.
+		if c := img.RGBAAt(0, 0); c == black {
+			t.Errorf("samples %d: -2+1.5i rendered in the set", samples)
+		}
+	}
+}
+
+// TestSamplesEdges checks that supersampling changes the pixels of the
+// edges of the set only: with a color map of a single color, the pixels
+// whose neighbors have their color are rendered the same. The set is the
+// Julia set of 0, the unit disk, with no filaments thinner than a pixel.
+func TestSamplesEdges(t *testing.T) {
+	white := func(float64) color.RGBA { return color.RGBA{0xff, 0xff, 0xff, 0xff} }
+	render := func(samples int) *image.RGBA {
+		o := Options{Width: 128, Height: 128, Samples: samples, ColorMap: white}
+		img, err := RenderFractal(context.Background(), Julia(0, 50), 0, 3, o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return img
+	}
+	img, smooth := render(1), render(3)
+	b := img.Bounds()
+	edges, changed := 0, 0
+	for y := b.Min.Y + 1; y < b.Max.Y-1; y++ {
+		for x := b.Min.X + 1; x < b.Max.X-1; x++ {
+			edge := false
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					edge = edge || img.RGBAAt(x+dx, y+dy) != img.RGBAAt(x, y)
+				}
+			}
+			if edge {
+				edges++
+			}
+			if smooth.RGBAAt(x, y) == img.RGBAAt(x, y) {
+				continue
+			}
+			changed++
+			if !edge {
+				t.Errorf("pixel %d,%d, inside of a region, changed from %v to %v", x, y, img.RGBAAt(x, y), smooth.RGBAAt(x, y))
+			}
+		}
+	}
+	if edges == 0 || changed == 0 {
+		t.Errorf("%d pixels changed of %d edge pixels, want the edges anti-aliased", changed, edges)
+	}
+}
+
+func TestCurveSVG(t *testing.T) {
+	for _, c := range []Curve{Lissajous(1.5, 0, 5), Rose(4, 1), Hypotrochoid(5, 3, 5)} {
+		var buf bytes.Buffer
+		if err := EncodeCurveSVG(context.Background(), &buf, c, Options{Width: 200, Height: 100}); err != nil {
+			t.Fatal(err)
+		}
+		var svg struct {
+			XMLName   xml.Name `xml:"http://www.w3.org/2000/svg svg"`
+			Width     int      `xml:"width,attr"`
+			Height    int      `xml:"height,attr"`
+			Polylines []struct {
+				Points string `xml:"points,attr"`
+			} `xml:"polyline"`
+		}
+		if err := xml.Unmarshal(buf.Bytes(), &svg); err != nil {
+			t.Fatalf("%s: %v", buf.String()[:100], err)
+		}
+		if svg.Width != 200 || svg.Height != 100 || len(svg.Polylines) == 0 {
+			t.Fatalf("svg of %dx%d with %d polylines, want 200x100 and the curve", svg.Width, svg.Height, len(svg.Polylines))
+		}
+		for i, p := range svg.Polylines {
+			for _, pt := range strings.Fields(p.Points) {
+				var x, y float64
+				if _, err := fmt.Sscanf(pt, "%f,%f", &x, &y); err != nil || x < 0 || x > 200 || y < 0 || y > 100 {
+					t.Errorf("polyline %d: point %q out of the image", i, pt)
+				}
+			}
+		}
+	}
+}
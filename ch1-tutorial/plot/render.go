@@ -0,0 +1,80 @@
+package plot
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"runtime"
+	"sync"
+)
+
+// Options are the options of the rendering of an image.
+type Options struct {
+	Width, Height int
+	// Samples is the number of samples of a pixel in each direction,
+	// for anti-aliasing. A pixel is the average of Samples² samples.
+	Samples    int
+	ColorMap   ColorMap
+	Background color.Color // of the curves
+	// The image is rendered in tiles of Tile×Tile pixels,
+	// by Workers goroutines.
+	Tile    int
+	Workers int
+}
+
+// withDefaults returns the options with defaults for the zero values.
+func (o Options) withDefaults() Options {
+	if o.Width == 0 {
+		o.Width = 512
+	}
+	if o.Height == 0 {
+		o.Height = o.Width
+	}
+	if o.Samples == 0 {
+		o.Samples = 1
+	}
+	if o.ColorMap == nil {
+		o.ColorMap = ColorMaps["fire"]
+	}
+	if o.Background == nil {
+		o.Background = color.Black
+	}
+	if o.Tile == 0 {
+		o.Tile = 64
+	}
+	if o.Workers == 0 {
+		o.Workers = runtime.GOMAXPROCS(0)
+	}
+	return o
+}
+
+// tiles calls f with the tiles of the rectangle, concurrently
+// on the workers of the options. Once the context is done, the
+// tiles left are skipped, and tiles returns the error of the context.
+func tiles(ctx context.Context, r image.Rectangle, o Options, f func(image.Rectangle)) error {
+	ch := make(chan image.Rectangle)
+	var wg sync.WaitGroup
+	for i := 0; i < o.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range ch {
+				f(t)
+			}
+		}()
+	}
+	defer func() {
+		close(ch)
+		wg.Wait()
+	}()
+	for y := r.Min.Y; y < r.Max.Y; y += o.Tile {
+		for x := r.Min.X; x < r.Max.X; x += o.Tile {
+			select {
+			case ch <- image.Rect(x, y, x+o.Tile, y+o.Tile).Intersect(r):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	return ctx.Err()
+}
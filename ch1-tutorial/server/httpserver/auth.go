@@ -0,0 +1,147 @@
+package httpserver
+
+import (
+	"bufio"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// BasicAuth returns a middleware letting through only the requests with
+// the basic-auth credentials of a user of the htpasswd file. The
+// passwords of the file are hashed with MD5 (htpasswd -m, the default of
+// Apache) or SHA-1 (htpasswd -s); the bcrypt ones of htpasswd -B are
+// not supported.
+func BasicAuth(realm, htpasswd string) (func(http.Handler) http.Handler, error) {
+	users, err := readHtpasswd(htpasswd)
+	if err != nil {
+		return nil, err
+	}
+	challenge := fmt.Sprintf("Basic realm=%q, charset=\"UTF-8\"", realm)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, password, ok := r.BasicAuth()
+			hash, known := users[user]
+			if !known {
+				// The password is hashed anyway, for the time of the
+				// response not to tell which users exist.
+				hash = "$apr1$xxxxxxxx$"
+			}
+			if !ok || !checkPassword(hash, password) || !known {
+				w.Header().Set("WWW-Authenticate", challenge)
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+// readHtpasswd returns the password hashes of the htpasswd file, by user.
+func readHtpasswd(name string) (map[string]string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	users := make(map[string]string)
+	input := bufio.NewScanner(f)
+	for n := 1; input.Scan(); n++ {
+		line := strings.TrimSpace(input.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		user, hash, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: no colon between the user and the password", name, n)
+		}
+		if !strings.HasPrefix(hash, "$apr1$") && !strings.HasPrefix(hash, "{SHA}") {
+			return nil, fmt.Errorf("%s:%d: the password of %s is not hashed with MD5 or SHA-1", name, n, user)
+		}
+		users[user] = hash
+	}
+	if err := input.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return users, nil
+}
+
+// checkPassword reports whether the password has the hash.
+func checkPassword(hash, password string) bool {
+	var h string
+	switch {
+	case strings.HasPrefix(hash, "{SHA}"):
+		sum := sha1.Sum([]byte(password))
+		h = "{SHA}" + base64.StdEncoding.EncodeToString(sum[:])
+	case strings.HasPrefix(hash, "$apr1$"):
+		salt, _, _ := strings.Cut(strings.TrimPrefix(hash, "$apr1$"), "$")
+		h = apr1(password, salt)
+	default:
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(h), []byte(hash)) == 1
+}
+
+// apr1 returns the Apache MD5 hash of the password: the MD5-based crypt
+// of FreeBSD, with the magic string $apr1$.
+func apr1(password, salt string) string {
+	const magic = "$apr1$"
+	if len(salt) > 8 {
+		salt = salt[:8]
+	}
+	pw := []byte(password)
+	alt := md5.Sum([]byte(password + salt + password))
+	d := md5.New()
+	d.Write([]byte(password + magic + salt))
+	for n := len(pw); n > 0; n -= 16 {
+		d.Write(alt[:min(n, 16)])
+	}
+	for n := len(pw); n > 0; n >>= 1 {
+		if n&1 != 0 {
+			d.Write([]byte{0})
+		} else {
+			d.Write(pw[:1])
+		}
+	}
+	sum := d.Sum(nil)
+	// 1000 rounds, to slow down the guessing of passwords.
+	for i := 0; i < 1000; i++ {
+		d := md5.New()
+		if i&1 != 0 {
+			d.Write(pw)
+		} else {
+			d.Write(sum)
+		}
+		if i%3 != 0 {
+			d.Write([]byte(salt))
+		}
+		if i%7 != 0 {
+			d.Write(pw)
+		}
+		if i&1 != 0 {
+			d.Write(sum)
+		} else {
+			d.Write(pw)
+		}
+		sum = d.Sum(nil)
+	}
+	const itoa64 = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	var b strings.Builder
+	b.WriteString(magic + salt + "$")
+	to64 := func(v uint32, n int) {
+		for ; n > 0; n-- {
+			b.WriteByte(itoa64[v&0x3f])
+			v >>= 6
+		}
+	}
+	for _, g := range [][3]int{{0, 6, 12}, {1, 7, 13}, {2, 8, 14}, {3, 9, 15}, {4, 10, 5}} {
+		to64(uint32(sum[g[0]])<<16|uint32(sum[g[1]])<<8|uint32(sum[g[2]]), 4)
+	}
+	to64(uint32(sum[11]), 2)
+	return b.String()
+}
@@ -0,0 +1,100 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAPR1(t *testing.T) {
+	// From openssl passwd -apr1 -salt abcdefgh s3cret.
+	for _, tt := range []struct{ password, salt, want string }{
+		{"s3cret", "abcdefgh", "$apr1$abcdefgh$M2T3erDstkD7SsE2QQnfH0"},
+		{"s3cret", "abcdefghijk", "$apr1$abcdefgh$M2T3erDstkD7SsE2QQnfH0"},
+	} {
+		if got := apr1(tt.password, tt.salt); got != tt.want {
+			t.Errorf("apr1(%q, %q) = %s, want %s", tt.password, tt.salt, got, tt.want)
+		}
+	}
+}
+
+func TestCheckPassword(t *testing.T) {
+	for _, tt := range []struct {
+		hash, password string
+		ok             bool
+	}{
+		{"$apr1$abcdefgh$M2T3erDstkD7SsE2QQnfH0", "s3cret", true},
+		{"$apr1$abcdefgh$M2T3erDstkD7SsE2QQnfH0", "secret", false},
+		{"$apr1$abcdefgh$M2T3erDstkD7SsE2QQnfH1", "s3cret", false},
+		{"{SHA}/vNB+F2HQ559kaLUZbmHHvZrXpg=", "s3cret", true},
+		{"{SHA}/vNB+F2HQ559kaLUZbmHHvZrXpg=", "", false},
+		{"$2y$05$abcdefghijklmnopqrstuv", "s3cret", false},
+	} {
+		if ok := checkPassword(tt.hash, tt.password); ok != tt.ok {
+			t.Errorf("checkPassword(%s, %q) = %v, want %v", tt.hash, tt.password, ok, tt.ok)
+		}
+	}
+}
+
+func writeHtpasswd(t *testing.T, lines ...string) string {
+	t.Helper()
+	name := filepath.Join(t.TempDir(), ".htpasswd")
+	if err := os.WriteFile(name, []byte(strings.Join(lines, "\n")), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+func TestBasicAuth(t *testing.T) {
+	auth, err := BasicAuth("files", writeHtpasswd(t,
+		"# users",
+		"ada:$apr1$abcdefgh$M2T3erDstkD7SsE2QQnfH0",
+		"",
+		"bob:{SHA}/vNB+F2HQ559kaLUZbmHHvZrXpg=",
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := auth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	for _, tt := range []struct {
+		user, password string
+		status         int
+	}{
+		{"ada", "s3cret", 200},
+		{"bob", "s3cret", 200},
+		{"ada", "wrong", 401},
+		{"eve", "s3cret", 401},
+		{"", "", 401},
+	} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/", nil)
+		if tt.user != "" {
+			r.SetBasicAuth(tt.user, tt.password)
+		}
+		h.ServeHTTP(w, r)
+		if w.Code != tt.status {
+			t.Errorf("%s:%s: status %d, want %d", tt.user, tt.password, w.Code, tt.status)
+		}
+		if c := w.Header().Get("WWW-Authenticate"); (w.Code == 401) != (c == `Basic realm="files", charset="UTF-8"`) {
+			t.Errorf("%s:%s: WWW-Authenticate = %q", tt.user, tt.password, c)
+		}
+	}
+}
+
+func TestReadHtpasswd(t *testing.T) {
+	for _, lines := range [][]string{
+		{"ada"},
+		{"ada:$2y$05$abcdefghijklmnopqrstuv"},
+		{"ada:plain"},
+	} {
+		if _, err := readHtpasswd(writeHtpasswd(t, lines...)); err == nil {
+			t.Errorf("read %q", lines)
+		}
+	}
+	if _, err := readHtpasswd(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("read a missing file")
+	}
+}
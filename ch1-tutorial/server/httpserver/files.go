@@ -0,0 +1,264 @@
+package httpserver
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A FileServer serves the files of a directory. Unlike http.FileServer,
+// it serves the precompressed variants of the files, name.br or name.gz,
+// to the clients accepting them, and hides the dot files, like a
+// .htpasswd. The files are opened with an os.Root, so that no path, nor
+// symbolic link, leads out of the directory. Large files take longer
+// than the WriteTimeout of a Server: register it with Server.Streaming.
+type FileServer struct {
+	root    *os.Root
+	escapes error // of the root, for the paths out of it
+	logger  *log.Logger
+}
+
+// encodings are the precompressed variants of the files,
+// in order of preference.
+var encodings = []struct{ name, ext string }{
+	{"br", ".br"},
+	{"gzip", ".gz"},
+}
+
+// NewFileServer returns a server of the files of the directory.
+func NewFileServer(dir string) (*FileServer, error) {
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	// Package os does not export the error of the paths out of a root;
+	// it is the one of .., which it returns without opening anything.
+	var escapes error
+	var pe *fs.PathError
+	if _, err := root.Open(".."); errors.As(err, &pe) {
+		escapes = pe.Err
+	}
+	return &FileServer{root: root, escapes: escapes, logger: DefaultConfig.Logger}, nil
+}
+
+// ServeHTTP serves the file of the path of the request, with ETag,
+// Last-Modified and Range support, or the listing of the directory,
+// or its index.html.
+func (s *FileServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "HEAD" {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	name, ok := cleanPath(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	f, err := s.root.Open(name)
+	if err != nil {
+		s.error(w, r, err)
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		s.error(w, r, err)
+		return
+	}
+	if info.IsDir() {
+		// The links of the listing are relative to the directory. The
+		// redirect is relative too, for the server to work under
+		// http.StripPrefix.
+		if !strings.HasSuffix(r.URL.Path, "/") {
+			target := path.Base(r.URL.Path) + "/"
+			if r.URL.RawQuery != "" {
+				target += "?" + r.URL.RawQuery
+			}
+			w.Header().Set("Location", target)
+			w.WriteHeader(http.StatusMovedPermanently)
+			return
+		}
+		index := path.Join(name, "index.html")
+		if fi, err := s.root.Stat(index); err == nil && fi.Mode().IsRegular() {
+			s.serveFile(w, r, index, fi)
+			return
+		}
+		s.serveDir(w, r, f)
+		return
+	}
+	if !info.Mode().IsRegular() {
+		http.NotFound(w, r)
+		return
+	}
+	s.serveFile(w, r, name, info)
+}
+
+// cleanPath returns the name of the file of the URL path, relative to the
+// root, or false if the path has a dot file.
+func cleanPath(p string) (string, bool) {
+	name := strings.TrimPrefix(path.Clean("/"+p), "/")
+	if name == "" {
+		return ".", true
+	}
+	for _, elem := range strings.Split(name, "/") {
+		if strings.HasPrefix(elem, ".") {
+			return "", false
+		}
+	}
+	return name, true
+}
+
+// serveFile serves the regular file, or its precompressed variant.
+// The ETag is made of the modification time and size of the file served.
+func (s *FileServer) serveFile(w http.ResponseWriter, r *http.Request, name string, info fs.FileInfo) {
+	h := w.Header()
+	h.Add("Vary", "Accept-Encoding")
+	served, encoding := name, ""
+	for _, e := range encodings {
+		if !accepts(r, e.name) {
+			continue
+		}
+		if fi, err := s.root.Stat(name + e.ext); err == nil && fi.Mode().IsRegular() {
+			served, encoding, info = name+e.ext, e.name, fi
+			break
+		}
+	}
+	f, err := s.root.Open(served)
+	if err != nil {
+		s.error(w, r, err)
+		return
+	}
+	defer f.Close()
+	if encoding != "" {
+		h.Set("Content-Encoding", encoding)
+		// The type is the one of the file, not of its compression.
+		if ctype := mime.TypeByExtension(path.Ext(name)); ctype != "" {
+			h.Set("Content-Type", ctype)
+		}
+	}
+	h.Set("ETag", fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size()))
+	// ServeContent handles the conditional and Range requests.
+	http.ServeContent(w, r, name, info.ModTime(), f)
+}
+
+// accepts reports whether the Accept-Encoding of the request has the
+// encoding, with a non-zero quality.
+func accepts(r *http.Request, encoding string) bool {
+	for _, h := range r.Header.Values("Accept-Encoding") {
+		for _, part := range strings.Split(h, ",") {
+			name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+			if !strings.EqualFold(strings.TrimSpace(name), encoding) {
+				continue
+			}
+			q, ok := strings.CutPrefix(strings.TrimSpace(params), "q=")
+			if !ok {
+				return true
+			}
+			v, err := strconv.ParseFloat(q, 64)
+			return err == nil && v > 0
+		}
+	}
+	return false
+}
+
+// listing is the HTML of a directory listing.
+var listing = template.Must(template.New("listing").Funcs(template.FuncMap{
+	"href": func(e fileEntry) string {
+		// ./ keeps a name with a colon from being read as a scheme.
+		u := &url.URL{Path: "./" + e.Name}
+		if e.Dir {
+			u.Path += "/"
+		}
+		return u.String()
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head><title>Index of {{.Path}}</title></head>
+<body>
+<h1>Index of {{.Path}}</h1>
+<table>
+<tr style='text-align: left'>
+  <th>Name</th>
+  <th>Size</th>
+  <th>Modified</th>
+</tr>
+{{if ne .Path "/"}}<tr><td><a href='../'>../</a></td><td></td><td></td></tr>{{end}}
+{{range .Entries}}
+<tr>
+  <td><a href='{{href .}}'>{{.Name}}{{if .Dir}}/{{end}}</a></td>
+  <td>{{if not .Dir}}{{.Size}}{{end}}</td>
+  <td>{{.ModTime.Format "2006-01-02 15:04"}}</td>
+</tr>
+{{end}}
+</table>
+</body>
+</html>
+`))
+
+type fileEntry struct {
+	Name    string
+	Dir     bool
+	Size    int64
+	ModTime time.Time
+}
+
+// serveDir serves the listing of the directory, its
+// subdirectories first, but for the dot files.
+func (s *FileServer) serveDir(w http.ResponseWriter, r *http.Request, dir *os.File) {
+	des, err := dir.ReadDir(-1)
+	if err != nil {
+		s.error(w, r, err)
+		return
+	}
+	var entries []fileEntry
+	for _, de := range des {
+		if strings.HasPrefix(de.Name(), ".") {
+			continue
+		}
+		info, err := de.Info()
+		if err != nil {
+			continue // removed since read
+		}
+		entries = append(entries, fileEntry{de.Name(), de.IsDir(), info.Size(), info.ModTime()})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Dir != entries[j].Dir {
+			return entries[i].Dir
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	data := struct {
+		Path    string
+		Entries []fileEntry
+	}{r.URL.Path, entries}
+	if err := listing.Execute(w, data); err != nil {
+		s.logger.Printf("%s: %v", RequestID(r.Context()), err)
+	}
+}
+
+// error serves the error of opening a file: not found, forbidden or
+// internal. The paths out of the root are not found.
+func (s *FileServer) error(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, fs.ErrNotExist), s.escapes != nil && errors.Is(err, s.escapes):
+		http.NotFound(w, r)
+	case errors.Is(err, fs.ErrPermission):
+		http.Error(w, "forbidden", http.StatusForbidden)
+	default:
+		s.logger.Printf("%s: %v", RequestID(r.Context()), err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+	}
+}
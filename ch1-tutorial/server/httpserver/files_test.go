@@ -0,0 +1,171 @@
+package httpserver
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestFiles returns a file server of a directory of
+//
+//	index.txt, style.css, style.css.gz, app.js, app.js.br, app.js.gz,
+//	.htpasswd, .git/config, docs/index.html, docs/a:b.txt and out,
+//	a symbolic link to a file out of the directory
+func newTestFiles(t *testing.T) *FileServer {
+	t.Helper()
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "root")
+	for name, content := range map[string]string{
+		"index.txt":       "0123456789",
+		"style.css":       "css",
+		"style.css.gz":    "css gzip",
+		"app.js":          "js",
+		"app.js.br":       "js brotli",
+		"app.js.gz":       "js gzip",
+		".htpasswd":       "ada:{SHA}x",
+		".git/config":     "[core]",
+		"docs/index.html": "<h1>docs</h1>",
+		"docs/a:b.txt":    "colon",
+		"../secret.txt":   "secret",
+	} {
+		name = filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(parent, "secret.txt"), filepath.Join(dir, "out")); err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewFileServer(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.logger = log.New(io.Discard, "", 0)
+	return s
+}
+
+func getFile(s http.Handler, path string, header ...string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	r.URL.Path = path // unlike NewRequest, kept as it is
+	for i := 0; i < len(header); i += 2 {
+		r.Header.Set(header[i], header[i+1])
+	}
+	s.ServeHTTP(w, r)
+	return w
+}
+
+func TestFilesTraversal(t *testing.T) {
+	s := newTestFiles(t)
+	if s.escapes == nil {
+		t.Fatal("no error of the paths out of the root")
+	}
+	for _, path := range []string{
+		"/../secret.txt",
+		"../secret.txt",
+		"/docs/../../secret.txt",
+		"/out",
+	} {
+		if w := getFile(s, path); w.Code != http.StatusNotFound || strings.Contains(w.Body.String(), "secret") {
+			t.Errorf("GET %s = %d %q, want 404", path, w.Code, w.Body.String())
+		}
+	}
+	// The escape is a 404, not an internal error.
+	rec := httptest.NewRecorder()
+	s.error(rec, httptest.NewRequest("GET", "/out", nil), &os.PathError{Op: "openat", Path: "out", Err: s.escapes})
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("escape error = %d, want 404", rec.Code)
+	}
+}
+
+func TestFilesDotFiles(t *testing.T) {
+	s := newTestFiles(t)
+	for _, path := range []string{"/.htpasswd", "/.git/config", "/.git/", "/docs/../.htpasswd"} {
+		if w := getFile(s, path); w.Code != http.StatusNotFound {
+			t.Errorf("GET %s = %d, want 404", path, w.Code)
+		}
+	}
+	w := getFile(s, "/")
+	if w.Code != 200 || strings.Contains(w.Body.String(), "htpasswd") || strings.Contains(w.Body.String(), ".git") {
+		t.Errorf("listing = %d %q, want no dot files", w.Code, w.Body.String())
+	}
+}
+
+func TestFilesPrecompressed(t *testing.T) {
+	s := newTestFiles(t)
+	for _, tt := range []struct {
+		path, accept, body, encoding string
+	}{
+		{"/app.js", "", "js", ""},
+		{"/app.js", "gzip", "js gzip", "gzip"},
+		{"/app.js", "gzip, br", "js brotli", "br"},
+		{"/app.js", "br;q=0, gzip;q=0.5", "js gzip", "gzip"},
+		{"/app.js", "BR", "js brotli", "br"},
+		{"/app.js", "deflate", "js", ""},
+		{"/style.css", "br, gzip", "css gzip", "gzip"},
+		{"/index.txt", "br, gzip", "0123456789", ""},
+	} {
+		w := getFile(s, tt.path, "Accept-Encoding", tt.accept)
+		h := w.Header()
+		if w.Code != 200 || w.Body.String() != tt.body || h.Get("Content-Encoding") != tt.encoding {
+			t.Errorf("GET %s, Accept-Encoding %q = %d %q, encoding %q, want %q, %q",
+				tt.path, tt.accept, w.Code, w.Body.String(), h.Get("Content-Encoding"), tt.body, tt.encoding)
+		}
+		if h.Get("Vary") != "Accept-Encoding" {
+			t.Errorf("GET %s: Vary = %q", tt.path, h.Get("Vary"))
+		}
+	}
+	w := getFile(s, "/style.css", "Accept-Encoding", "gzip")
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/css") {
+		t.Errorf("Content-Type = %q, want the one of the CSS", ct)
+	}
+	// The variants have ETags of their own.
+	if a, b := getFile(s, "/app.js").Header().Get("ETag"), w.Header().Get("ETag"); a == "" || a == b {
+		t.Errorf("ETags = %q and %q, want distinct ones", a, b)
+	}
+}
+
+func TestFilesRangeAndConditional(t *testing.T) {
+	s := newTestFiles(t)
+	w := getFile(s, "/index.txt", "Range", "bytes=2-4")
+	if w.Code != http.StatusPartialContent || w.Body.String() != "234" {
+		t.Errorf("range = %d %q, want 206 234", w.Code, w.Body.String())
+	}
+	etag := getFile(s, "/index.txt").Header().Get("ETag")
+	if w := getFile(s, "/index.txt", "If-None-Match", etag); w.Code != http.StatusNotModified {
+		t.Errorf("If-None-Match = %d, want 304", w.Code)
+	}
+}
+
+func TestFilesDirectories(t *testing.T) {
+	s := newTestFiles(t)
+	if w := getFile(s, "/docs"); w.Code != http.StatusMovedPermanently || w.Header().Get("Location") != "docs/" {
+		t.Errorf("GET /docs = %d to %q, want a redirect to docs/", w.Code, w.Header().Get("Location"))
+	}
+	if w := getFile(s, "/docs/"); w.Body.String() != "<h1>docs</h1>" {
+		t.Errorf("GET /docs/ = %q, want its index.html", w.Body.String())
+	}
+	w := getFile(s, "/")
+	for _, want := range []string{`href='./docs/'`, `href='./index.txt'`} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("listing has no %s", want)
+		}
+	}
+	if w := getFile(s, "/docs/a:b.txt"); w.Body.String() != "colon" {
+		t.Errorf("GET /docs/a:b.txt = %q", w.Body.String())
+	}
+	r := httptest.NewRequest("POST", "/index.txt", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, r)
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != "GET, HEAD" {
+		t.Errorf("POST = %d, want 405", rec.Code)
+	}
+}
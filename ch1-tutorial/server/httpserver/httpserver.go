@@ -0,0 +1,205 @@
+// Package httpserver runs the handlers of the ch1 servers the way a
+// production server would: with request IDs, an access log, timeouts,
+// per-path request counts and a graceful shutdown on SIGINT or SIGTERM.
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Config is the configuration of a Server. The zero
+// values are replaced by the ones of DefaultConfig.
+type Config struct {
+	Addr            string        // address to listen on
+	ReadTimeout     time.Duration // to read a request, body included
+	WriteTimeout    time.Duration // to write a response
+	IdleTimeout     time.Duration // of the keep-alive connections
+	ShutdownTimeout time.Duration // to finish the requests in flight on shutdown
+	Logger          *log.Logger   // of the access log and the errors
+}
+
+// DefaultConfig is the default configuration of a Server.
+var DefaultConfig = Config{
+	Addr:            "localhost:8000",
+	ReadTimeout:     5 * time.Second,
+	WriteTimeout:    10 * time.Second,
+	IdleTimeout:     2 * time.Minute,
+	ShutdownTimeout: 10 * time.Second,
+	Logger:          log.New(os.Stderr, "", log.LstdFlags),
+}
+
+// MaxPaths is the number of paths a Server counts the requests of.
+// The requests of the other paths are counted together as "(other)",
+// and the ones of no route as "(not found)".
+const MaxPaths = 1000
+
+// A Server routes the requests to its handlers. It counts the requests
+// of each path, and serves the counts as JSON at /count.
+type Server struct {
+	cfg Config
+	mux *http.ServeMux
+	srv *http.Server
+
+	counts sync.Map // of *int64 by path, incremented atomically
+	paths  int64    // number of paths in counts, up to MaxPaths
+
+	mu         sync.Mutex
+	countFuncs []func() map[string]int64
+}
+
+// New returns a server with the configuration.
+func New(cfg Config) *Server {
+	d := DefaultConfig
+	if cfg.Addr == "" {
+		cfg.Addr = d.Addr
+	}
+	if cfg.ReadTimeout == 0 {
+		cfg.ReadTimeout = d.ReadTimeout
+	}
+	if cfg.WriteTimeout == 0 {
+		cfg.WriteTimeout = d.WriteTimeout
+	}
+	if cfg.IdleTimeout == 0 {
+		cfg.IdleTimeout = d.IdleTimeout
+	}
+	if cfg.ShutdownTimeout == 0 {
+		cfg.ShutdownTimeout = d.ShutdownTimeout
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = d.Logger
+	}
+	s := &Server{cfg: cfg, mux: http.NewServeMux()}
+	s.srv = &http.Server{
+		Addr:         cfg.Addr,
+		Handler:      s.requestID(s.accessLog(s.count(s.mux))),
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+		ErrorLog:     cfg.Logger,
+	}
+	s.HandleFunc("/count", s.countHandler)
+	return s
+}
+
+// Handle registers the handler for the pattern, like http.Handle.
+func (s *Server) Handle(pattern string, h http.Handler) {
+	s.mux.Handle(pattern, h)
+}
+
+// HandleFunc registers the handler function for the pattern, like http.HandleFunc.
+func (s *Server) HandleFunc(pattern string, f func(http.ResponseWriter, *http.Request)) {
+	s.mux.HandleFunc(pattern, f)
+}
+
+// ListenAndServe serves the requests until the process receives SIGINT
+// or SIGTERM. It then stops accepting connections and waits for the
+// requests in flight, for ShutdownTimeout at most.
+func (s *Server) ListenAndServe() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return s.Run(ctx)
+}
+
+// Run serves the requests until the context is done, and then shuts
+// the server down like ListenAndServe.
+func (s *Server) Run(ctx context.Context) error {
+	errc := make(chan error, 1)
+	go func() {
+		s.cfg.Logger.Printf("listening on %s", s.cfg.Addr)
+		errc <- s.srv.ListenAndServe()
+	}()
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+	s.cfg.Logger.Print("shutting down")
+	sctx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
+	defer cancel()
+	if err := s.srv.Shutdown(sctx); err != nil {
+		return err
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// count counts the request in the counter of its path.
+func (s *Server) count(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(s.counter(r), 1)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// counter returns the counter of the path of the request, adding it
+// if there are fewer than MaxPaths.
+func (s *Server) counter(r *http.Request) *int64 {
+	path := r.URL.Path
+	if n, ok := s.counts.Load(path); ok {
+		return n.(*int64)
+	}
+	// Scanners would otherwise add a counter for each path they try.
+	if _, pattern := s.mux.Handler(r); pattern == "" {
+		path = "(not found)"
+	} else if atomic.AddInt64(&s.paths, 1) > MaxPaths {
+		atomic.AddInt64(&s.paths, -1)
+		path = "(other)"
+	} else {
+		n, loaded := s.counts.LoadOrStore(path, new(int64))
+		if loaded {
+			atomic.AddInt64(&s.paths, -1)
+		}
+		return n.(*int64)
+	}
+	n, _ := s.counts.LoadOrStore(path, new(int64))
+	return n.(*int64)
+}
+
+// CountFunc adds the counts returned by f to the ones of Counts and
+// /count, like the requests of each upstream of a Proxy.
+func (s *Server) CountFunc(f func() map[string]int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.countFuncs = append(s.countFuncs, f)
+}
+
+// Counts returns the number of requests of each path so far,
+// and the counts of the functions of CountFunc.
+func (s *Server) Counts() map[string]int64 {
+	counts := make(map[string]int64)
+	s.counts.Range(func(path, n any) bool {
+		counts[path.(string)] = atomic.LoadInt64(n.(*int64))
+		return true
+	})
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, f := range s.countFuncs {
+		for k, n := range f() {
+			counts[k] = n
+		}
+	}
+	return counts
+}
+
+// countHandler serves the counts of Counts as JSON, sorted by name.
+func (s *Server) countHandler(w http.ResponseWriter, r *http.Request) {
+	counts := s.Counts()
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(counts); err != nil {
+		s.cfg.Logger.Printf("%s: %v", RequestID(r.Context()), err)
+	}
+}
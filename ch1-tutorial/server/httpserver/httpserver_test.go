@@ -0,0 +1,66 @@
+package httpserver
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// get serves a GET of the path with the handler of the server.
+func get(s *Server, path string, header http.Header) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", path, nil)
+	for k, v := range header {
+		r.Header[k] = v
+	}
+	s.srv.Handler.ServeHTTP(w, r)
+	return w
+}
+
+func newTestServer() *Server {
+	s := New(Config{Logger: log.New(io.Discard, "", 0)})
+	s.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {})
+	s.HandleFunc("/only", func(w http.ResponseWriter, r *http.Request) {})
+	return s
+}
+
+func TestCounts(t *testing.T) {
+	s := newTestServer()
+	for _, path := range []string{"/a", "/a", "/b/c", "/only", "/only/x"} {
+		get(s, path, nil)
+	}
+	s.CountFunc(func() map[string]int64 { return map[string]int64{"upstream": 7} })
+	want := map[string]int64{"/a": 2, "/b/c": 1, "/only": 1, "/only/x": 1, "upstream": 7}
+	if got := s.Counts(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("counts = %v, want %v", got, want)
+	}
+}
+
+func TestCountsBounded(t *testing.T) {
+	s := New(Config{Logger: log.New(io.Discard, "", 0)})
+	s.HandleFunc("/p/", func(w http.ResponseWriter, r *http.Request) {})
+	for i := 0; i < MaxPaths+10; i++ {
+		get(s, fmt.Sprintf("/p/%d", i), nil)
+	}
+	get(s, "/p/0", nil)
+	get(s, "/nothing", nil)
+	counts := s.Counts()
+	if len(counts) != MaxPaths+2 || counts["/p/0"] != 2 || counts["(other)"] != 10 || counts["(not found)"] != 1 {
+		t.Errorf("%d counts, /p/0 = %d, (other) = %d, (not found) = %d, want %d, 2, 10 and 1",
+			len(counts), counts["/p/0"], counts["(other)"], counts["(not found)"], MaxPaths+2)
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	s := newTestServer()
+	a, b := get(s, "/", nil).Header().Get("X-Request-ID"), get(s, "/", nil).Header().Get("X-Request-ID")
+	if len(a) != 16 || a == b {
+		t.Errorf("request IDs = %q and %q, want two of 16 hex digits", a, b)
+	}
+	if id := get(s, "/", http.Header{"X-Request-Id": {"abc"}}).Header().Get("X-Request-ID"); id != "abc" {
+		t.Errorf("request ID = %q, want the one of the client", id)
+	}
+}
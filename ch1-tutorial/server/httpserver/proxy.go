@@ -0,0 +1,359 @@
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ProxyConfig is the configuration of a Proxy. The zero values but
+// Upstreams are replaced by defaults.
+type ProxyConfig struct {
+	Upstreams []string // base URLs of the upstreams, like http://localhost:8001
+	Balance   string   // round-robin (the default) or least-conn
+
+	HealthPath     string        // probed with HEAD requests, "/" by default
+	HealthInterval time.Duration // between probes of a healthy upstream, 5s by default
+	HealthTimeout  time.Duration // of a probe, 2s by default
+
+	// Retries is the number of other upstreams an idempotent request is
+	// sent to, when an upstream fails it. By default, all the others;
+	// it must not be negative.
+	Retries int
+
+	Transport http.RoundTripper // to the upstreams, http.DefaultTransport by default
+	Logger    *log.Logger       // DefaultConfig.Logger by default
+}
+
+// maxRetryBody bounds the body of the requests kept to be sent again.
+// The requests with larger bodies are not retried.
+const maxRetryBody = 1 << 20
+
+// maxBackoff bounds the delay between the probes of an unhealthy upstream,
+// which doubles after each failure like the retries of WaitForServer.
+const maxBackoff = time.Minute
+
+// A Proxy is a reverse proxy to upstreams. It balances the requests over
+// the healthy upstreams, found by probing them with HEAD requests, and
+// sends the idempotent requests to another upstream when one fails them.
+// Its responses may stream for longer than the WriteTimeout of a
+// Server: register it with Server.Streaming.
+type Proxy struct {
+	cfg       ProxyConfig
+	upstreams []*upstream
+	rr        uint64 // the next upstream, for round-robin
+	rp        *httputil.ReverseProxy
+}
+
+// An upstream is a server of a Proxy, and its counters.
+type upstream struct {
+	url      *url.URL
+	healthy  atomic.Bool
+	active   int64 // requests in flight
+	requests int64 // requests sent
+	failures int64 // requests failed: errors or 502, 503 and 504 responses
+}
+
+var errNoUpstream = errors.New("no healthy upstream")
+
+// NewProxy returns a proxy to the upstreams of the configuration. The
+// upstreams are deemed healthy until probed by CheckHealth.
+func NewProxy(cfg ProxyConfig) (*Proxy, error) {
+	if len(cfg.Upstreams) == 0 {
+		return nil, errors.New("proxy: no upstreams")
+	}
+	switch cfg.Balance {
+	case "":
+		cfg.Balance = "round-robin"
+	case "round-robin", "least-conn":
+	default:
+		return nil, fmt.Errorf("proxy: unknown balance %q, not round-robin or least-conn", cfg.Balance)
+	}
+	if cfg.HealthPath == "" {
+		cfg.HealthPath = "/"
+	}
+	if cfg.HealthInterval == 0 {
+		cfg.HealthInterval = 5 * time.Second
+	}
+	if cfg.HealthTimeout == 0 {
+		cfg.HealthTimeout = 2 * time.Second
+	}
+	if cfg.Retries < 0 {
+		return nil, fmt.Errorf("proxy: negative retries %d", cfg.Retries)
+	}
+	if cfg.Retries == 0 || cfg.Retries >= len(cfg.Upstreams) {
+		cfg.Retries = len(cfg.Upstreams) - 1
+	}
+	if cfg.Transport == nil {
+		cfg.Transport = http.DefaultTransport
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = DefaultConfig.Logger
+	}
+	p := &Proxy{cfg: cfg}
+	for _, s := range cfg.Upstreams {
+		u, err := url.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("proxy: %v", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+			return nil, fmt.Errorf("proxy: upstream %q is not an http or https URL", s)
+		}
+		up := &upstream{url: u}
+		up.healthy.Store(true)
+		p.upstreams = append(p.upstreams, up)
+	}
+	p.rp = &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetXForwarded()
+			pr.Out.Header.Set("X-Request-ID", RequestID(pr.In.Context()))
+		},
+		// The upstream is chosen by the transport, for each try.
+		Transport: roundTripper(p.roundTrip),
+		ErrorLog:  cfg.Logger,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			cfg.Logger.Printf("%s: proxy: %v", RequestID(r.Context()), err)
+			if errors.Is(err, errNoUpstream) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			} else {
+				w.WriteHeader(http.StatusBadGateway)
+			}
+		},
+	}
+	return p, nil
+}
+
+// ServeHTTP sends the request to an upstream, and its response back.
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.rp.ServeHTTP(w, r)
+}
+
+type roundTripper func(*http.Request) (*http.Response, error)
+
+func (f roundTripper) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// roundTrip sends the request to an upstream. An idempotent request is
+// sent to another upstream, up to Retries times, when the upstream
+// fails: on an error, or a 502, 503 or 504 response.
+func (p *Proxy) roundTrip(r *http.Request) (*http.Response, error) {
+	tries := 1
+	var body []byte
+	if idempotent(r.Method) {
+		tries += p.cfg.Retries
+		if r.Body != nil && r.Body != http.NoBody {
+			// The body is read to be sent again, unless it is too large.
+			b, err := io.ReadAll(io.LimitReader(r.Body, maxRetryBody+1))
+			if err != nil {
+				return nil, err
+			}
+			if len(b) > maxRetryBody {
+				tries = 1
+				r.Body = struct {
+					io.Reader
+					io.Closer
+				}{io.MultiReader(bytes.NewReader(b), r.Body), r.Body}
+			} else {
+				r.Body.Close()
+				body = b
+			}
+		}
+	}
+	tried := make(map[*upstream]bool)
+	var resp *http.Response
+	var err error
+	for i := 0; i < tries; i++ {
+		u := p.next(tried)
+		if u == nil {
+			break
+		}
+		tried[u] = true
+		if resp != nil {
+			resp.Body.Close() // of the failed try
+		}
+		out := r.Clone(r.Context())
+		out.URL.Scheme = u.url.Scheme
+		out.URL.Host = u.url.Host
+		out.URL.Path, out.URL.RawPath = joinPath(u.url, r.URL)
+		out.Host = ""
+		if body != nil {
+			out.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		resp, err = u.roundTrip(p.cfg.Transport, out)
+		if err == nil && !failed(resp.StatusCode) {
+			return resp, nil
+		}
+		if err != nil {
+			p.cfg.Logger.Printf("%s: proxy: %s: %v", RequestID(r.Context()), u.url, err)
+		}
+	}
+	if resp == nil && err == nil {
+		err = errNoUpstream
+	}
+	return resp, err
+}
+
+// roundTrip sends the request to the upstream, counting it in flight
+// until the body of the response is closed.
+func (u *upstream) roundTrip(t http.RoundTripper, r *http.Request) (*http.Response, error) {
+	atomic.AddInt64(&u.requests, 1)
+	atomic.AddInt64(&u.active, 1)
+	resp, err := t.RoundTrip(r)
+	if err != nil || failed(resp.StatusCode) {
+		atomic.AddInt64(&u.failures, 1)
+	}
+	if err != nil {
+		atomic.AddInt64(&u.active, -1)
+		return nil, err
+	}
+	resp.Body = &doneBody{ReadCloser: resp.Body, done: func() { atomic.AddInt64(&u.active, -1) }}
+	return resp, nil
+}
+
+// doneBody calls done once, when closed.
+type doneBody struct {
+	io.ReadCloser
+	once sync.Once
+	done func()
+}
+
+func (b *doneBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.done)
+	return err
+}
+
+// next returns the next healthy upstream not tried yet, by the balance
+// of the proxy, or nil if there are none.
+func (p *Proxy) next(tried map[*upstream]bool) *upstream {
+	var best *upstream
+	n := len(p.upstreams)
+	start := int(atomic.AddUint64(&p.rr, 1) % uint64(n))
+	for i := 0; i < n; i++ {
+		u := p.upstreams[(start+i)%n]
+		if tried[u] || !u.healthy.Load() {
+			continue
+		}
+		if p.cfg.Balance == "round-robin" {
+			return u
+		}
+		// The ties of least-conn are broken round-robin.
+		if best == nil || atomic.LoadInt64(&u.active) < atomic.LoadInt64(&best.active) {
+			best = u
+		}
+	}
+	return best
+}
+
+// idempotent reports whether requests of the method can be sent twice,
+// per RFC 9110.
+func idempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "TRACE", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+func failed(status int) bool {
+	return status == http.StatusBadGateway || status == http.StatusServiceUnavailable ||
+		status == http.StatusGatewayTimeout
+}
+
+// joinPath returns the path of the request under the one of the upstream.
+func joinPath(base, u *url.URL) (path, rawPath string) {
+	if base.Path == "" || base.Path == "/" {
+		return u.Path, u.RawPath
+	}
+	return strings.TrimSuffix(base.Path, "/") + "/" + strings.TrimPrefix(u.Path, "/"), ""
+}
+
+// CheckHealth probes the upstreams until the context is done, with HEAD
+// requests of HealthPath. An upstream is healthy when it responds with a
+// status other than 5xx. A healthy upstream is probed every
+// HealthInterval; an unhealthy one is probed with an exponential
+// back-off, and gets no requests until healthy again.
+func (p *Proxy) CheckHealth(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, u := range p.upstreams {
+		wg.Add(1)
+		go func(u *upstream) {
+			defer wg.Done()
+			p.checkHealth(ctx, u)
+		}(u)
+	}
+	wg.Wait()
+}
+
+func (p *Proxy) checkHealth(ctx context.Context, u *upstream) {
+	client := &http.Client{Transport: p.cfg.Transport, Timeout: p.cfg.HealthTimeout}
+	probe := u.url.JoinPath(p.cfg.HealthPath).String()
+	for tries := 0; ; {
+		err := headProbe(ctx, client, probe)
+		if ctx.Err() != nil {
+			return
+		}
+		healthy := err == nil
+		if healthy != u.healthy.Swap(healthy) {
+			if healthy {
+				p.cfg.Logger.Printf("proxy: %s is healthy", u.url)
+			} else {
+				p.cfg.Logger.Printf("proxy: %s is unhealthy: %v", u.url, err)
+			}
+		}
+		delay := p.cfg.HealthInterval
+		if healthy {
+			tries = 0
+		} else {
+			if d := time.Second << uint(tries); d < maxBackoff {
+				delay = d // exponential back-off
+				tries++
+			} else {
+				delay = maxBackoff
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+func headProbe(ctx context.Context, client *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, "HEAD", url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("HEAD %s: %s", url, resp.Status)
+	}
+	return nil
+}
+
+// Counts returns the counters of each upstream, by the names
+// "proxy URL requests", "proxy URL failures" and "proxy URL active",
+// for Server.CountFunc.
+func (p *Proxy) Counts() map[string]int64 {
+	counts := make(map[string]int64, 3*len(p.upstreams))
+	for _, u := range p.upstreams {
+		counts["proxy "+u.url.String()+" requests"] = atomic.LoadInt64(&u.requests)
+		counts["proxy "+u.url.String()+" failures"] = atomic.LoadInt64(&u.failures)
+		counts["proxy "+u.url.String()+" active"] = atomic.LoadInt64(&u.active)
+	}
+	return counts
+}
@@ -0,0 +1,265 @@
+package httpserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// A testUpstream is an upstream failing on command: it answers with its status,
+// 200 by default, or drops the connection if the status is -1.
+type testUpstream struct {
+	*httptest.Server
+	name     string
+	status   int64
+	requests int64
+	delay    time.Duration
+}
+
+func newUpstream(t *testing.T, name string) *testUpstream {
+	u := &testUpstream{name: name, status: http.StatusOK}
+	u.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&u.requests, 1)
+		time.Sleep(u.delay)
+		status := int(atomic.LoadInt64(&u.status))
+		if status == -1 {
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.WriteHeader(status)
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, "%s %s %s %s", u.name, r.Method, r.URL.Path, body)
+	}))
+	t.Cleanup(u.Close)
+	return u
+}
+
+func (u *testUpstream) fail(status int) { atomic.StoreInt64(&u.status, int64(status)) }
+func (u *testUpstream) count() int      { return int(atomic.LoadInt64(&u.requests)) }
+
+func newTestProxy(t *testing.T, cfg ProxyConfig, ups ...*testUpstream) *Proxy {
+	t.Helper()
+	for _, u := range ups {
+		cfg.Upstreams = append(cfg.Upstreams, u.URL)
+	}
+	cfg.Logger = log.New(io.Discard, "", 0)
+	p, err := NewProxy(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// send sends a request through the proxy, and returns the status and
+// the body of the response.
+func send(p http.Handler, method, path, body string) (int, string) {
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return w.Code, w.Body.String()
+}
+
+func TestProxyRoundRobin(t *testing.T) {
+	a, b := newUpstream(t, "a"), newUpstream(t, "b")
+	p := newTestProxy(t, ProxyConfig{}, a, b)
+	seen := map[string]int{}
+	for i := 0; i < 4; i++ {
+		status, body := send(p, "GET", "/x", "")
+		if status != 200 {
+			t.Fatalf("status = %d, want 200", status)
+		}
+		seen[strings.Fields(body)[0]]++
+	}
+	if seen["a"] != 2 || seen["b"] != 2 {
+		t.Errorf("requests = %v, want 2 to each upstream", seen)
+	}
+	counts := p.Counts()
+	if counts["proxy "+a.URL+" requests"] != 2 || counts["proxy "+a.URL+" active"] != 0 {
+		t.Errorf("counts = %v", counts)
+	}
+}
+
+func TestProxyPath(t *testing.T) {
+	a := newUpstream(t, "a")
+	p := newTestProxy(t, ProxyConfig{Upstreams: []string{a.URL + "/base/"}})
+	if _, body := send(p, "GET", "/x/y?q=1", ""); body != "a GET /base/x/y " {
+		t.Errorf("body = %q, want the path under /base", body)
+	}
+}
+
+func TestProxyRetry(t *testing.T) {
+	for _, failure := range []int{http.StatusServiceUnavailable, http.StatusBadGateway, -1} {
+		a, b := newUpstream(t, "a"), newUpstream(t, "b")
+		a.fail(failure)
+		p := newTestProxy(t, ProxyConfig{}, a, b)
+		for i := 0; i < 4; i++ {
+			// The body is sent again to the other upstream.
+			status, body := send(p, "PUT", "/x", "data")
+			if status != 200 || body != "b PUT /x data" {
+				t.Errorf("failure %d: PUT = %d %q, want b's response", failure, status, body)
+			}
+		}
+		if n := p.Counts()["proxy "+a.URL+" failures"]; n != int64(a.count()) || n == 0 {
+			t.Errorf("failure %d: %d failures of a, want its %d requests", failure, n, a.count())
+		}
+	}
+}
+
+func TestProxyNoRetry(t *testing.T) {
+	a, b := newUpstream(t, "a"), newUpstream(t, "b")
+	a.fail(http.StatusServiceUnavailable)
+	p := newTestProxy(t, ProxyConfig{}, a, b)
+	statuses := map[int]int{}
+	for i := 0; i < 4; i++ {
+		status, _ := send(p, "POST", "/x", "data")
+		statuses[status]++
+	}
+	// A POST is not idempotent: a's failures are sent back.
+	if statuses[503] != 2 || statuses[200] != 2 || a.count() != 2 || b.count() != 2 {
+		t.Errorf("statuses = %v after %d and %d requests, want 2 of each", statuses, a.count(), b.count())
+	}
+}
+
+func TestProxyRetries(t *testing.T) {
+	ups := []*testUpstream{newUpstream(t, "a"), newUpstream(t, "b"), newUpstream(t, "c")}
+	for _, u := range ups {
+		u.fail(http.StatusServiceUnavailable)
+	}
+	p := newTestProxy(t, ProxyConfig{Retries: 1}, ups...)
+	if status, _ := send(p, "GET", "/", ""); status != 503 {
+		t.Errorf("status = %d, want the 503 of the last try", status)
+	}
+	if n := ups[0].count() + ups[1].count() + ups[2].count(); n != 2 {
+		t.Errorf("%d tries, want 2", n)
+	}
+
+	for _, u := range ups {
+		u.fail(-1)
+	}
+	if status, _ := send(p, "GET", "/", ""); status != http.StatusBadGateway {
+		t.Errorf("status = %d, want 502 when no upstream answers", status)
+	}
+
+	if _, err := NewProxy(ProxyConfig{Upstreams: []string{ups[0].URL}, Retries: -1}); err == nil {
+		t.Error("NewProxy accepted negative retries")
+	}
+}
+
+func TestProxyHealth(t *testing.T) {
+	a, b := newUpstream(t, "a"), newUpstream(t, "b")
+	a.fail(http.StatusInternalServerError)
+	p := newTestProxy(t, ProxyConfig{HealthInterval: 10 * time.Millisecond}, a, b)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		p.CheckHealth(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+	waitFor(t, func() bool { return !p.upstreams[0].healthy.Load() })
+	before := a.count()
+	for i := 0; i < 4; i++ {
+		if _, body := send(p, "POST", "/", ""); !strings.HasPrefix(body, "b ") {
+			t.Errorf("body = %q, want only the healthy upstream", body)
+		}
+	}
+	if a.count() != before {
+		t.Errorf("%d requests to the unhealthy upstream", a.count()-before)
+	}
+
+	b.fail(http.StatusInternalServerError)
+	waitFor(t, func() bool { return !p.upstreams[1].healthy.Load() })
+	if status, _ := send(p, "GET", "/", ""); status != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503 with no healthy upstream", status)
+	}
+
+	// a recovers after its back-off of a second.
+	a.fail(http.StatusOK)
+	waitFor(t, func() bool { return p.upstreams[0].healthy.Load() })
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); !cond(); time.Sleep(5 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+	}
+}
+
+func TestProxyLeastConn(t *testing.T) {
+	slow, fast := newUpstream(t, "slow"), newUpstream(t, "fast")
+	slow.delay = 200 * time.Millisecond
+	p := newTestProxy(t, ProxyConfig{Balance: "least-conn"}, slow, fast)
+	done := make(chan struct{})
+	go func() {
+		send(p, "GET", "/", "")
+		send(p, "GET", "/", "")
+		close(done)
+	}()
+	// While a request is in flight at one upstream, the other gets them.
+	waitFor(t, func() bool { return slow.count() == 1 || fast.count() == 2 })
+	if slow.count() == 1 {
+		for i := 0; i < 3; i++ {
+			if _, body := send(p, "GET", "/", ""); !strings.HasPrefix(body, "fast ") {
+				t.Errorf("body = %q, want the upstream with no request in flight", body)
+			}
+		}
+	}
+	<-done
+}
+
+// TestProxyStreaming checks that a response streamed for longer than the
+// write timeout of the server is not cut off.
+func TestProxyStreaming(t *testing.T) {
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 6; i++ {
+			fmt.Fprintf(w, "%d", i)
+			w.(http.Flusher).Flush()
+			time.Sleep(50 * time.Millisecond)
+		}
+	}))
+	defer up.Close()
+	cfg := ProxyConfig{Upstreams: []string{up.URL}, Logger: log.New(io.Discard, "", 0)}
+	p, err := NewProxy(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := New(Config{WriteTimeout: 100 * time.Millisecond, Logger: cfg.Logger})
+	s.Handle("/stream", s.Streaming(p))
+	s.Handle("/cut", p)
+	ts := httptest.NewUnstartedServer(s.srv.Handler)
+	ts.Config.WriteTimeout = s.cfg.WriteTimeout
+	ts.Start()
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil || string(body) != "012345" {
+		t.Errorf("streamed %q, %v, want 012345", body, err)
+	}
+
+	// Without Streaming, the write timeout cuts the response.
+	resp, err = http.Get(ts.URL + "/cut")
+	if err == nil {
+		body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+	if err == nil && string(body) == "012345" {
+		t.Error("response not cut by the write timeout")
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"log"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anjanashankar9/go-learning/ch1-tutorial/lissajous"
+	"github.com/anjanashankar9/go-learning/ch1-tutorial/server/httpserver"
+)
+
+// Server2 is a minimal "echo" and counter server. The server counts
+// the requests of each path, and serves the counts at /count:
+//
+//	cd ch1-tutorial && go run ./server/server2 -addr localhost:8000
+//
+// It serves the animations of the lissajous package at /lissajous:
+//
+//	curl 'localhost:8000/lissajous?cycles=7&palette=rainbow&seed=42' > l.gif
+//
+// With -proxy, it is a reverse proxy to the upstreams instead, for all
+// but /count, /debug/request and /lissajous; /count has the counts of the upstreams too:
+//
+//	server2 -proxy http://localhost:8001,http://localhost:8002 -balance least-conn
+//
+// With -root, it serves the files of the directory at /files/, to the
+// users of the -htpasswd file if given:
+//
+//	server2 -root ./public -htpasswd ./users.htpasswd
+var (
+	addr     = flag.String("addr", "localhost:8000", "address to listen on")
+	proxy    = flag.String("proxy", "", "comma-separated `URLs` of the upstreams to proxy to")
+	balance  = flag.String("balance", "round-robin", "balance of the proxy: round-robin or least-conn")
+	health   = flag.String("health", "/", "`path` of the HEAD probes of the upstreams")
+	interval = flag.Duration("health-interval", 5*time.Second, "interval of the probes of the healthy upstreams")
+	root     = flag.String("root", "", "`directory` of the files to serve at /files/")
+	htpasswd = flag.String("htpasswd", "", "htpasswd `file` of the users allowed to get the files")
+)
+
+func main() {
+	flag.Parse()
+	srv := httpserver.New(httpserver.Config{Addr: *addr})
+	if *proxy != "" {
+		p, err := httpserver.NewProxy(httpserver.ProxyConfig{
+			Upstreams:      strings.Split(*proxy, ","),
+			Balance:        *balance,
+			HealthPath:     *health,
+			HealthInterval: *interval,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go p.CheckHealth(ctx)
+		srv.Handle("/", srv.Streaming(p))
+		srv.CountFunc(p.Counts)
+	} else {
+		srv.HandleFunc("/", handler2)
+	}
+	srv.HandleFunc("/debug/request", handler3)
+	srv.HandleFunc("/lissajous", lissajous.Handler)
+	if *root != "" {
+		fs, err := httpserver.NewFileServer(*root)
+		if err != nil {
+			log.Fatal(err)
+		}
+		var h http.Handler = http.StripPrefix("/files", srv.Streaming(fs))
+		if *htpasswd != "" {
+			auth, err := httpserver.BasicAuth("files", *htpasswd)
+			if err != nil {
+				log.Fatal(err)
+			}
+			h = auth(h)
+		}
+		srv.Handle("/files/", h)
+	}
+	if err := srv.ListenAndServe(); err != nil {
+		log.Fatal(err)
+	}
+}
+// handler echoes the Path component of the requested URL.
+func handler2(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintf(w, "URL.Path = %q\n", r.URL.Path)
+}
+
+// handler echoes the method, URL, headers, address and form of the request.
+func handler3(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintf(w, "%s %s %s\n", r.Method, r.URL, r.Proto)
+	for k, v := range r.Header {
+		fmt.Fprintf(w, "Header[%q] = %q\n", k, v)
+	}
+	fmt.Fprintf(w, "Host = %q\n", r.Host)
+	fmt.Fprintf(w, "RemoteAddr = %q\n", r.RemoteAddr)
+	if err := r.ParseForm(); err != nil {
+		log.Print(err)
+	}
+	for k, v := range r.Form {
+		fmt.Fprintf(w, "Form[%q] = %q\n", k, v)
+	}
+}
@@ -0,0 +1,321 @@
+package main
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/gob"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// An entry is a distinct line: the line as first seen, how many times it
+// is seen, and where. First numbers the first occurrence across the
+// inputs, so that lines are reported in the order of the inputs.
+type entry struct {
+	Key   string // the line, as compared
+	Line  string
+	Count int
+	First int64
+	Locs  []loc
+}
+
+// A loc is a line of an input, the file being an index in index.files.
+type loc struct {
+	File int
+	Line int
+}
+
+// entryOverhead estimates the memory of an entry, but for its strings
+// and locations: its map slot, struct and string headers.
+const entryOverhead = 128
+
+// maxLevels bounds the re-partitioning of the partitions too large for
+// the memory. Beyond, like for a line seen at so many locations that
+// its entry alone is too large, a partition is merged in memory anyway.
+const maxLevels = 4
+
+// index counts the distinct lines. The entries are in memory until they
+// take more than maxMem bytes; they are then spilled to partitions on
+// disk, chosen by the hash of their key, and the memory is freed. The
+// entries of a key are all in the same partition, so that each partition
+// is merged on its own at the end. A partition still too large for the
+// memory is merged by an index of its own, which partitions it again
+// with another hash.
+type index struct {
+	files    []string // names of the inputs
+	withLocs bool     // whether to keep the locations of the lines
+	maxMem   int
+	dir      string // of the partitions, created in it when first spilled
+	parts    int    // number of partitions
+	level    int    // of re-partitioning, 0 for the index of the inputs
+
+	entries map[string]*entry
+	order   []*entry // the entries in memory, by first occurrence
+	mem     int      // estimated bytes of the entries in memory
+	seq     int64
+	spilled []*os.File // the partitions, once spilled
+	bufs    []*bufio.Writer
+	encs    []*gob.Encoder
+}
+
+func newIndex(maxMem, parts int, withLocs bool) *index {
+	return &index{
+		maxMem:   maxMem,
+		parts:    parts,
+		withLocs: withLocs,
+		entries:  make(map[string]*entry),
+	}
+}
+
+// add counts the line of key at the location.
+func (x *index) add(key, line string, at loc) error {
+	e := x.entries[key]
+	if e == nil {
+		e = &entry{Key: key, Line: line, First: x.seq}
+		x.entries[key] = e
+		x.order = append(x.order, e)
+		x.mem += entryOverhead + len(key) + len(line)
+	}
+	x.seq++
+	e.Count++
+	if x.withLocs {
+		e.Locs = append(e.Locs, at)
+		x.mem += 16
+	}
+	if x.mem > x.maxMem {
+		return x.spill()
+	}
+	return nil
+}
+
+// merge adds an entry of a partition to the ones of its key. The
+// entries of a partition come by first occurrence within each spill,
+// and the entries of a spill were all first seen after the ones of the
+// spills before, so that the order stays by first occurrence.
+func (x *index) merge(e *entry) error {
+	m := x.entries[e.Key]
+	if m == nil {
+		x.entries[e.Key] = e
+		x.order = append(x.order, e)
+		x.mem += entryOverhead + len(e.Key) + len(e.Line) + 16*len(e.Locs)
+	} else {
+		m.Count += e.Count
+		m.Locs = append(m.Locs, e.Locs...)
+		x.mem += 16 * len(e.Locs)
+		if e.First < m.First {
+			m.Line, m.First = e.Line, e.First
+		}
+	}
+	if x.mem > x.maxMem && x.level < maxLevels {
+		return x.spill()
+	}
+	return nil
+}
+
+// spill appends the entries in memory to their partitions.
+func (x *index) spill() error {
+	if x.spilled == nil {
+		dir, err := os.MkdirTemp(x.dir, "uniq")
+		if err != nil {
+			return err
+		}
+		x.dir = dir
+		for i := 0; i < x.parts; i++ {
+			f, err := os.Create(filepath.Join(dir, fmt.Sprintf("part%03d", i)))
+			if err != nil {
+				return err
+			}
+			b := bufio.NewWriter(f)
+			x.spilled = append(x.spilled, f)
+			x.bufs = append(x.bufs, b)
+			x.encs = append(x.encs, gob.NewEncoder(b))
+		}
+	}
+	for _, e := range x.order {
+		p := partition(e.Key, x.parts, x.level)
+		if err := x.encs[p].Encode(e); err != nil {
+			return fmt.Errorf("spilling to %s: %v", x.spilled[p].Name(), err)
+		}
+	}
+	for i, b := range x.bufs {
+		if err := b.Flush(); err != nil {
+			return fmt.Errorf("spilling to %s: %v", x.spilled[i].Name(), err)
+		}
+	}
+	x.entries = make(map[string]*entry)
+	x.order = nil
+	x.mem = 0
+	return nil
+}
+
+// close removes the partitions.
+func (x *index) close() error {
+	if x.spilled == nil {
+		return nil
+	}
+	for _, f := range x.spilled {
+		f.Close()
+	}
+	return os.RemoveAll(x.dir)
+}
+
+// partition returns the partition of the key, with a hash of its own
+// for each level of re-partitioning.
+func partition(key string, n, level int) int {
+	h := fnv.New64a()
+	h.Write([]byte{byte(level)})
+	io.WriteString(h, key)
+	return int(h.Sum64() % uint64(n))
+}
+
+// each calls f with the entries, by first occurrence. Once spilled, each
+// partition is merged and sorted into a run on disk, and the runs are
+// merged by first occurrence.
+func (x *index) each(f func(*entry) error) error {
+	if x.spilled == nil {
+		for _, e := range x.order {
+			if err := f(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := x.spill(); err != nil {
+		return err
+	}
+	var runs []*os.File
+	defer func() {
+		for _, r := range runs {
+			r.Close()
+		}
+	}()
+	for i, part := range x.spilled {
+		run, err := x.sortPartition(part, i)
+		if err != nil {
+			return err
+		}
+		runs = append(runs, run)
+	}
+	return mergeRuns(runs, f)
+}
+
+// sortPartition merges the entries of the spills of a partition, and
+// writes them by first occurrence to a run, a file next to it. The
+// entries are merged by an index at the next level, which spills them
+// in turn if they take more than the memory.
+func (x *index) sortPartition(part *os.File, i int) (*os.File, error) {
+	if _, err := part.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	sub := newIndex(x.maxMem, x.parts, x.withLocs)
+	sub.dir, sub.level = x.dir, x.level+1
+	defer sub.close()
+	dec := gob.NewDecoder(bufio.NewReader(part))
+	for {
+		e := new(entry)
+		err := dec.Decode(e)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %v", part.Name(), err)
+		}
+		if err := sub.merge(e); err != nil {
+			return nil, err
+		}
+	}
+
+	run, err := os.Create(filepath.Join(x.dir, fmt.Sprintf("run%03d", i)))
+	if err != nil {
+		return nil, err
+	}
+	b := bufio.NewWriter(run)
+	enc := gob.NewEncoder(b)
+	err = sub.each(func(e *entry) error { return enc.Encode(e) })
+	if err == nil {
+		err = b.Flush()
+	}
+	if err == nil {
+		_, err = run.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		run.Close()
+		return nil, fmt.Errorf("writing %s: %v", run.Name(), err)
+	}
+	// The partition is no longer needed.
+	if err := part.Truncate(0); err != nil {
+		run.Close()
+		return nil, err
+	}
+	return run, nil
+}
+
+// A runReader is a run being merged, and its next entry.
+type runReader struct {
+	f    *os.File
+	dec  *gob.Decoder
+	next *entry
+}
+
+// read reads the next entry of the run, nil at its end.
+func (r *runReader) read() error {
+	r.next = new(entry)
+	err := r.dec.Decode(r.next)
+	if err == io.EOF {
+		r.next = nil
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s: %v", r.f.Name(), err)
+	}
+	return nil
+}
+
+// runHeap is a heap of runs, by first occurrence of their next entry.
+type runHeap []*runReader
+
+func (h runHeap) Len() int            { return len(h) }
+func (h runHeap) Less(i, j int) bool  { return h[i].next.First < h[j].next.First }
+func (h runHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *runHeap) Push(x interface{}) { *h = append(*h, x.(*runReader)) }
+func (h *runHeap) Pop() interface{} {
+	old := *h
+	r := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return r
+}
+
+// mergeRuns calls f with the entries of the runs, by first occurrence.
+// The keys of the runs are distinct, the runs being of distinct
+// partitions.
+func mergeRuns(runs []*os.File, f func(*entry) error) error {
+	var h runHeap
+	for _, run := range runs {
+		r := &runReader{f: run, dec: gob.NewDecoder(bufio.NewReader(run))}
+		if err := r.read(); err != nil {
+			return err
+		}
+		if r.next != nil {
+			h = append(h, r)
+		}
+	}
+	heap.Init(&h)
+	for len(h) > 0 {
+		r := h[0]
+		if err := f(r.next); err != nil {
+			return err
+		}
+		if err := r.read(); err != nil {
+			return err
+		}
+		if r.next == nil {
+			heap.Pop(&h)
+		} else {
+			heap.Fix(&h, 0)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// collect returns the entries of the index, in their order.
+func collect(t *testing.T, x *index) []entry {
+	t.Helper()
+	var got []entry
+	if err := x.each(func(e *entry) error {
+		got = append(got, *e)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+// want returns the entries of the lines, counted in memory.
+func want(lines []string) []entry {
+	var entries []entry
+	byKey := make(map[string]int)
+	for n, line := range lines {
+		i, ok := byKey[line]
+		if !ok {
+			i = len(entries)
+			byKey[line] = i
+			entries = append(entries, entry{Key: line, Line: line, First: int64(n)})
+		}
+		entries[i].Count++
+		entries[i].Locs = append(entries[i].Locs, loc{0, n + 1})
+	}
+	return entries
+}
+
+func TestIndexSpill(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var lines []string
+	for i := 0; i < 5000; i++ {
+		// Skewed, so that some lines are seen in many spills.
+		lines = append(lines, fmt.Sprintf("line %d", r.Intn(1+r.Intn(2000))))
+	}
+	for _, tt := range []struct {
+		name   string
+		maxMem int
+		parts  int
+	}{
+		{"memory", 1 << 30, 4},
+		{"spilled", 64 << 10, 8},
+		// The partitions are too large for the memory, and are
+		// partitioned again, down to maxLevels.
+		{"repartitioned", 2 << 10, 2},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tmp := t.TempDir()
+			x := newIndex(tt.maxMem, tt.parts, true)
+			x.dir = tmp
+			x.files = []string{"test"}
+			for n, line := range lines {
+				if err := x.add(line, line, loc{0, n + 1}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got := collect(t, x)
+			if err := x.close(); err != nil {
+				t.Fatal(err)
+			}
+			w := want(lines)
+			if len(got) != len(w) {
+				t.Fatalf("%d entries, want %d", len(got), len(w))
+			}
+			for i := range w {
+				if !reflect.DeepEqual(got[i], w[i]) {
+					t.Fatalf("entry %d = %+v, want %+v", i, got[i], w[i])
+				}
+			}
+			if files, err := os.ReadDir(tmp); err != nil || len(files) != 0 {
+				t.Errorf("left %d files in the spill directory", len(files))
+			}
+		})
+	}
+}
+
+// TestIndexLargeEntry checks that a line seen too often for the memory
+// does not re-partition forever.
+func TestIndexLargeEntry(t *testing.T) {
+	x := newIndex(1<<10, 4, true)
+	x.dir = t.TempDir()
+	for n := 1; n <= 1000; n++ {
+		if err := x.add("same", "same", loc{0, n}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got := collect(t, x)
+	x.close()
+	if len(got) != 1 || got[0].Count != 1000 || len(got[0].Locs) != 1000 || got[0].Locs[999].Line != 1000 {
+		t.Errorf("entries = %d, want one of 1000 locations", len(got))
+	}
+}
@@ -1,89 +1,144 @@
+// Uniq reports the distinct lines of its inputs, the named files or the
+// standard input, like uniq(1) but without the lines having to be sorted:
+//
+//	uniq -d -c -l *.txt
+//
+// prints each line seen more than once, with its count and the files
+// and line numbers where it is. The lines are compared after skipping
+// leading fields (-f) or whitespace (-b), and folding case (-i).
+//
+// The distinct lines are kept in memory up to -mem megabytes; beyond,
+// they are spilled to files partitioned by hash, in -tmpdir, so that
+// inputs larger than the memory can be read. A partition too large for
+// the memory is partitioned again. The lines are printed in the order
+// they are first seen.
 package main
 
 import (
 	"bufio"
-	"os"
+	"flag"
 	"fmt"
-	"io/ioutil"
+	"io"
+	"os"
 	"strings"
+	"unicode"
 )
 
-func main() {
+var (
+	count   = flag.Bool("c", false, "prefix lines with their number of occurrences")
+	dups    = flag.Bool("d", false, "only print the lines seen more than once")
+	uniques = flag.Bool("u", false, "only print the lines seen once")
+	fold    = flag.Bool("i", false, "ignore case when comparing lines")
+	fields  = flag.Int("f", 0, "skip `n` leading fields when comparing lines")
+	blanks  = flag.Bool("b", false, "ignore leading whitespace when comparing lines")
+	locs    = flag.Bool("l", false, "print the files and line numbers of each line")
+	mem     = flag.Int("mem", 256, "`megabytes` of lines kept in memory before spilling to disk")
+	parts   = flag.Int("parts", 64, "number of partitions of the lines spilled to disk")
+	tmpdir  = flag.String("tmpdir", "", "directory of the spilled lines (default the system one)")
+)
 
-	// This reads from standard input and writes to standard output
-	counts := make(map[string] int)
-	input := bufio.NewScanner(os.Stdin)
-	for input.Scan() {
-		counts[input.Text()]++
+func main() {
+	flag.Parse()
+	if *dups && *uniques {
+		fmt.Fprintln(os.Stderr, "uniq: -d and -u exclude each other")
+		os.Exit(2)
 	}
-
-	for line, n := range counts {
-		if n > 1 {
-			fmt.Println(line, " ", n)
-		}
+	if *fields < 0 || *mem <= 0 || *parts <= 0 {
+		fmt.Fprintln(os.Stderr, "uniq: -f, -mem and -parts must not be negative, nor -mem and -parts zero")
+		os.Exit(2)
 	}
 
-
-	/*
-	The program below can either read from standard input or from a list
-	of named files.
-	It reads one line at a time from the file and processes it.
-	*/
-	counts = make(map[string] int)
-	files := os.Args[1:]
+	x := newIndex(*mem<<20, *parts, *locs)
+	x.dir = *tmpdir
+	status := 0
+	files := flag.Args()
 	if len(files) == 0 {
-		countLines(os.Stdin, counts)
-	} else {
-		for _, arg := range files {
-			f, err := os.Open(arg)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "dup2: %v\n", err)
-				continue
-			}
-			countLines(f, counts)
-			f.Close()
+		files = []string{"-"}
+	}
+	for _, arg := range files {
+		if err := countFile(x, arg); err != nil {
+			fmt.Fprintf(os.Stderr, "uniq: %v\n", err)
+			status = 1
 		}
 	}
 
-	for line, n := range counts {
-		if n > 1 {
-			fmt.Println(line, " ", n)
+	out := bufio.NewWriter(os.Stdout)
+	err := x.each(func(e *entry) error {
+		if *dups && e.Count == 1 || *uniques && e.Count > 1 {
+			return nil
+		}
+		if *count {
+			fmt.Fprintf(out, "%7d ", e.Count)
 		}
+		out.WriteString(e.Line)
+		if *locs {
+			for i, l := range e.Locs {
+				sep := " "
+				if i == 0 {
+					sep = "\t"
+				}
+				fmt.Fprintf(out, "%s%s:%d", sep, x.files[l.File], l.Line)
+			}
+		}
+		return out.WriteByte('\n')
+	})
+	if err == nil {
+		err = out.Flush()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "uniq: %v\n", err)
+		status = 1
 	}
+	x.close()
+	os.Exit(status)
+}
 
-	/*
-	The program below can either read from standard input or from a list
-	of named files.
-	It reads all the lines from the file into the memory and processes it.
-	 */
-	counts = make(map[string] int)
-	files = os.Args[1:]
-	if len(files) == 0 {
-		countLines(os.Stdin, counts)
+// countFile counts the lines of the named file, or of the standard input
+// for "-".
+func countFile(x *index, name string) error {
+	var f io.Reader = os.Stdin
+	if name == "-" {
+		name = "<stdin>"
 	} else {
-		for _, arg := range files {
-			data, err := ioutil.ReadFile(arg)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "dup2: %v\n", err)
-				continue
-			}
-			for _, line := range strings.Split(string(data), "\n") {
-				counts[line]++
-			}
+		file, err := os.Open(name)
+		if err != nil {
+			return err
 		}
+		defer file.Close()
+		f = file
 	}
-
-	for line, n := range counts {
-		if n > 1 {
-			fmt.Println(line, " ", n)
+	x.files = append(x.files, name)
+	file := len(x.files) - 1
+	input := bufio.NewScanner(f)
+	input.Buffer(nil, 64<<20) // lines up to 64MB
+	for n := 1; input.Scan(); n++ {
+		line := input.Text()
+		if err := x.add(key(line), line, loc{file, n}); err != nil {
+			return err
 		}
 	}
-
+	if err := input.Err(); err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	return nil
 }
-func countLines(f *os.File, counts map[string]int) {
-	input := bufio.NewScanner(f)
-	for input.Scan() {
-		counts[input.Text()]++
+
+// key returns the line as compared, per the flags.
+func key(line string) string {
+	for i := 0; i < *fields; i++ {
+		// A field is whitespace followed by non-whitespace, as in uniq(1).
+		line = strings.TrimLeftFunc(line, unicode.IsSpace)
+		if j := strings.IndexFunc(line, unicode.IsSpace); j >= 0 {
+			line = line[j:]
+		} else {
+			line = ""
+		}
 	}
-	// NOTE: ignoring potential errors from input.Err()
-}
\ No newline at end of file
+	if *blanks {
+		line = strings.TrimLeftFunc(line, unicode.IsSpace)
+	}
+	if *fold {
+		line = strings.ToLower(line)
+	}
+	return line
+}
@@ -0,0 +1,145 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cache is an on-disk copy of the issues of some repositories, so that
+// they can be queried offline. It is stored as a JSON file.
+type Cache struct {
+	// Issues holds the issues, by key.
+	Issues map[string]*Issue
+	// Synced holds the time of the last update seen by a sync, by repository.
+	Synced map[string]time.Time
+
+	path string
+}
+
+// issueKey returns the key of an issue, like "golang/go#1234".
+func issueKey(repo string, number int) string {
+	return repo + "#" + strconv.Itoa(number)
+}
+
+// RepoOf returns the name of the repository of an issue, like "golang/go".
+func RepoOf(issue *Issue) string {
+	i := strings.Index(issue.RepositoryURL, "/repos/")
+	if i < 0 {
+		return ""
+	}
+	return issue.RepositoryURL[i+len("/repos/"):]
+}
+
+// OpenCache reads the cache stored at path. The cache is empty
+// if there is no file at path yet.
+func OpenCache(path string) (*Cache, error) {
+	c := &Cache{Issues: make(map[string]*Issue), Synced: make(map[string]time.Time), path: path}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return c, nil
+}
+
+// Save writes the cache to its file. The file is replaced
+// at once, so that it is never left half written.
+func (c *Cache) Save() error {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), c.path)
+}
+
+// Sync fetches the issues of the repository updated since the last sync,
+// all of them the first time, and returns how many were new or changed.
+// The issues are fetched from the oldest update, and Synced is advanced
+// as they are, so that a sync stopped by an error, or by the limit of
+// 1000 results of the search API, is taken on by the next one.
+func (c *Cache) Sync(ctx context.Context, client *Client, repo string) (int, error) {
+	terms := []string{"repo:" + repo, "is:issue"}
+	if t, ok := c.Synced[repo]; ok {
+		// The search has a precision of a second: the issues updated
+		// in the same second as the last one seen may not have been,
+		// and are fetched again. Those unchanged are not counted.
+		terms = append(terms, "updated:>="+t.UTC().Format(time.RFC3339))
+	}
+	n := 0
+	it := client.SearchIssuesSorted(ctx, terms, "updated", "asc")
+	for it.Next() {
+		issue := it.Issue()
+		key := issueKey(repo, issue.Number)
+		if old, ok := c.Issues[key]; !ok || !old.UpdatedAt.Equal(issue.UpdatedAt) {
+			n++
+		}
+		c.Issues[key] = issue
+		if issue.UpdatedAt.After(c.Synced[repo]) {
+			c.Synced[repo] = issue.UpdatedAt
+		}
+	}
+	return n, it.Err()
+}
+
+// Query returns the issues matching the filters of Filter, like
+// state=open, and containing all the words, in their title or body.
+// An empty repo matches the issues of all the repositories.
+func (c *Cache) Query(repo string, filters url.Values, words []string) []*Issue {
+	var issues []*Issue
+	for _, issue := range c.Issues {
+		if repo == "" || RepoOf(issue) == repo {
+			issues = append(issues, issue)
+		}
+	}
+	var matched []*Issue
+	for _, issue := range Filter(issues, filters) {
+		if containsWords(issue.Title+"\n"+issue.Body, words) {
+			matched = append(matched, issue)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if a, b := RepoOf(matched[i]), RepoOf(matched[j]); a != b {
+			return a < b
+		}
+		return matched[i].Number < matched[j].Number
+	})
+	return matched
+}
+
+// containsWords reports whether the text contains all the words, ignoring case.
+func containsWords(text string, words []string) bool {
+	text = strings.ToLower(text)
+	for _, w := range words {
+		if !strings.Contains(text, strings.ToLower(w)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+// Cache syncs the issues of repositories to a cache, and queries them
+// offline:
+//
+//	cache [-db issues.json] sync golang/go golang/tools
+//	cache [-db issues.json] query [-repo golang/go] [-state open] [-label bug]
+//		[-author login] [-milestone title] [-age week] [-group] [words...]
+//
+// The filters are the ones of github.Filter. The -group flag groups the
+// issues by age bucket. The token of the client is set from $GITHUB_TOKEN.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+
+	"github.com/anjanashankar9/go-learning/ch4-composite-types/github"
+)
+
+func main() {
+	db := flag.String("db", "issues.json", "path of the cache")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		log.Fatal("usage: cache [-db path] sync|query ...")
+	}
+	c, err := github.OpenCache(*db)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch cmd, args := flag.Arg(0), flag.Args()[1:]; cmd {
+	case "sync":
+		client := github.NewClient(os.Getenv("GITHUB_TOKEN"))
+		var failed bool
+		for _, repo := range args {
+			n, err := c.Sync(context.Background(), client, repo)
+			fmt.Printf("%s: %d issues updated\n", repo, n)
+			if err != nil {
+				log.Printf("%s: %v", repo, err)
+				failed = true
+			}
+		}
+		// The issues fetched before an error are kept.
+		if err := c.Save(); err != nil {
+			log.Fatal(err)
+		}
+		if failed {
+			os.Exit(1)
+		}
+	case "query":
+		qs := flag.NewFlagSet("query", flag.ExitOnError)
+		repo := qs.String("repo", "", "repository of the issues")
+		group := qs.Bool("group", false, "group the issues by age")
+		filters := url.Values{}
+		for _, name := range []string{"state", "label", "author", "milestone", "age"} {
+			name := name
+			qs.Func(name, "only the issues with this "+name, func(v string) error {
+				filters.Set(name, v)
+				return nil
+			})
+		}
+		qs.Parse(args)
+		issues := c.Query(*repo, filters, qs.Args())
+		if !*group {
+			printCached(issues)
+			return
+		}
+		byAge := make(map[string][]*github.Issue)
+		for _, issue := range issues {
+			b := github.AgeBucket(issue)
+			byAge[b] = append(byAge[b], issue)
+		}
+		for _, b := range github.AgeBuckets {
+			if len(byAge[b.Name]) > 0 {
+				fmt.Printf("%s:\n", b.Name)
+				printCached(byAge[b.Name])
+			}
+		}
+	default:
+		log.Fatalf("cache: unknown command %q", cmd)
+	}
+}
+
+func printCached(issues []*github.Issue) {
+	for _, item := range issues {
+		fmt.Printf("%-20s #%-5d %9.9s %.55s\n", github.RepoOf(item), item.Number, github.Login(item),
+			item.Title)
+	}
+}
@@ -0,0 +1,88 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// searchIssues answers the searches with the issues updated at or after
+// the time of their updated:>= term, in the order of their updates.
+func searchIssues(issues *[]*Issue) func(http.ResponseWriter, *http.Request, int) bool {
+	return func(w http.ResponseWriter, r *http.Request, count int) bool {
+		var since time.Time
+		for _, term := range strings.Fields(r.URL.Query().Get("q")) {
+			if v := strings.TrimPrefix(term, "updated:>="); v != term {
+				since, _ = time.Parse(time.RFC3339, v)
+			}
+		}
+		var result IssuesSearchResult
+		for _, issue := range *issues {
+			if !issue.UpdatedAt.Before(since) {
+				result.Items = append(result.Items, issue)
+			}
+		}
+		result.TotalCount = len(result.Items)
+		json.NewEncoder(w).Encode(result)
+		return true
+	}
+}
+
+func TestCacheSync(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	issue := func(number int, updated time.Time, state string) *Issue {
+		return &Issue{
+			Number:        number,
+			Title:         fmt.Sprintf("issue %d", number),
+			State:         state,
+			User:          &User{Login: "gopher"},
+			UpdatedAt:     updated,
+			RepositoryURL: "https://api.github.com/repos/golang/go",
+		}
+	}
+	issues := []*Issue{issue(1, at, "open"), issue(2, at.Add(time.Hour), "open")}
+	s := newServer(t, 1, 0)
+	s.reply = searchIssues(&issues)
+	client := s.client()
+
+	path := filepath.Join(t.TempDir(), "issues.json")
+	c, err := OpenCache(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sync := func(want int) {
+		t.Helper()
+		n, err := c.Sync(context.Background(), client, "golang/go")
+		if err != nil || n != want {
+			t.Errorf("Sync = %d, %v, want %d updated", n, err, want)
+		}
+	}
+	sync(2)
+	// The last issue is fetched again, but it has not changed.
+	sync(0)
+	if q := s.requests[len(s.requests)-1].URL.Query().Get("q"); !strings.Contains(q, "updated:>=2024-03-01T13:00:00Z") {
+		t.Errorf("q = %q, want the issues updated since the last sync", q)
+	}
+	issues = append(issues, issue(1, at.Add(2*time.Hour), "closed"))[1:]
+	sync(1)
+
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if c, err = OpenCache(path); err != nil {
+		t.Fatal(err)
+	}
+	got := c.Query("golang/go", url.Values{"state": {"open"}}, []string{"ISSUE"})
+	if len(got) != 1 || got[0].Number != 2 {
+		t.Errorf("open issues = %v, want #2", got)
+	}
+	if got := c.Query("", url.Values{}, []string{"issue 1"}); len(got) != 1 || got[0].State != "closed" {
+		t.Errorf("issue 1 = %v, want it closed", got)
+	}
+}
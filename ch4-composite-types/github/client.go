@@ -0,0 +1,345 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Client is a client of the GitHub issues API. Unlike SearchIssues, it
+// authenticates with a token, follows the pages of the results, waits
+// when the rate limit is exhausted and makes conditional requests with
+// the ETags of the pages it has already fetched, which do not count
+// against the rate limit when they have not changed.
+type Client struct {
+	// BaseURL is the URL of the API, https://api.github.com by default.
+	BaseURL string
+	// Token is the personal access token sent with the requests, if any.
+	// Unauthenticated clients are limited to 10 searches a minute.
+	Token string
+	// PerPage is the number of issues in a page, 100 at most.
+	PerPage int
+	// HTTPClient is the client making the requests,
+	// http.DefaultClient by default.
+	HTTPClient *http.Client
+
+	mu    sync.Mutex
+	rate  Rate
+	pages map[string]page // by URL
+}
+
+// Rate is the rate limit of a client, as reported by the last response.
+type Rate struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// page is a cached response, sent again when the server answers
+// a conditional request with 304 Not Modified.
+type page struct {
+	etag string
+	body []byte
+	next string
+}
+
+// NewClient returns a client of api.github.com authenticating with the token.
+func NewClient(token string) *Client {
+	return &Client{
+		BaseURL: "https://api.github.com",
+		Token:   token,
+		PerPage: 100,
+	}
+}
+
+// Rate returns the rate limit reported by the last response.
+func (c *Client) Rate() Rate {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rate
+}
+
+// SearchIssues returns an iterator over the issues matching the terms.
+// The pages are fetched as the iterator advances.
+func (c *Client) SearchIssues(ctx context.Context, terms []string) *Issues {
+	return c.SearchIssuesSorted(ctx, terms, "", "")
+}
+
+// SearchIssuesSorted is like SearchIssues, with the issues sorted by a
+// field like "created" or "updated", in "asc" or "desc" order. The issues
+// are sorted by relevance if the field is "".
+func (c *Client) SearchIssuesSorted(ctx context.Context, terms []string, sort, order string) *Issues {
+	v := url.Values{"q": {strings.Join(terms, " ")}}
+	if sort != "" {
+		v.Set("sort", sort)
+		v.Set("order", order)
+	}
+	if c.PerPage > 0 {
+		v.Set("per_page", strconv.Itoa(c.PerPage))
+	}
+	return &Issues{c: c, ctx: ctx, next: strings.TrimSuffix(c.BaseURL, "/") + "/search/issues?" + v.Encode()}
+}
+
+// Issues iterates over the issues of a search, in the manner of
+// bufio.Scanner:
+//
+//	it := client.SearchIssues(ctx, terms)
+//	for it.Next() {
+//		issue := it.Issue()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Issues struct {
+	c     *Client
+	ctx   context.Context
+	next  string // URL of the next page, "" after the last one
+	items []*Issue
+	issue *Issue
+	total int
+	err   error
+}
+
+// Next advances to the next issue, fetching the next page if needed.
+// It returns false at the end of the results or on error.
+func (it *Issues) Next() bool {
+	for len(it.items) == 0 {
+		if it.err != nil || it.next == "" {
+			it.issue = nil
+			return false
+		}
+		var result *IssuesSearchResult
+		result, it.next, it.err = it.c.get(it.ctx, it.next)
+		if it.err != nil {
+			continue
+		}
+		it.items, it.total = result.Items, result.TotalCount
+	}
+	it.issue, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Issue returns the current issue.
+func (it *Issues) Issue() *Issue { return it.issue }
+
+// TotalCount returns the number of issues matching the search,
+// once the first page is fetched.
+func (it *Issues) TotalCount() int { return it.total }
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Issues) Err() error { return it.err }
+
+// get fetches the page at rawurl, and returns it with the URL of the next page.
+func (c *Client) get(ctx context.Context, rawurl string) (*IssuesSearchResult, string, error) {
+	for attempt := 0; ; attempt++ {
+		if err := c.waitRate(ctx); err != nil {
+			return nil, "", err
+		}
+		req, err := c.newRequest(ctx, http.MethodGet, rawurl, nil)
+		if err != nil {
+			return nil, "", err
+		}
+		c.mu.Lock()
+		cached, ok := c.pages[rawurl]
+		c.mu.Unlock()
+		if ok {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+
+		resp, body, err := c.send(req)
+		if err != nil {
+			return nil, "", err
+		}
+		rate, hasRate := parseRate(resp.Header)
+
+		switch {
+		case resp.StatusCode == http.StatusNotModified && ok:
+			body = cached.body
+		case resp.StatusCode == http.StatusOK:
+			cached = page{etag: resp.Header.Get("ETag"), body: body, next: nextLink(resp.Header.Get("Link"))}
+			if cached.etag != "" {
+				c.mu.Lock()
+				if c.pages == nil {
+					c.pages = make(map[string]page)
+				}
+				c.pages[rawurl] = cached
+				c.mu.Unlock()
+			}
+		case limited(resp, rate, hasRate):
+			// Try again once the limit is reset, but not at once if the
+			// reset is already past: the clocks may differ.
+			d := retryAfter(resp.Header)
+			if hasRate && time.Until(rate.Reset) > d {
+				d = time.Until(rate.Reset)
+			}
+			if b := backoff(attempt); d < b {
+				d = b
+			}
+			if err := sleep(ctx, d); err != nil {
+				return nil, "", err
+			}
+			continue
+		default:
+			return nil, "", fmt.Errorf("search query failed : %s", resp.Status)
+		}
+
+		var result IssuesSearchResult
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, "", err
+		}
+		return &result, cached.next, nil
+	}
+}
+
+// newRequest returns an authenticated request of the API.
+// The body, if any, is encoded in JSON.
+func (c *Client) newRequest(ctx context.Context, method, rawurl string, body interface{}) (*http.Request, error) {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rawurl, r)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	return req, nil
+}
+
+// send sends the request, and returns the response with its body read.
+// It records the rate limit reported by the response.
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+	if rate, ok := parseRate(resp.Header); ok {
+		c.mu.Lock()
+		c.rate = rate
+		c.mu.Unlock()
+	}
+	return resp, body, nil
+}
+
+// waitRate blocks until the rate limit is reset, if it is exhausted.
+func (c *Client) waitRate(ctx context.Context) error {
+	c.mu.Lock()
+	rate := c.rate
+	c.mu.Unlock()
+	if rate.Limit == 0 || rate.Remaining > 0 {
+		return nil
+	}
+	return sleep(ctx, time.Until(rate.Reset))
+}
+
+// limited reports whether the response is a rejection
+// because of the primary or the secondary rate limit.
+func limited(resp *http.Response, rate Rate, hasRate bool) bool {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	return (hasRate && rate.Remaining == 0) || resp.Header.Get("Retry-After") != ""
+}
+
+// parseRate parses the X-RateLimit headers of a response.
+func parseRate(h http.Header) (Rate, bool) {
+	limit, err1 := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	remaining, err2 := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	reset, err3 := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return Rate{}, false
+	}
+	return Rate{Limit: limit, Remaining: remaining, Reset: time.Unix(reset, 0)}, true
+}
+
+// minBackoff and maxBackoff bound the wait before a request
+// rejected by the rate limit is sent again.
+var (
+	minBackoff = time.Second
+	maxBackoff = time.Minute
+)
+
+// backoff returns the least wait before the attempt-th retry
+// of a rejected request, doubling from minBackoff.
+func backoff(attempt int) time.Duration {
+	d := minBackoff
+	for i := 0; i < attempt && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
+
+// retryAfter returns the delay of the Retry-After header, in seconds.
+func retryAfter(h http.Header) time.Duration {
+	secs, err := strconv.Atoi(h.Get("Retry-After"))
+	if err != nil {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
+
+// nextLink returns the URL of the next page in a Link header like
+//
+//	<https://api.github.com/search/issues?q=go&page=2>; rel="next", <...>; rel="last"
+//
+// or "" if there is none.
+func nextLink(header string) string {
+	for _, link := range strings.Split(header, ",") {
+		parts := strings.Split(link, ";")
+		if len(parts) < 2 {
+			continue
+		}
+		for _, p := range parts[1:] {
+			if strings.TrimSpace(p) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(parts[0]), "<>")
+			}
+		}
+	}
+	return ""
+}
+
+// sleep waits for d, or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,229 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// server is a fake search API serving pages of n issues each.
+type server struct {
+	*httptest.Server
+	pages int
+	n     int
+
+	mu       sync.Mutex
+	requests []*http.Request
+	// reply, if set, answers the requests instead of the pages.
+	reply func(w http.ResponseWriter, r *http.Request, count int) bool
+}
+
+func newServer(t *testing.T, pages, n int) *server {
+	s := &server{pages: pages, n: n}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r)
+	count := len(s.requests)
+	s.mu.Unlock()
+	if s.reply != nil && s.reply(w, r, count) {
+		return
+	}
+	p, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if p == 0 {
+		p = 1
+	}
+	etag := fmt.Sprintf(`"page%d"`, p)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	if p < s.pages {
+		q := r.URL.Query()
+		q.Set("page", strconv.Itoa(p+1))
+		w.Header().Set("Link", fmt.Sprintf(`<%s%s?%s>; rel="next", <%s/last>; rel="last"`, s.URL, r.URL.Path, q.Encode(), s.URL))
+	}
+	w.Header().Set("ETag", etag)
+	result := IssuesSearchResult{TotalCount: s.pages * s.n}
+	for i := 0; i < s.n; i++ {
+		result.Items = append(result.Items, &Issue{Number: (p-1)*s.n + i + 1})
+	}
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *server) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.requests)
+}
+
+func (s *server) client() *Client {
+	c := NewClient("secret")
+	c.BaseURL = s.URL
+	c.PerPage = s.n
+	return c
+}
+
+// numbers returns the numbers of the issues of the search.
+func numbers(it *Issues) ([]int, error) {
+	var ns []int
+	for it.Next() {
+		ns = append(ns, it.Issue().Number)
+	}
+	return ns, it.Err()
+}
+
+func TestSearchIssuesPages(t *testing.T) {
+	s := newServer(t, 3, 2)
+	it := s.client().SearchIssuesSorted(context.Background(), []string{"repo:golang/go", "is:open"}, "updated", "asc")
+	got, err := numbers(it)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(got) != "[1 2 3 4 5 6]" || it.TotalCount() != 6 {
+		t.Errorf("issues = %v of %d, want [1 2 3 4 5 6] of 6", got, it.TotalCount())
+	}
+	if s.count() != 3 {
+		t.Errorf("%d requests, want one per page", s.count())
+	}
+	r := s.requests[0]
+	q := r.URL.Query()
+	if q.Get("q") != "repo:golang/go is:open" || q.Get("sort") != "updated" || q.Get("order") != "asc" || q.Get("per_page") != "2" {
+		t.Errorf("query = %v", q)
+	}
+	if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("Authorization = %q", got)
+	}
+}
+
+func TestSearchIssuesETag(t *testing.T) {
+	s := newServer(t, 2, 2)
+	c := s.client()
+	for i := 0; i < 2; i++ {
+		got, err := numbers(c.SearchIssues(context.Background(), []string{"go"}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(got) != "[1 2 3 4]" {
+			t.Errorf("search %d: issues = %v, want [1 2 3 4]", i+1, got)
+		}
+	}
+	// The second search revalidates the pages of the first one.
+	for i, r := range s.requests {
+		want := ""
+		if i >= 2 {
+			want = fmt.Sprintf(`"page%d"`, i-1)
+		}
+		if got := r.Header.Get("If-None-Match"); got != want {
+			t.Errorf("request %d: If-None-Match = %q, want %q", i+1, got, want)
+		}
+	}
+}
+
+// limit answers the first requests, up to n, with a rejection by the
+// rate limit resetting at reset.
+func limit(n int, reset time.Time) func(http.ResponseWriter, *http.Request, int) bool {
+	return func(w http.ResponseWriter, r *http.Request, count int) bool {
+		if count > n {
+			w.Header().Set("X-RateLimit-Limit", "30")
+			w.Header().Set("X-RateLimit-Remaining", "29")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+			return false
+		}
+		w.Header().Set("X-RateLimit-Limit", "30")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		http.Error(w, "API rate limit exceeded", http.StatusForbidden)
+		return true
+	}
+}
+
+func TestSearchIssuesRateLimit(t *testing.T) {
+	defer func(d time.Duration) { minBackoff = d }(minBackoff)
+	minBackoff = 10 * time.Millisecond
+
+	s := newServer(t, 1, 2)
+	s.reply = limit(2, time.Now().Add(-time.Minute))
+	c := s.client()
+	start := time.Now()
+	got, err := numbers(c.SearchIssues(context.Background(), []string{"go"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(got) != "[1 2]" || s.count() != 3 {
+		t.Errorf("issues = %v after %d requests, want [1 2] after 3", got, s.count())
+	}
+	// The retries back off, although the reset is past.
+	if d := time.Since(start); d < 30*time.Millisecond {
+		t.Errorf("retried within %v, want at least 30ms", d)
+	}
+	if r := c.Rate(); r.Limit != 30 || r.Remaining != 29 {
+		t.Errorf("rate = %+v, want 29 of 30 remaining", r)
+	}
+}
+
+func TestSearchIssuesRateLimitBackoff(t *testing.T) {
+	defer func(d time.Duration) { minBackoff = d }(minBackoff)
+	minBackoff = 10 * time.Millisecond
+
+	s := newServer(t, 1, 2)
+	s.reply = limit(1<<30, time.Now().Add(-time.Minute))
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err := numbers(s.client().SearchIssues(ctx, []string{"go"}))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("search = %v, want %v", err, context.DeadlineExceeded)
+	}
+	// 10, 20, 40 and 80ms between the requests.
+	if n := s.count(); n > 6 {
+		t.Errorf("%d requests in 200ms, want the retries to back off", n)
+	}
+}
+
+func TestSearchIssuesWaitsForReset(t *testing.T) {
+	s := newServer(t, 1, 2)
+	s.reply = limit(1, time.Now().Add(time.Hour))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := numbers(s.client().SearchIssues(ctx, []string{"go"}))
+	if !errors.Is(err, context.DeadlineExceeded) || s.count() != 1 {
+		t.Errorf("search = %v after %d requests, want to wait for the reset after 1", err, s.count())
+	}
+}
+
+func TestSearchIssuesError(t *testing.T) {
+	s := newServer(t, 1, 2)
+	s.reply = func(w http.ResponseWriter, r *http.Request, count int) bool {
+		http.Error(w, "Validation Failed", http.StatusUnprocessableEntity)
+		return true
+	}
+	if _, err := numbers(s.client().SearchIssues(context.Background(), []string{"go"})); err == nil {
+		t.Error("search succeeded on a 422")
+	}
+	if s.count() != 1 {
+		t.Errorf("%d requests, want no retry", s.count())
+	}
+}
+
+func TestNextLink(t *testing.T) {
+	for header, want := range map[string]string{
+		`<https://api.github.com/search/issues?q=go&page=2>; rel="next", <https://api.github.com/search/issues?q=go&page=9>; rel="last"`: "https://api.github.com/search/issues?q=go&page=2",
+		`<https://api.github.com/search/issues?q=go&page=1>; rel="prev"`:                                                                 "",
+		``: "",
+	} {
+		if got := nextLink(header); got != want {
+			t.Errorf("nextLink(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+// Dashboard serves the issues matching the arguments as a web page,
+// instead of writing the issueList table to stdout once:
+//
+//	dashboard [-addr localhost:8000] [-refresh 5m] repo:golang/go is:open json decoder
+//
+// The search is run again in the background every refresh period, and
+// the pages are rendered from the last results:
+//
+//	/                   the issues, filtered by the query string
+//	/milestones         the milestones, with the number of issues in each
+//	/users/             the authors, with the number of issues of each
+//	/users/{login}      the issues of an author
+//
+// The list of issues may be filtered with the state, label, author,
+// milestone and age parameters, and sorted by clicking on the columns.
+// The age is one of the buckets of github.AgeBuckets, like age=week.
+// The token of the client is set from $GITHUB_TOKEN.
+package main
+
+import (
+	"context"
+	"flag"
+	"html/template"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anjanashankar9/go-learning/ch4-composite-types/github"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8000", "address to listen on")
+	refresh := flag.Duration("refresh", 5*time.Minute, "period of the searches")
+	flag.Parse()
+
+	d := &dashboard{client: github.NewClient(os.Getenv("GITHUB_TOKEN")), terms: flag.Args()}
+	go d.run(context.Background(), *refresh)
+
+	http.HandleFunc("/", d.list)
+	http.HandleFunc("/milestones", d.milestones)
+	http.HandleFunc("/users/", d.users)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
+
+// dashboard holds the last results of the search.
+type dashboard struct {
+	client *github.Client
+	terms  []string
+
+	mu      sync.RWMutex
+	issues  []*github.Issue
+	updated time.Time
+	err     error // of the last search
+}
+
+// run searches the issues every period, until the context is done.
+func (d *dashboard) run(ctx context.Context, period time.Duration) {
+	tick := time.NewTicker(period)
+	defer tick.Stop()
+	for {
+		d.search(ctx)
+		select {
+		case <-tick.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// search runs the search, and keeps the results if it succeeds.
+// The client sends the ETags of the pages of the previous search,
+// so unchanged results are not counted against the rate limit.
+func (d *dashboard) search(ctx context.Context) {
+	var issues []*github.Issue
+	it := d.client.SearchIssues(ctx, d.terms)
+	for it.Next() {
+		issues = append(issues, it.Issue())
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.err = it.Err(); d.err != nil {
+		log.Printf("dashboard: %v", d.err)
+		return
+	}
+	d.issues, d.updated = issues, time.Now()
+}
+
+// snapshot returns the last results of the search.
+func (d *dashboard) snapshot() ([]*github.Issue, time.Time, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.issues, d.updated, d.err
+}
+
+// columns are the sortable columns of the issue list, by sort parameter.
+var columns = []struct {
+	Key, Title string
+	less       func(a, b *github.Issue) bool
+}{
+	{"number", "#", func(a, b *github.Issue) bool { return a.Number < b.Number }},
+	{"state", "State", func(a, b *github.Issue) bool { return a.State < b.State }},
+	{"user", "User", func(a, b *github.Issue) bool { return github.Login(a) < github.Login(b) }},
+	{"title", "Title", func(a, b *github.Issue) bool { return strings.ToLower(a.Title) < strings.ToLower(b.Title) }},
+	{"age", "Age", func(a, b *github.Issue) bool { return a.CreatedAt.After(b.CreatedAt) }},
+}
+
+// column is a header of the issue list, linking to the list sorted by it.
+type column struct {
+	Title, URL, Arrow string
+}
+
+// sortIssues sorts the issues by the sort parameter of the query string,
+// in descending order if desc is set, and returns the headers of the list.
+func sortIssues(issues []*github.Issue, q url.Values) []column {
+	key, desc := q.Get("sort"), q.Get("desc") != ""
+	var cols []column
+	for _, c := range columns {
+		v := url.Values{}
+		for k, vs := range q {
+			v[k] = vs
+		}
+		v.Set("sort", c.Key)
+		v.Del("desc")
+		col := column{Title: c.Title}
+		if c.Key == key {
+			less := c.less
+			if desc {
+				col.Arrow = "▼"
+				sort.SliceStable(issues, func(i, j int) bool { return less(issues[j], issues[i]) })
+			} else {
+				col.Arrow = "▲"
+				v.Set("desc", "1")
+				sort.SliceStable(issues, func(i, j int) bool { return less(issues[i], issues[j]) })
+			}
+		}
+		col.URL = "?" + v.Encode()
+		cols = append(cols, col)
+	}
+	return cols
+}
+
+// list serves the issue list.
+func (d *dashboard) list(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	d.render(w, r, "Issues", r.URL.Query())
+}
+
+// render writes the page of the issues matching the filters.
+func (d *dashboard) render(w http.ResponseWriter, r *http.Request, title string, q url.Values) {
+	issues, updated, err := d.snapshot()
+	issues = github.Filter(issues, q)
+	cols := sortIssues(issues, q)
+	data := struct {
+		Title   string
+		Issues  []*github.Issue
+		Columns []column
+		Filters url.Values
+		Ages    interface{}
+		Updated time.Time
+		Err     error
+	}{title, issues, cols, q, github.AgeBuckets, updated, err}
+	if err := dashboardList.Execute(w, data); err != nil {
+		log.Print(err)
+	}
+}
+
+// count is a row of the milestone and user pages.
+type count struct {
+	Name, URL string
+	Open      int
+	Closed    int
+}
+
+// counts counts the open and closed issues by the key
+// of each issue, and returns them sorted by name.
+func counts(issues []*github.Issue, key func(*github.Issue) (name, url string)) []*count {
+	byName := make(map[string]*count)
+	var cs []*count
+	for _, issue := range issues {
+		name, u := key(issue)
+		if name == "" {
+			continue
+		}
+		c := byName[name]
+		if c == nil {
+			c = &count{Name: name, URL: u}
+			byName[name] = c
+			cs = append(cs, c)
+		}
+		if issue.State == "closed" {
+			c.Closed++
+		} else {
+			c.Open++
+		}
+	}
+	sort.Slice(cs, func(i, j int) bool { return cs[i].Name < cs[j].Name })
+	return cs
+}
+
+// milestones serves the milestones of the issues.
+func (d *dashboard) milestones(w http.ResponseWriter, r *http.Request) {
+	issues, _, _ := d.snapshot()
+	cs := counts(issues, func(issue *github.Issue) (string, string) {
+		if issue.Milestone == nil {
+			return "", ""
+		}
+		return issue.Milestone.Title, "/?" + url.Values{"milestone": {issue.Milestone.Title}}.Encode()
+	})
+	if err := dashboardCounts.Execute(w, struct {
+		Title  string
+		Counts []*count
+	}{"Milestones", cs}); err != nil {
+		log.Print(err)
+	}
+}
+
+// users serves the authors of the issues, or the issues of an author.
+func (d *dashboard) users(w http.ResponseWriter, r *http.Request) {
+	if name := strings.TrimPrefix(r.URL.Path, "/users/"); name != "" {
+		q := r.URL.Query()
+		q.Set("author", name)
+		d.render(w, r, "Issues of "+name, q)
+		return
+	}
+	issues, _, _ := d.snapshot()
+	cs := counts(issues, func(issue *github.Issue) (string, string) {
+		return github.Login(issue), "/users/" + url.PathEscape(github.Login(issue))
+	})
+	if err := dashboardCounts.Execute(w, struct {
+		Title  string
+		Counts []*count
+	}{"Users", cs}); err != nil {
+		log.Print(err)
+	}
+}
+
+// The pages use html/template, unlike issueList, since the
+// issues are written by anyone.
+const dashboardNav = `{{define "nav"}}<p><a href="/">Issues</a> | <a href="/milestones">Milestones</a> | <a href="/users/">Users</a></p>{{end}}`
+
+var dashboardList = template.Must(template.New("list").
+	Funcs(template.FuncMap{"daysAgo": github.DaysAgo}).
+	Parse(dashboardNav + `
+<!DOCTYPE html>
+<title>{{.Title}}</title>
+{{template "nav"}}
+<h1>{{.Title}}: {{len .Issues}} issues</h1>
+{{if .Err}}<p style="color: red">Last search failed: {{.Err}}</p>{{end}}
+<form>
+  {{range $k, $v := .Filters}}{{if eq $k "sort" "desc"}}<input type="hidden" name="{{$k}}" value="{{index $v 0}}">{{end}}{{end}}
+  <select name="state">
+    <option value="">any state</option>
+    <option{{if eq (.Filters.Get "state") "open"}} selected{{end}}>open</option>
+    <option{{if eq (.Filters.Get "state") "closed"}} selected{{end}}>closed</option>
+  </select>
+  <input name="label" placeholder="label" value="{{.Filters.Get "label"}}">
+  <input name="author" placeholder="author" value="{{.Filters.Get "author"}}">
+  <input name="milestone" placeholder="milestone" value="{{.Filters.Get "milestone"}}">
+  <select name="age">
+    <option value="">any age</option>
+    {{$age := .Filters.Get "age"}}{{range .Ages}}<option{{if eq $age .Name}} selected{{end}}>{{.Name}}</option>{{end}}
+  </select>
+  <button>Filter</button>
+</form>
+<table>
+<tr style='text-align: left'>
+  {{range .Columns}}<th><a href="{{.URL}}">{{.Title}}</a> {{.Arrow}}</th>{{end}}
+  <th>Labels</th>
+  <th>Milestone</th>
+</tr>
+{{range .Issues}}
+<tr>
+  <td><a href='{{.HTMLURL}}'>{{.Number}}</a></td>
+  <td>{{.State}}</td>
+  <td>{{if .User}}<a href='/users/{{.User.Login}}'>{{.User.Login}}</a>{{end}}</td>
+  <td><a href='{{.HTMLURL}}'>{{.Title}}</a></td>
+  <td>{{.CreatedAt | daysAgo}} days</td>
+  <td>{{range .Labels}}<a href='/?label={{.Name}}'>{{.Name}}</a> {{end}}</td>
+  <td>{{with .Milestone}}<a href='/?milestone={{.Title}}'>{{.Title}}</a>{{end}}</td>
+</tr>
+{{end}}
+</table>
+<p>Updated {{.Updated.Format "15:04:05"}}</p>
+`))
+
+var dashboardCounts = template.Must(template.New("counts").Parse(dashboardNav + `
+<!DOCTYPE html>
+<title>{{.Title}}</title>
+{{template "nav"}}
+<h1>{{.Title}}</h1>
+<table>
+<tr style='text-align: left'><th>Name</th><th>Open</th><th>Closed</th></tr>
+{{range .Counts}}
+<tr><td><a href='{{.URL}}'>{{.Name}}</a></td><td>{{.Open}}</td><td>{{.Closed}}</td></tr>
+{{end}}
+</table>
+`))
@@ -0,0 +1,78 @@
+package github
+
+import (
+	"net/url"
+	"time"
+)
+
+// AgeBuckets are the values of the age filter, with the
+// number of days below which an issue falls in the bucket.
+var AgeBuckets = []struct {
+	Name string
+	Days int
+}{
+	{"day", 1},
+	{"week", 7},
+	{"month", 30},
+	{"year", 365},
+	{"older", -1},
+}
+
+// DaysAgo returns the number of days elapsed since t.
+func DaysAgo(t time.Time) int {
+	return int(time.Since(t).Hours() / 24)
+}
+
+// AgeBucket returns the age bucket of an issue.
+func AgeBucket(issue *Issue) string {
+	days := DaysAgo(issue.CreatedAt)
+	for _, b := range AgeBuckets {
+		if days < b.Days {
+			return b.Name
+		}
+	}
+	return "older"
+}
+
+// Filter returns the issues matching the filters of a query string,
+// like state=open&label=bug. The filters are state, author, milestone,
+// label and age, one of the AgeBuckets.
+func Filter(issues []*Issue, q url.Values) []*Issue {
+	var matched []*Issue
+	for _, issue := range issues {
+		if s := q.Get("state"); s != "" && issue.State != s {
+			continue
+		}
+		if a := q.Get("author"); a != "" && (issue.User == nil || issue.User.Login != a) {
+			continue
+		}
+		if m := q.Get("milestone"); m != "" && (issue.Milestone == nil || issue.Milestone.Title != m) {
+			continue
+		}
+		if l := q.Get("label"); l != "" && !hasLabel(issue, l) {
+			continue
+		}
+		if a := q.Get("age"); a != "" && AgeBucket(issue) != a {
+			continue
+		}
+		matched = append(matched, issue)
+	}
+	return matched
+}
+
+func hasLabel(issue *Issue, name string) bool {
+	for _, l := range issue.Labels {
+		if l.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Login returns the login of the author of an issue, or "" if it has none.
+func Login(issue *Issue) string {
+	if issue.User == nil {
+		return ""
+	}
+	return issue.User.Login
+}
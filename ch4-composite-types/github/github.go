@@ -1,4 +1,17 @@
-package main
+// Package github is a client of the GitHub issues API. The programs
+// using it are in the subdirectories:
+//
+//	search        lists the issues of a search
+//	issuesreport  prints them with a text template
+//	issueshtml    prints them as an HTML table
+//	issues        creates, views, edits and closes issues
+//	dashboard     serves the issues of a search as a web page
+//	cache         syncs the issues of repositories, and queries them offline
+//
+// They are part of the ch4-composite-types module:
+//
+//	cd ch4-composite-types && go run ./github/search repo:golang/go is:open json decoder
+package github
 
 import (
 	"encoding/json"
@@ -22,14 +35,33 @@ type Issue struct {
 	Title     string
 	State     string
 	User      *User
+	Labels    []*Label
+	Milestone *Milestone
 	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 	Body      string    // in Markdown format
+
+	// RepositoryURL is the API URL of the repository of the issue,
+	// like https://api.github.com/repos/golang/go.
+	RepositoryURL string `json:"repository_url"`
 }
 type User struct {
 	Login   string
 	HTMLURL string `json:"html_url"`
 }
 
+type Label struct {
+	Name  string
+	Color string // in hex, like "d73a4a"
+}
+
+type Milestone struct {
+	Number  int
+	Title   string
+	State   string
+	HTMLURL string `json:"html_url"`
+}
+
 func SearchIssues(terms []string) (*IssuesSearchResult, error) {
 	q := url.QueryEscape(strings.Join(terms, " "))
 
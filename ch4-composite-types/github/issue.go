@@ -0,0 +1,76 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// IssueRequest holds the fields of an issue to create.
+type IssueRequest struct {
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+}
+
+// IssueEdit holds the fields of an issue to edit. The nil fields are
+// left unchanged, so that an empty Body clears the body of the issue.
+type IssueEdit struct {
+	Title *string `json:"title,omitempty"`
+	Body  *string `json:"body,omitempty"`
+	State *string `json:"state,omitempty"` // "open" or "closed"
+}
+
+// GetIssue returns the issue of the repository, named like "golang/go".
+func (c *Client) GetIssue(ctx context.Context, repo string, number int) (*Issue, error) {
+	return c.issue(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/issues/%d", repo, number), nil)
+}
+
+// CreateIssue creates an issue in the repository.
+func (c *Client) CreateIssue(ctx context.Context, repo string, r *IssueRequest) (*Issue, error) {
+	return c.issue(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/issues", repo), r)
+}
+
+// EditIssue changes an issue of the repository.
+func (c *Client) EditIssue(ctx context.Context, repo string, number int, e *IssueEdit) (*Issue, error) {
+	return c.issue(ctx, http.MethodPatch, fmt.Sprintf("/repos/%s/issues/%d", repo, number), e)
+}
+
+// CloseIssue closes an issue of the repository.
+func (c *Client) CloseIssue(ctx context.Context, repo string, number int) (*Issue, error) {
+	closed := "closed"
+	return c.EditIssue(ctx, repo, number, &IssueEdit{State: &closed})
+}
+
+// issue sends a request of the issues API, with the body encoded
+// in JSON if not nil, and decodes the issue returned.
+func (c *Client) issue(ctx context.Context, method, path string, body interface{}) (*Issue, error) {
+	for {
+		if err := c.waitRate(ctx); err != nil {
+			return nil, err
+		}
+		req, err := c.newRequest(ctx, method, strings.TrimSuffix(c.BaseURL, "/")+path, body)
+		if err != nil {
+			return nil, err
+		}
+		resp, b, err := c.send(req)
+		if err != nil {
+			return nil, err
+		}
+		switch rate, ok := parseRate(resp.Header); {
+		case resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated:
+			var issue Issue
+			if err := json.Unmarshal(b, &issue); err != nil {
+				return nil, err
+			}
+			return &issue, nil
+		case limited(resp, rate, ok):
+			if err := sleep(ctx, retryAfter(resp.Header)); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("%s %s failed : %s", method, path, resp.Status)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEditIssue(t *testing.T) {
+	s := newServer(t, 1, 1)
+	var bodies []string
+	s.reply = func(w http.ResponseWriter, r *http.Request, count int) bool {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, r.Method+" "+r.URL.Path+" "+string(b))
+		json.NewEncoder(w).Encode(&Issue{Number: 7, Title: "title"})
+		return true
+	}
+	c := s.client()
+	ctx := context.Background()
+	title, empty := "title", ""
+	if _, err := c.EditIssue(ctx, "golang/go", 7, &IssueEdit{Title: &title, Body: &empty}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CloseIssue(ctx, "golang/go", 7); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreateIssue(ctx, "golang/go", &IssueRequest{Title: "title"}); err != nil {
+		t.Fatal(err)
+	}
+	// The body is cleared, and the nil fields are left out.
+	want := []string{
+		`PATCH /repos/golang/go/issues/7 {"title":"title","body":""}`,
+		`PATCH /repos/golang/go/issues/7 {"state":"closed"}`,
+		`POST /repos/golang/go/issues {"title":"title"}`,
+	}
+	if strings.Join(bodies, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests:\n%s\nwant:\n%s", strings.Join(bodies, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	result := &IssuesSearchResult{TotalCount: 1, Items: []*Issue{{
+		Number:    7,
+		HTMLURL:   "https://github.com/golang/go/issues/7",
+		Title:     "<b>bold</b>",
+		State:     "open",
+		User:      &User{Login: "gopher"},
+		CreatedAt: time.Now().Add(-49 * time.Hour),
+	}}}
+	var text, html bytes.Buffer
+	if err := Report.Execute(&text, result); err != nil {
+		t.Fatal(err)
+	}
+	if err := IssueList.Execute(&html, result); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(text.String(), "Age:    2 days") || !strings.Contains(text.String(), "Title:  <b>bold</b>") {
+		t.Errorf("report:\n%s", text.String())
+	}
+	if !strings.Contains(html.String(), "&lt;b&gt;bold&lt;/b&gt;") {
+		t.Errorf("issue list does not escape the title:\n%s", html.String())
+	}
+}
@@ -0,0 +1,183 @@
+// Issues creates, views, edits and closes the issues of a repository:
+//
+//	issues [--format text|html] create owner/repo
+//	issues [--format text|html] view owner/repo number
+//	issues [--format text|html] edit owner/repo number
+//	issues [--format text|html] close owner/repo number
+//
+// The titles and bodies are written in $VISUAL or $EDITOR, the way git
+// has commit messages written. The issues are printed with the
+// github.Report and github.IssueList templates.
+// The token of the client is set from $GITHUB_TOKEN.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/anjanashankar9/go-learning/ch4-composite-types/github"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("issues: ")
+	format := flag.String("format", "text", "output format, text or html")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: issues [--format text|html] create|view|edit|close owner/repo [number]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	args := flag.Args()
+	if len(args) < 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	var templ executor
+	switch *format {
+	case "text":
+		templ = github.Report
+	case "html":
+		templ = github.IssueList
+	default:
+		log.Fatalf("unknown format %q", *format)
+	}
+
+	client := github.NewClient(os.Getenv("GITHUB_TOKEN"))
+	ctx := context.Background()
+	cmd, repo := args[0], args[1]
+	var number int
+	if cmd != "create" {
+		if len(args) != 3 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		n, err := strconv.Atoi(args[2])
+		if err != nil {
+			log.Fatalf("bad issue number %q", args[2])
+		}
+		number = n
+	}
+
+	var (
+		issue *github.Issue
+		err   error
+	)
+	switch cmd {
+	case "create":
+		var title, body string
+		if title, body, err = editIssue("", ""); err == nil {
+			issue, err = client.CreateIssue(ctx, repo, &github.IssueRequest{Title: title, Body: body})
+		}
+	case "view":
+		issue, err = client.GetIssue(ctx, repo, number)
+	case "edit":
+		if issue, err = client.GetIssue(ctx, repo, number); err != nil {
+			break
+		}
+		var title, body string
+		if title, body, err = editIssue(issue.Title, issue.Body); err == nil {
+			// The body is sent even if empty, to clear it.
+			issue, err = client.EditIssue(ctx, repo, number, &github.IssueEdit{Title: &title, Body: &body})
+		}
+	case "close":
+		issue, err = client.CloseIssue(ctx, repo, number)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := printIssues(os.Stdout, templ, issue); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// executor is a text or an HTML template.
+type executor interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// printIssues prints the issues with the template, as a search result.
+func printIssues(w io.Writer, templ executor, issues ...*github.Issue) error {
+	return templ.Execute(w, &github.IssuesSearchResult{TotalCount: len(issues), Items: issues})
+}
+
+// scissors is the line of the edited text below which everything is
+// ignored, like the one of git commit --cleanup=scissors.
+const scissors = "# ------------------------ >8 ------------------------"
+
+const editHelp = scissors + `
+# Do not modify or remove the line above.
+# Everything below it will be ignored.
+#
+# The first line is the title of the issue, and the lines after
+# the blank line following it are its body, in Markdown.
+# An empty title aborts.
+`
+
+// errAborted is returned when the title of an edited issue is left empty.
+var errAborted = errors.New("aborting because of the empty title")
+
+// editIssue has the title and the body of an issue edited,
+// and returns them.
+func editIssue(title, body string) (string, string, error) {
+	text := title + "\n\n"
+	if body != "" {
+		text += body + "\n\n"
+	}
+	text, err := edit(text + editHelp)
+	if err != nil {
+		return "", "", err
+	}
+	if i := strings.Index(text, scissors); i >= 0 {
+		text = text[:i]
+	}
+	title, body, _ = strings.Cut(strings.TrimSpace(text), "\n")
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", "", errAborted
+	}
+	return title, strings.TrimSpace(body), nil
+}
+
+// edit opens the text in the editor, and returns it once the editor exits.
+// The editor is $VISUAL, $EDITOR or vi, run by the shell so that it may
+// have arguments, like "code --wait".
+func edit(text string) (string, error) {
+	f, err := os.CreateTemp("", "ISSUE_EDITMSG-*.md")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(text); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	cmd := exec.Command("sh", "-c", editor+` "$@"`, editor, f.Name())
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("editor %s: %v", editor, err)
+	}
+	b, err := os.ReadFile(f.Name())
+	return string(b), err
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"text/template"
 	"time"
+
+	"github.com/anjanashankar9/go-learning/ch4-composite-types/github"
 )
 
 /*
@@ -103,7 +105,7 @@ var issueList = template.Must(template.New("issuelist").Parse(`
      `))
 
 func main() {
-	result, err := SearchIssues(os.Args[1:])
+	result, err := github.SearchIssues(os.Args[1:])
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"text/template"
 	"time"
+
+	"github.com/anjanashankar9/go-learning/ch4-composite-types/github"
 )
 
 /*
@@ -74,7 +76,7 @@ var report = template.Must(template.New("issueList").
 	Parse(templ))
 
 func main() {
-	result, err := SearchIssues(os.Args[1:])
+	result, err := github.SearchIssues(os.Args[1:])
 	if err != nil {
 		log.Fatal(err)
 	}
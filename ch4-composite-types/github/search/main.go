@@ -0,0 +1,30 @@
+// Search lists all the issues matching the arguments. It sets the token
+// of the client from $GITHUB_TOKEN, if any:
+//
+//	search repo:golang/go is:open json decoder
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/anjanashankar9/go-learning/ch4-composite-types/github"
+)
+
+func main() {
+	client := github.NewClient(os.Getenv("GITHUB_TOKEN"))
+	it := client.SearchIssues(context.Background(), os.Args[1:])
+	for n := 0; it.Next(); n++ {
+		if n == 0 {
+			fmt.Printf("%d issues:\n", it.TotalCount())
+		}
+		item := it.Issue()
+		fmt.Printf("#%-5d %9.9s %.55s\n", item.Number, item.User.Login,
+			item.Title)
+	}
+	if err := it.Err(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,39 @@
+package github
+
+import (
+	htmltemplate "html/template"
+	"text/template"
+)
+
+// Report prints an IssuesSearchResult as text.
+var Report = template.Must(template.New("report").
+	Funcs(template.FuncMap{"daysAgo": DaysAgo}).
+	Parse(`{{.TotalCount}} issues:
+{{range .Items}}--------------------------------
+Number: {{.Number}}
+State:  {{.State}}
+User:   {{.User.Login}}
+Title:  {{.Title | printf "%.64s"}}
+Age:    {{.CreatedAt | daysAgo}} days
+{{end}}`))
+
+// IssueList prints an IssuesSearchResult as an HTML table.
+var IssueList = htmltemplate.Must(htmltemplate.New("issueList").Parse(`
+<h1>{{.TotalCount}} issues</h1>
+<table>
+<tr style='text-align: left'>
+  <th>#</th>
+  <th>State</th>
+  <th>User</th>
+  <th>Title</th>
+</tr>
+{{range .Items}}
+<tr>
+  <td><a href='{{.HTMLURL}}'>{{.Number}}</a></td>
+  <td>{{.State}}</td>
+  <td><a href='{{.User.HTMLURL}}'>{{.User.Login}}</a></td>
+  <td><a href='{{.HTMLURL}}'>{{.Title}}</a></td>
+</tr>
+{{end}}
+</table>
+`))
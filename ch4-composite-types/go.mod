@@ -0,0 +1,3 @@
+module github.com/anjanashankar9/go-learning/ch4-composite-types
+
+go 1.18
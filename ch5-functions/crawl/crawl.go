@@ -0,0 +1,274 @@
+// Crawl crawls the web breadth-first from the urls given as arguments,
+// and writes the graph of the links it discovers as DOT or JSON:
+//
+//	crawl -depth 2 -workers 8 -format dot https://go.dev/ > go.dot
+//
+// By default it follows the links to the hosts of the arguments only,
+// and does not fetch the pages disallowed by their robots.txt.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	depth    = flag.Int("depth", 2, "maximum number of links from the arguments")
+	workers  = flag.Int("workers", 8, "number of concurrent requests")
+	sameHost = flag.Bool("same-host", true, "only follow the links to the hosts of the arguments")
+	robots   = flag.Bool("robots", true, "obey robots.txt")
+	agent    = flag.String("agent", "crawl", "user agent of the requests, and in robots.txt")
+	format   = flag.String("format", "dot", "format of the graph, dot or json")
+	timeout  = flag.Duration("timeout", 30*time.Second, "timeout of a request, redirects included")
+)
+
+func main() {
+	flag.Parse()
+	if flag.NArg() == 0 || *workers < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *format != "dot" && *format != "json" {
+		log.Fatalf("crawl: unknown format %q", *format)
+	}
+	c := newCrawler(*agent, *workers, *depth, *timeout, *robots)
+	for _, arg := range flag.Args() {
+		u, err := url.Parse(arg)
+		if err != nil {
+			log.Fatalf("crawl: %v", err)
+		}
+		if *sameHost {
+			c.hosts[u.Host] = true
+		}
+	}
+
+	pages := c.crawl(flag.Args())
+	var err error
+	if *format == "json" {
+		err = writeJSON(os.Stdout, pages)
+	} else {
+		err = writeDOT(os.Stdout, pages)
+	}
+	if err != nil {
+		log.Fatalf("crawl: %v", err)
+	}
+}
+
+// A Page is a crawled page.
+type Page struct {
+	URL    string   `json:"url"`
+	Depth  int      `json:"depth"`
+	Status int      `json:"status,omitempty"`
+	Words  int      `json:"words"`
+	Images int      `json:"images"`
+	Links  []string `json:"links,omitempty"`
+	Err    string   `json:"error,omitempty"`
+}
+
+type crawler struct {
+	client  *http.Client // with CheckRedirect set to checkRedirect
+	agent   string
+	workers int
+	depth   int
+	hosts   map[string]bool // the hosts to follow the links to, all if empty
+	robots  *robotsCache    // nil if robots.txt is ignored
+}
+
+// newCrawler returns a crawler whose requests time out after timeout,
+// and which obeys robots.txt if obey is set.
+func newCrawler(agent string, workers, depth int, timeout time.Duration, obey bool) *crawler {
+	c := &crawler{
+		agent:   agent,
+		workers: workers,
+		depth:   depth,
+		hosts:   make(map[string]bool),
+	}
+	c.client = &http.Client{Timeout: timeout, CheckRedirect: c.checkRedirect}
+	if obey {
+		// The robots.txt files follow their redirects anywhere.
+		c.robots = newRobotsCache(&http.Client{Timeout: timeout}, agent)
+	}
+	return c
+}
+
+// crawl crawls the web breadth-first from the urls, and returns
+// the pages in the order they were discovered.
+func (c *crawler) crawl(urls []string) []*Page {
+	var pages []*Page
+	seen := make(map[string]bool)
+	var level []string
+	for _, u := range urls {
+		if !seen[u] {
+			seen[u] = true
+			level = append(level, u)
+		}
+	}
+
+	// The workers fetch the pages of a level at once; the
+	// links of its pages make the next level.
+	jobs := make(chan job)
+	defer close(jobs)
+	for i := 0; i < c.workers; i++ {
+		go func() {
+			for j := range jobs {
+				*j.page = c.fetch(j.url, j.depth)
+				j.done.Done()
+			}
+		}()
+	}
+	for d := 0; len(level) > 0; d++ {
+		results := make([]*Page, len(level))
+		var done sync.WaitGroup
+		done.Add(len(level))
+		for i, u := range level {
+			jobs <- job{url: u, depth: d, page: &results[i], done: &done}
+		}
+		done.Wait()
+
+		level = nil
+		for _, p := range results {
+			pages = append(pages, p)
+			if d == c.depth {
+				continue
+			}
+			for _, link := range p.Links {
+				if !seen[link] && c.follow(link) {
+					seen[link] = true
+					level = append(level, link)
+				}
+			}
+		}
+	}
+	return pages
+}
+
+// A job is a page for a worker to fetch.
+type job struct {
+	url   string
+	depth int
+	page  **Page          // set to the page fetched
+	done  *sync.WaitGroup // of the level
+}
+
+// follow reports whether the link should be crawled.
+func (c *crawler) follow(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	if len(c.hosts) > 0 && !c.hosts[u.Host] {
+		return false
+	}
+	return true
+}
+
+// errRedirect is the error of the redirects that are not followed.
+var errRedirect = errors.New("redirect not followed")
+
+// checkRedirect is the CheckRedirect function of the client. It follows
+// the redirects to the pages the crawler would follow a link to.
+func (c *crawler) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+	if !c.follow(req.URL.String()) {
+		return fmt.Errorf("%w: %s is on another host", errRedirect, req.URL)
+	}
+	if c.robots != nil {
+		ok, err := c.robots.allowed(req.URL.String())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%w: %s is disallowed by robots.txt", errRedirect, req.URL)
+		}
+	}
+	return nil
+}
+
+// fetch fetches the page at url and extracts its links, words and images.
+func (c *crawler) fetch(rawurl string, depth int) *Page {
+	p := &Page{URL: rawurl, Depth: depth}
+	if c.robots != nil {
+		ok, err := c.robots.allowed(rawurl)
+		if err != nil {
+			p.Err = err.Error()
+			return p
+		}
+		if !ok {
+			p.Err = "disallowed by robots.txt"
+			return p
+		}
+	}
+	req, err := http.NewRequest(http.MethodGet, rawurl, nil)
+	if err != nil {
+		p.Err = err.Error()
+		return p
+	}
+	req.Header.Set("User-Agent", c.agent)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		p.Err = err.Error()
+		return p
+	}
+	defer resp.Body.Close()
+	p.Status = resp.StatusCode
+	if resp.StatusCode != http.StatusOK {
+		p.Err = resp.Status
+		return p
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "" && !strings.HasPrefix(ct, "text/html") {
+		return p
+	}
+	doc, err := html.Parse(resp.Body)
+	if err != nil {
+		p.Err = fmt.Sprintf("parsing %s as HTML: %v", rawurl, err)
+		return p
+	}
+	// The links are resolved against the URL of the last request,
+	// after the redirects.
+	visit(doc, resp.Request.URL, p, make(map[string]bool))
+	return p
+}
+
+// visit adds the links, words and images of the tree
+// rooted at n to the page, like forEachNode.
+func visit(n *html.Node, base *url.URL, p *Page, links map[string]bool) {
+	switch {
+	case n.Type == html.TextNode:
+		p.Words += len(strings.Fields(n.Data))
+		return
+	case n.Type == html.ElementNode && (n.Data == "script" || n.Data == "style"):
+		return
+	case n.Type == html.ElementNode && n.Data == "img":
+		p.Images++
+	case n.Type == html.ElementNode && n.Data == "a":
+		for _, a := range n.Attr {
+			if a.Key != "href" {
+				continue
+			}
+			link, err := base.Parse(a.Val)
+			if err != nil || (link.Scheme != "http" && link.Scheme != "https") {
+				continue // ignore bad URLs, mailto: and javascript: links
+			}
+			link.Fragment = ""
+			if s := link.String(); !links[s] {
+				links[s] = true
+				p.Links = append(p.Links, s)
+			}
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		visit(c, base, p, links)
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// site is a test web site, counting the requests of each path.
+type site struct {
+	*httptest.Server
+	mu   sync.Mutex
+	hits map[string]int
+}
+
+func newSite(t *testing.T, handler func(s *site, w http.ResponseWriter, r *http.Request)) *site {
+	s := &site{hits: make(map[string]int)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.hits[r.URL.Path]++
+		s.mu.Unlock()
+		handler(s, w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *site) hit(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hits[path]
+}
+
+// page writes an HTML page of the words, linking to the links.
+func page(w http.ResponseWriter, words string, links ...string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, "<html><body><p>%s</p><img src=x.png>", words)
+	for _, l := range links {
+		fmt.Fprintf(w, `<a href="%s">link</a>`, l)
+	}
+	fmt.Fprint(w, "</body></html>")
+}
+
+// crawlSite crawls the site, and returns the pages by path.
+func crawlSite(t *testing.T, c *crawler, s *site) map[string]*Page {
+	t.Helper()
+	u, _ := url.Parse(s.URL)
+	c.hosts[u.Host] = true
+	byPath := make(map[string]*Page)
+	for _, p := range c.crawl([]string{s.URL + "/"}) {
+		u, err := url.Parse(p.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		byPath[u.RequestURI()] = p
+	}
+	return byPath
+}
+
+func TestCrawl(t *testing.T) {
+	other := newSite(t, func(s *site, w http.ResponseWriter, r *http.Request) {
+		page(w, "elsewhere")
+	})
+	s := newSite(t, func(s *site, w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			fmt.Fprint(w, "User-agent: *\nDisallow: /secret\nDisallow: /*?print=1$\n")
+		case "/":
+			page(w, "the home page", "/a", "/b#top", "/secret", "/a?print=1", "/away", "/hidden",
+				"/slow", "/missing", "mailto:gopher@example.com", other.URL+"/linked")
+		case "/a":
+			page(w, "page a", "/c", "/")
+		case "/b", "/c":
+			page(w, "page "+r.URL.Path[1:])
+		case "/away":
+			http.Redirect(w, r, other.URL+"/", http.StatusFound)
+		case "/hidden":
+			http.Redirect(w, r, "/secret/page", http.StatusFound)
+		case "/slow":
+			time.Sleep(500 * time.Millisecond)
+			page(w, "too late")
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	pages := crawlSite(t, newCrawler("crawl", 4, 1, 100*time.Millisecond, true), s)
+
+	if p := pages["/"]; p == nil || p.Err != "" || p.Words != 13 || p.Images != 1 || len(p.Links) != 9 {
+		t.Errorf("home page = %+v", p)
+	}
+	if p := pages["/a"]; p == nil || p.Depth != 1 || p.Err != "" || p.Words != 4 {
+		t.Errorf("page a = %+v", p)
+	}
+	if p := pages["/b"]; p == nil || p.Err != "" {
+		t.Errorf("page b = %+v, want the link without its fragment", p)
+	}
+	if _, ok := pages["/c"]; ok {
+		t.Error("crawled page c, deeper than the depth")
+	}
+	for path, want := range map[string]string{
+		"/secret":    "disallowed by robots.txt",
+		"/a?print=1": "disallowed by robots.txt",
+		"/away":      "is on another host",
+		"/hidden":    "is disallowed by robots.txt",
+		"/slow":      "Client.Timeout exceeded",
+		"/missing":   "404 Not Found",
+	} {
+		if p := pages[path]; p == nil || !strings.Contains(p.Err, want) {
+			t.Errorf("page %s = %+v, want error %q", path, p, want)
+		}
+	}
+	if n := len(pages); n != 9 {
+		t.Errorf("crawled %d pages, want 9", n)
+	}
+	if s.hit("/secret") != 0 || s.hit("/secret/page") != 0 || other.hit("/") != 0 || other.hit("/linked") != 0 {
+		t.Errorf("fetched pages disallowed or on another host: %v, %v", s.hits, other.hits)
+	}
+	if n := s.hit("/robots.txt"); n != 1 {
+		t.Errorf("robots.txt fetched %d times, want once", n)
+	}
+}
+
+func TestCrawlWorkers(t *testing.T) {
+	var (
+		mu            sync.Mutex
+		running, most int
+	)
+	s := newSite(t, func(s *site, w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			var links []string
+			for i := 0; i < 20; i++ {
+				links = append(links, fmt.Sprintf("/p%d", i))
+			}
+			page(w, "index", links...)
+			return
+		}
+		mu.Lock()
+		running++
+		if running > most {
+			most = running
+		}
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+		page(w, "leaf")
+	})
+	pages := crawlSite(t, newCrawler("crawl", 3, 1, time.Second, false), s)
+	if len(pages) != 21 {
+		t.Errorf("crawled %d pages, want 21", len(pages))
+	}
+	if most > 3 || most < 2 {
+		t.Errorf("%d requests at once, want up to the 3 workers", most)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// An Edge is a link from a page to another.
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// writeJSON writes the pages and their links as JSON.
+func writeJSON(w io.Writer, pages []*Page) error {
+	graph := struct {
+		Pages []*Page `json:"pages"`
+		Edges []Edge  `json:"edges"`
+	}{Pages: pages, Edges: []Edge{}}
+	for _, p := range pages {
+		for _, link := range p.Links {
+			graph.Edges = append(graph.Edges, Edge{p.URL, link})
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(graph)
+}
+
+// writeDOT writes the pages and their links as a Graphviz graph. The
+// pages crawled are labelled with their counts, and the ones that
+// failed are red. The links not followed are grey.
+func writeDOT(w io.Writer, pages []*Page) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph crawl {")
+	fmt.Fprintln(bw, "\tnode [shape=box, fontname=\"Helvetica\", fontsize=10];")
+	crawled := make(map[string]bool)
+	for _, p := range pages {
+		crawled[p.URL] = true
+		label := fmt.Sprintf("%s\ndepth %d, %d words, %d images", p.URL, p.Depth, p.Words, p.Images)
+		attrs := ""
+		if p.Err != "" {
+			label = fmt.Sprintf("%s\n%s", p.URL, p.Err)
+			attrs = ", color=red"
+		}
+		fmt.Fprintf(bw, "\t%q [label=%q%s];\n", p.URL, label, attrs)
+	}
+	for _, p := range pages {
+		for _, link := range p.Links {
+			if !crawled[link] {
+				fmt.Fprintf(bw, "\t%q [color=grey, fontcolor=grey];\n", link)
+				crawled[link] = true
+			}
+			fmt.Fprintf(bw, "\t%q -> %q;\n", p.URL, link)
+		}
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
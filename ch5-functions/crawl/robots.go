@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// robotsCache holds the rules of the robots.txt of the hosts crawled.
+type robotsCache struct {
+	client *http.Client
+	agent  string
+
+	mu    sync.Mutex
+	rules map[string]*robotsEntry // by scheme and host
+}
+
+// robotsEntry is the entry of a host, fetched once by the first
+// worker needing it while the others wait.
+type robotsEntry struct {
+	ready chan struct{} // closed when rules is set
+	rules []rule
+}
+
+// A rule allows or disallows the paths matching pattern: the paths
+// starting with it, where "*" matches any characters, and where a
+// final "$" matches the end of the path.
+type rule struct {
+	pattern string
+	allow   bool
+}
+
+func newRobotsCache(client *http.Client, agent string) *robotsCache {
+	return &robotsCache{client: client, agent: agent, rules: make(map[string]*robotsEntry)}
+}
+
+// allowed reports whether the robots.txt of the host of rawurl allows
+// the agent to fetch it.
+func (rc *robotsCache) allowed(rawurl string) (bool, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return false, err
+	}
+	key := u.Scheme + "://" + u.Host
+	rc.mu.Lock()
+	e := rc.rules[key]
+	if e == nil {
+		e = &robotsEntry{ready: make(chan struct{})}
+		rc.rules[key] = e
+		rc.mu.Unlock()
+		e.rules = rc.fetch(key)
+		close(e.ready)
+	} else {
+		rc.mu.Unlock()
+		<-e.ready
+	}
+	// The rules apply to the query too, like "Disallow: /search?q=".
+	path := u.EscapedPath()
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	return allowed(e.rules, path), nil
+}
+
+// fetch fetches and parses the robots.txt of the site. A missing or
+// unreachable robots.txt allows everything.
+func (rc *robotsCache) fetch(site string) []rule {
+	req, err := http.NewRequest(http.MethodGet, site+"/robots.txt", nil)
+	if err != nil {
+		return nil
+	}
+	req.Header.Set("User-Agent", rc.agent)
+	resp, err := rc.client.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+	return parseRobots(resp.Body, rc.agent)
+}
+
+// parseRobots returns the rules of the group of robots.txt applying to
+// the agent: the group naming it, or else the group of "*".
+func parseRobots(r io.Reader, agent string) []rule {
+	agent = strings.ToLower(agent)
+	var (
+		mine, star   []rule
+		matchedMine  bool
+		inMine       bool // the current group applies to the agent
+		inStar       bool // the current group applies to "*"
+		groupStarted bool // rules were read since the last user-agent line
+	)
+	scan := bufio.NewScanner(r)
+	for scan.Scan() {
+		line := scan.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key, value = strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)
+		switch key {
+		case "user-agent":
+			if groupStarted {
+				// A user-agent line after rules starts a new group.
+				inMine, inStar, groupStarted = false, false, false
+			}
+			v := strings.ToLower(value)
+			if v == "*" {
+				inStar = true
+			} else if strings.Contains(agent, v) {
+				inMine, matchedMine = true, true
+			}
+		case "allow", "disallow":
+			groupStarted = true
+			if value == "" {
+				continue // "Disallow:" allows everything
+			}
+			r := rule{pattern: value, allow: key == "allow"}
+			if inMine {
+				mine = append(mine, r)
+			}
+			if inStar {
+				star = append(star, r)
+			}
+		}
+	}
+	if matchedMine {
+		return mine
+	}
+	return star
+}
+
+// allowed reports whether the rules allow the path: the matching rule
+// with the longest pattern applies, and allow wins a tie.
+func allowed(rules []rule, path string) bool {
+	if path == "" {
+		path = "/"
+	}
+	best, ok := -1, true
+	for _, r := range rules {
+		if !match(r.pattern, path) {
+			continue
+		}
+		if n := len(r.pattern); n > best || (n == best && r.allow) {
+			best, ok = n, r.allow
+		}
+	}
+	return ok
+}
+
+// match reports whether the path matches the pattern of a rule.
+func match(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+	parts := strings.Split(pattern, "*")
+	// The first part is a prefix, and the others are found in turn,
+	// at their first place: the rest of the path is left to the
+	// next parts as long as possible.
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+	last := len(parts) - 1
+	for i, part := range parts[1:] {
+		if anchored && i+1 == last {
+			return strings.HasSuffix(rest, part)
+		}
+		j := strings.Index(rest, part)
+		if j < 0 {
+			return false
+		}
+		rest = rest[j+len(part):]
+	}
+	return !anchored || rest == ""
+}
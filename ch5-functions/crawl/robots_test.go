@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const robotsTxt = `
+# The rules of all the robots.
+User-agent: *
+Disallow: /private/
+Allow: /private/public
+Disallow: /search?q=
+Disallow: /*.pdf$
+Disallow: /tmp/*/cache
+
+User-agent: crawl
+User-agent: other
+Disallow: /admin
+Allow: /admin/help$
+`
+
+func TestParseRobots(t *testing.T) {
+	for _, tt := range []struct {
+		agent string
+		want  string
+	}{
+		{"crawl/1.0", "[{/admin false} {/admin/help$ true}]"},
+		{"Mozilla", "[{/private/ false} {/private/public true} {/search?q= false} {/*.pdf$ false} {/tmp/*/cache false}]"},
+	} {
+		rules := parseRobots(strings.NewReader(robotsTxt), tt.agent)
+		if got := fmt.Sprint(fmtRules(rules)); got != tt.want {
+			t.Errorf("rules of %s = %s, want %s", tt.agent, got, tt.want)
+		}
+	}
+}
+
+func fmtRules(rules []rule) []string {
+	var s []string
+	for _, r := range rules {
+		s = append(s, fmt.Sprintf("{%s %v}", r.pattern, r.allow))
+	}
+	return s
+}
+
+func TestAllowed(t *testing.T) {
+	star := parseRobots(strings.NewReader(robotsTxt), "Mozilla")
+	crawl := parseRobots(strings.NewReader(robotsTxt), "crawl")
+	for _, tt := range []struct {
+		rules []rule
+		path  string
+		want  bool
+	}{
+		{star, "/", true},
+		{star, "", true},
+		{star, "/private/", false},
+		{star, "/private/public/index.html", true},
+		{star, "/search", true},
+		{star, "/search?q=go", false},
+		{star, "/search?page=2&q=go", true},
+		{star, "/docs/spec.pdf", false},
+		{star, "/docs/spec.pdf?download=1", true},
+		{star, "/docs/spec.pdf.html", true},
+		{star, "/tmp/a/b/cache/x", false},
+		{star, "/tmp/cache", true},
+		{crawl, "/admin/users", false},
+		{crawl, "/admin/help", true},
+		{crawl, "/admin/help/more", false},
+		{crawl, "/private/", true},
+	} {
+		if got := allowed(tt.rules, tt.path); got != tt.want {
+			t.Errorf("allowed(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	for _, tt := range []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"/a", "/abc", true},
+		{"/a$", "/abc", false},
+		{"/a$", "/a", true},
+		{"/*", "/anything", true},
+		{"/*$", "/anything", true},
+		{"/a*c$", "/abcbc", true},
+		{"/a*c$", "/abcb", false},
+		{"/a*b*c", "/a-b-c-d", true},
+		{"/a*b*c", "/a-c-b", false},
+		{"*.php", "/index.php?x=1", true},
+		{"*.php$", "/index.php?x=1", false},
+	} {
+		if got := match(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("match(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"golang.org/x/net/html"
@@ -82,7 +83,7 @@ func CountWordsAndImages(url string) (words, images int, err error) {
 		return
 	}
 
-	_, err = html.Parse(resp.Body)
+	doc, err := html.Parse(resp.Body)
 	resp.Body.Close()
 
 	if err != nil {
@@ -90,11 +91,32 @@ func CountWordsAndImages(url string) (words, images int, err error) {
 		return
 	}
 
-	words, images = 1, 2
+	words, images = countWordsAndImages(doc)
 
 	return
 }
 
+// countWordsAndImages counts the words of the text and the img
+// elements of the tree rooted at n. The text of scripts and
+// style sheets is not counted.
+func countWordsAndImages(n *html.Node) (words, images int) {
+	switch {
+	case n.Type == html.TextNode:
+		words = len(strings.Fields(n.Data))
+		return
+	case n.Type == html.ElementNode && n.Data == "img":
+		images = 1
+	case n.Type == html.ElementNode && (n.Data == "script" || n.Data == "style"):
+		return
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		w, i := countWordsAndImages(c)
+		words += w
+		images += i
+	}
+	return
+}
+
 /*
 Errors are an important part of a package's API or an application's
 user interface and failure is just one of several expected behaviors.
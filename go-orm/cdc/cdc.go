@@ -0,0 +1,261 @@
+// Package cdc streams the changes made to the entities as ChangeEvents.
+//
+// The Hook of an Outbox records every mutation of the watched entities as
+// an OutboxEvent, in the same transaction as the mutation. The Outbox then
+// delivers the events to its sinks once they are committed, and marks them
+// as delivered. An event is delivered again if the delivery fails or the
+// process stops before marking it, so delivery is at-least-once and the
+// consumers should ignore the events whose ID they have already seen:
+//
+//	client := ent.NewClient(ent.Driver(cdc.NewDriver(drv)))
+//	o := cdc.NewOutbox(client, []cdc.Sink{cdc.Chan(events), cdc.NewJSONL("changes.jsonl")})
+//	client.Use(o.Hook())
+//	go o.Run(ctx)
+//
+// The Hook runs the mutations made outside a transaction in one of its
+// own, with their events, when the client is built on a Driver. On other
+// drivers, such mutations commit before their events are written, and a
+// crash in between loses the events.
+package cdc
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/group"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/membership"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/outboxevent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/privacy"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/registration"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+)
+
+// The operations of the ChangeEvents.
+const (
+	OpCreate = "create"
+	OpUpdate = "update"
+	OpDelete = "delete"
+)
+
+// ChangeEvent describes a change made to an entity.
+type ChangeEvent struct {
+	// ID is the id of the event, increasing with the order of the
+	// writes. Consumers can use it to drop the events they have seen.
+	ID int `json:"id"`
+	// Op is OpCreate, OpUpdate or OpDelete.
+	Op string `json:"op"`
+	// Entity is the type of the entity, and EntityID its id.
+	Entity   string `json:"entity"`
+	EntityID int    `json:"entity_id"`
+	// Fields holds the values of the fields set by the change, Added the
+	// values added to numeric fields, and Cleared the fields it cleared.
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+	Added   map[string]interface{} `json:"added,omitempty"`
+	Cleared []string               `json:"cleared,omitempty"`
+	// Edges holds the names of the edges the change added or removed.
+	Edges []string `json:"edges,omitempty"`
+	// Time is the time of the change.
+	Time time.Time `json:"time"`
+}
+
+// mutation is implemented by the generated mutations.
+type mutation interface {
+	ent.Mutation
+	ID() (int, bool)
+	IDs(context.Context) ([]int, error)
+	Client() *ent.Client
+	Tx() (*ent.Tx, error)
+}
+
+// Hook returns a hook writing the changes of the entities of the given
+// types, or of the Users, Cars and Groups if none are given, to the outbox.
+// When the mutation runs in a transaction found in its context (see
+// ent.NewTxContext), or in the Hook's own, the events are delivered as
+// soon as it commits. The events of other transactions wait for the next
+// poll of Run.
+func (o *Outbox) Hook(types ...string) ent.Hook {
+	if len(types) == 0 {
+		types = []string{ent.TypeUser, ent.TypeCar, ent.TypeGroup}
+	}
+	watched := make(map[string]bool)
+	for _, t := range types {
+		watched[t] = true
+	}
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, em ent.Mutation) (ent.Value, error) {
+			mu, ok := em.(mutation)
+			if !ok || !watched[em.Type()] {
+				return next.Mutate(ctx, em)
+			}
+			if _, err := mu.Tx(); err != nil {
+				return o.mutateInTx(ctx, mu, next)
+			}
+			v, err := record(ctx, mu, next, mu.Client())
+			if err != nil {
+				return nil, err
+			}
+			if tx := ent.TxFromContext(ctx); tx != nil {
+				tx.OnCommit(func(next ent.Committer) ent.Committer {
+					return ent.CommitFunc(func(ctx context.Context, tx *ent.Tx) error {
+						if err := next.Commit(ctx, tx); err != nil {
+							return err
+						}
+						o.Notify()
+						return nil
+					})
+				})
+			} else {
+				o.Notify()
+			}
+			return v, nil
+		})
+	}
+}
+
+// mutateInTx runs the mutation, made outside a transaction, and writes
+// its events in a transaction of its own, if the client is built on a
+// Driver, and without one otherwise.
+func (o *Outbox) mutateInTx(ctx context.Context, mu mutation, next ent.Mutator) (ent.Value, error) {
+	if atomic.LoadInt32(&o.plain) == 0 {
+		b := &binding{}
+		tx, err := mu.Client().Tx(withBinding(ctx, b))
+		if err != nil {
+			return nil, err
+		}
+		if b.tx != nil {
+			return o.commit(withBinding(ctx, b), tx, mu, next)
+		}
+		// Not a Driver: the transaction is of no use to the mutation.
+		atomic.StoreInt32(&o.plain, 1)
+		if err := tx.Rollback(); err != nil {
+			return nil, err
+		}
+	}
+	v, err := record(ctx, mu, next, mu.Client())
+	if err != nil {
+		return nil, err
+	}
+	o.Notify()
+	return v, nil
+}
+
+// commit runs the mutation and writes its events in the transaction.
+func (o *Outbox) commit(ctx context.Context, tx *ent.Tx, mu mutation, next ent.Mutator) (ent.Value, error) {
+	// The hooks of the mutation deferring work to the
+	// commit find the transaction in the context.
+	v, err := record(ent.NewTxContext(ctx, tx), mu, next, tx.Client())
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("%w: rolling back transaction: %v", err, rerr)
+		}
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("cdc: committing transaction: %w", err)
+	}
+	o.Notify()
+	return v, nil
+}
+
+// record runs the mutation and writes its events with client.
+func record(ctx context.Context, mu mutation, next ent.Mutator, client *ent.Client) (ent.Value, error) {
+	// The ids of the entities matched by bulk updates and deletes are
+	// not known afterwards, so get them first. The policy of the
+	// mutation adds the filters of the viewer to its predicates, the
+	// tenant's among them, so it is evaluated before: the ids are then
+	// the ones of the entities the mutation changes. It is evaluated
+	// again by the mutation, which only adds the same filters again.
+	var ids []int
+	if mu.Op().Is(ent.OpUpdate | ent.OpDelete | ent.OpDeleteOne) {
+		if p := policy(mu.Type()); p != nil {
+			if err := p.EvalMutation(ctx, mu); err != nil {
+				return nil, err
+			}
+		}
+		var err error
+		if ids, err = mu.IDs(allow(ctx)); err != nil {
+			return nil, err
+		}
+	}
+	v, err := next.Mutate(ctx, mu)
+	if err != nil {
+		return nil, err
+	}
+	if id, ok := mu.ID(); ok && ids == nil {
+		ids = []int{id}
+	}
+	if err := write(ctx, client, mu, ids); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// policy returns the privacy policy of the entities of the type.
+func policy(typ string) ent.Policy {
+	switch typ {
+	case ent.TypeCar:
+		return car.Policy
+	case ent.TypeGroup:
+		return group.Policy
+	case ent.TypeMembership:
+		return membership.Policy
+	case ent.TypeRegistration:
+		return registration.Policy
+	case ent.TypeUser:
+		return user.Policy
+	}
+	return nil
+}
+
+// write adds the events of the mutation of the entities with the given
+// ids to the outbox, through the client of the transaction of the
+// mutation, if any.
+func write(ctx context.Context, client *ent.Client, m mutation, ids []int) error {
+	op := OpUpdate
+	switch {
+	case m.Op().Is(ent.OpCreate):
+		op = OpCreate
+	case m.Op().Is(ent.OpDelete | ent.OpDeleteOne):
+		op = OpDelete
+	}
+	fields := make(map[string]interface{})
+	for _, name := range m.Fields() {
+		fields[name], _ = m.Field(name)
+	}
+	added := make(map[string]interface{})
+	for _, name := range m.AddedFields() {
+		added[name], _ = m.AddedField(name)
+	}
+	var edges []string
+	seen := make(map[string]bool)
+	for _, names := range [][]string{m.AddedEdges(), m.RemovedEdges(), m.ClearedEdges()} {
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				edges = append(edges, name)
+			}
+		}
+	}
+	builders := make([]*ent.OutboxEventCreate, len(ids))
+	for i, id := range ids {
+		builders[i] = client.OutboxEvent.Create().
+			SetOp(outboxevent.Op(op)).
+			SetEntity(m.Type()).
+			SetEntityID(id).
+			SetFields(fields).
+			SetAdded(added).
+			SetCleared(m.ClearedFields()).
+			SetEdges(edges)
+	}
+	return client.OutboxEvent.CreateBulk(builders...).Exec(allow(ctx))
+}
+
+// allow returns a context in which the privacy rules are not evaluated,
+// since the outbox records the changes of all the viewers.
+func allow(ctx context.Context) context.Context {
+	return privacy.DecisionContext(ctx, privacy.Allow)
+}
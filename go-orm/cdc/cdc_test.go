@@ -0,0 +1,180 @@
+package cdc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	_ "github.com/anjanashankar9/go-learning/go-orm/ent/runtime"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+	"github.com/anjanashankar9/go-learning/go-orm/tenant"
+	"github.com/anjanashankar9/go-learning/go-orm/viewer"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// setup returns a client of an in-memory SQLite database, built on a
+// Driver if wrap is set, with the Hook of an Outbox whose events are
+// recorded by the returned function.
+func setup(t *testing.T, wrap bool) (*ent.Client, *Outbox, func() []ChangeEvent) {
+	t.Helper()
+	drv, err := entsql.Open(dialect.SQLite, fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d dialect.Driver = drv
+	if wrap {
+		d = NewDriver(drv)
+	}
+	client := ent.NewClient(ent.Driver(d))
+	t.Cleanup(func() { client.Close() })
+	if err := client.Schema.Create(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	var events []ChangeEvent
+	o := NewOutbox(client, []Sink{SinkFunc(func(_ context.Context, es []ChangeEvent) error {
+		events = append(events, es...)
+		return nil
+	})})
+	client.Use(o.Hook())
+	delivered := func() []ChangeEvent {
+		t.Helper()
+		events = nil
+		if _, err := o.Deliver(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		return events
+	}
+	return client, o, delivered
+}
+
+// describe returns the op, entity and id of the events.
+func describe(events []ChangeEvent) []string {
+	var s []string
+	for _, e := range events {
+		s = append(s, fmt.Sprintf("%s %s %d", e.Op, e.Entity, e.EntityID))
+	}
+	return s
+}
+
+func check(t *testing.T, events []ChangeEvent, want ...string) {
+	t.Helper()
+	if got := describe(events); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("events = %q, want %q", got, want)
+	}
+}
+
+var errOutbox = errors.New("outbox is down")
+
+// failOutbox makes the writes of the events fail while *fail is set.
+func failOutbox(client *ent.Client, fail *bool) {
+	client.OutboxEvent.Use(func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if *fail {
+				return nil, errOutbox
+			}
+			return next.Mutate(ctx, m)
+		})
+	})
+}
+
+func TestHookOwnTx(t *testing.T) {
+	client, _, delivered := setup(t, true)
+	ctx := viewer.AdminContext(context.Background())
+	a8m := client.User.Create().SetName("a8m").SetAge(30).SaveX(ctx)
+	client.User.UpdateOne(a8m).SetAge(31).ExecX(ctx)
+	check(t, delivered(), fmt.Sprint("create User ", a8m.ID), fmt.Sprint("update User ", a8m.ID))
+
+	// The mutation and its events commit together, or not at all.
+	var fail bool
+	failOutbox(client, &fail)
+	fail = true
+	if _, err := client.User.Create().SetName("nati").SetAge(28).Save(ctx); !errors.Is(err, errOutbox) {
+		t.Fatalf("create = %v, want %v", err, errOutbox)
+	}
+	if err := client.User.UpdateOne(a8m).SetAge(40).Exec(ctx); !errors.Is(err, errOutbox) {
+		t.Fatalf("update = %v, want %v", err, errOutbox)
+	}
+	if n := client.User.Query().Where(user.Name("nati")).CountX(ctx); n != 0 {
+		t.Errorf("%d users created without their event, want 0", n)
+	}
+	if age := client.User.GetX(ctx, a8m.ID).Age; age != 31 {
+		t.Errorf("age = %d after an update without its event, want 31", age)
+	}
+	fail = false
+	check(t, delivered())
+
+	// The bulk mutations and their events too.
+	client.Car.Create().SetModel("Tesla").SetRegisteredAt(time.Now()).SetOwner(a8m).ExecX(ctx)
+	delivered()
+	fail = true
+	if _, err := client.Car.Delete().Exec(ctx); !errors.Is(err, errOutbox) {
+		t.Fatalf("delete = %v, want %v", err, errOutbox)
+	}
+	if n := client.Car.Query().CountX(ctx); n != 1 {
+		t.Errorf("%d cars after a delete without its events, want 1", n)
+	}
+}
+
+func TestHookWithoutDriver(t *testing.T) {
+	client, _, delivered := setup(t, false)
+	ctx := viewer.AdminContext(context.Background())
+	a8m := client.User.Create().SetName("a8m").SetAge(30).SaveX(ctx)
+	check(t, delivered(), fmt.Sprint("create User ", a8m.ID))
+}
+
+func TestHookUserTx(t *testing.T) {
+	client, _, delivered := setup(t, true)
+	ctx := viewer.AdminContext(context.Background())
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a8m := tx.User.Create().SetName("a8m").SetAge(30).SaveX(ent.NewTxContext(ctx, tx))
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	check(t, delivered(), fmt.Sprint("create User ", a8m.ID))
+
+	tx, err = client.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.User.Create().SetName("nati").SetAge(28).ExecX(ctx)
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	check(t, delivered())
+}
+
+func TestHookPolicy(t *testing.T) {
+	client, _, delivered := setup(t, true)
+	admin := viewer.AdminContext(context.Background())
+	var cars []*ent.Car
+	for _, tid := range []int{1, 2} {
+		ctx := tenant.NewContext(admin, tid)
+		u := client.User.Create().SetName(fmt.Sprint("user", tid)).SetAge(30).SaveX(ctx)
+		cars = append(cars, client.Car.Create().SetModel("Tesla").SetRegisteredAt(time.Now()).SetOwner(u).SaveX(ctx))
+	}
+	delivered()
+
+	// An admin of tenant 1 updates all the cars: the ones of tenant 1.
+	if n := client.Car.Update().SetModel("Ford").SaveX(tenant.NewContext(admin, 1)); n != 1 {
+		t.Fatalf("updated %d cars, want 1", n)
+	}
+	check(t, delivered(), fmt.Sprint("update Car ", cars[0].ID))
+
+	// A user deletes all the cars: their own.
+	owner := cars[1].QueryOwner().OnlyIDX(admin)
+	uctx := tenant.NewContext(viewer.UserContext(context.Background(), owner), 2)
+	if n := client.Car.Delete().Where(car.ModelEQ("Tesla")).ExecX(uctx); n != 1 {
+		t.Fatalf("deleted %d cars, want 1", n)
+	}
+	check(t, delivered(), fmt.Sprint("delete Car ", cars[1].ID))
+}
@@ -0,0 +1,112 @@
+package cdc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"entgo.io/ent/dialect"
+)
+
+// Driver is a dialect.Driver for the client whose changes an Outbox
+// records. It lets the Hook run a mutation made outside a transaction in
+// one of its own, with the writes of its events, so that a crash cannot
+// commit the one without the others:
+//
+//	drv, err := sql.Open(dialect.Postgres, dsn)
+//	if err != nil {
+//		return err
+//	}
+//	client := ent.NewClient(ent.Driver(cdc.NewDriver(drv)))
+//
+// The statements run with the context of such a mutation go to its
+// transaction. The other statements go to the wrapped driver.
+type Driver struct {
+	dialect.Driver
+}
+
+// NewDriver returns a Driver wrapping drv.
+func NewDriver(drv dialect.Driver) *Driver {
+	return &Driver{Driver: drv}
+}
+
+// binding holds the transaction the Hook opened for a mutation, once
+// the Driver began it.
+type binding struct {
+	tx dialect.Tx
+}
+
+type bindingKey struct{}
+
+func withBinding(ctx context.Context, b *binding) context.Context {
+	return context.WithValue(ctx, bindingKey{}, b)
+}
+
+func boundTx(ctx context.Context) (*binding, dialect.Tx) {
+	b, _ := ctx.Value(bindingKey{}).(*binding)
+	if b == nil {
+		return nil, nil
+	}
+	return b, b.tx
+}
+
+// Exec implements the dialect.Driver.Exec method.
+func (d *Driver) Exec(ctx context.Context, query string, args, v interface{}) error {
+	if _, tx := boundTx(ctx); tx != nil {
+		return tx.Exec(ctx, query, args, v)
+	}
+	return d.Driver.Exec(ctx, query, args, v)
+}
+
+// Query implements the dialect.Driver.Query method.
+func (d *Driver) Query(ctx context.Context, query string, args, v interface{}) error {
+	if _, tx := boundTx(ctx); tx != nil {
+		return tx.Query(ctx, query, args, v)
+	}
+	return d.Driver.Query(ctx, query, args, v)
+}
+
+// Tx starts a transaction, or returns the one of the mutation of the
+// context. The statements that ent runs in a transaction of their own
+// are then part of it, and its Commit and Rollback are left to the Hook.
+func (d *Driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	return d.begin(ctx, d.Driver.Tx)
+}
+
+// BeginTx starts a transaction with options, if the wrapped driver
+// supports them. ent.Client.BeginTx requires this method.
+func (d *Driver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("cdc: Driver.BeginTx is not supported")
+	}
+	return d.begin(ctx, func(ctx context.Context) (dialect.Tx, error) {
+		return drv.BeginTx(ctx, opts)
+	})
+}
+
+func (d *Driver) begin(ctx context.Context, begin func(context.Context) (dialect.Tx, error)) (dialect.Tx, error) {
+	b, tx := boundTx(ctx)
+	switch {
+	case b == nil:
+		return begin(ctx)
+	case tx != nil:
+		return nestedTx{tx}, nil
+	}
+	tx, err := begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b.tx = tx
+	return tx, nil
+}
+
+// nestedTx is a transaction run inside the one of a mutation.
+type nestedTx struct {
+	dialect.Tx
+}
+
+func (nestedTx) Commit() error   { return nil }
+func (nestedTx) Rollback() error { return nil }
@@ -0,0 +1,142 @@
+package cdc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/outboxevent"
+)
+
+// Defaults used when the corresponding Option is not given.
+const (
+	DefaultInterval  = time.Second
+	DefaultBatchSize = 100
+)
+
+// Outbox delivers the events written by its Hook to its sinks. Only one
+// Outbox should deliver the events of a database, or they are delivered
+// once by each.
+type Outbox struct {
+	client   *ent.Client
+	sinks    []Sink
+	interval time.Duration
+	batch    int
+	wake     chan struct{}
+	// plain is 1 once the Hook finds the client is not built on a
+	// Driver, to stop opening transactions for the mutations.
+	plain int32
+}
+
+// Option configures an Outbox.
+type Option func(*Outbox)
+
+// Interval sets how often Run polls the outbox for events
+// it was not notified of.
+func Interval(d time.Duration) Option {
+	return func(o *Outbox) {
+		o.interval = d
+	}
+}
+
+// BatchSize sets the maximum number of events sent to the sinks at once.
+func BatchSize(n int) Option {
+	return func(o *Outbox) {
+		o.batch = n
+	}
+}
+
+// NewOutbox returns an Outbox delivering the events of the database of
+// client to the sinks.
+func NewOutbox(client *ent.Client, sinks []Sink, opts ...Option) *Outbox {
+	o := &Outbox{
+		client:   client,
+		sinks:    sinks,
+		interval: DefaultInterval,
+		batch:    DefaultBatchSize,
+		wake:     make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Notify wakes Run up to deliver the events written so far.
+func (o *Outbox) Notify() {
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run delivers the events until ctx is done, when notified and every
+// interval. Failed deliveries are logged and retried at the next interval.
+func (o *Outbox) Run(ctx context.Context) error {
+	t := time.NewTicker(o.interval)
+	defer t.Stop()
+	for {
+		// The events stay in the outbox until the next
+		// attempt, so the errors are only logged.
+		if _, err := o.Deliver(ctx); err != nil && ctx.Err() == nil {
+			log.Print(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-o.wake:
+		case <-t.C:
+		}
+	}
+}
+
+// Deliver sends the undelivered events to the sinks, in order, and marks
+// them as delivered. It returns the number of events delivered.
+func (o *Outbox) Deliver(ctx context.Context) (int, error) {
+	ctx = allow(ctx)
+	n := 0
+	for {
+		rows, err := o.client.OutboxEvent.Query().
+			Where(outboxevent.DeliveredAtIsNil()).
+			Order(ent.Asc(outboxevent.FieldID)).
+			Limit(o.batch).
+			All(ctx)
+		if err != nil {
+			return n, fmt.Errorf("cdc: reading outbox: %w", err)
+		}
+		if len(rows) == 0 {
+			return n, nil
+		}
+		events := make([]ChangeEvent, len(rows))
+		ids := make([]int, len(rows))
+		for i, r := range rows {
+			events[i] = ChangeEvent{
+				ID:       r.ID,
+				Op:       string(r.Op),
+				Entity:   r.Entity,
+				EntityID: r.EntityID,
+				Fields:   r.Fields,
+				Added:    r.Added,
+				Cleared:  r.Cleared,
+				Edges:    r.Edges,
+				Time:     r.CreatedAt,
+			}
+			ids[i] = r.ID
+		}
+		for _, s := range o.sinks {
+			if err := s.Send(ctx, events); err != nil {
+				return n, fmt.Errorf("cdc: delivering events %d to %d: %w", ids[0], ids[len(ids)-1], err)
+			}
+		}
+		err = o.client.OutboxEvent.Update().
+			Where(outboxevent.IDIn(ids...)).
+			SetDeliveredAt(time.Now()).
+			Exec(ctx)
+		if err != nil {
+			return n, fmt.Errorf("cdc: marking events delivered: %w", err)
+		}
+		n += len(rows)
+	}
+}
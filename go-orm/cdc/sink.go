@@ -0,0 +1,121 @@
+package cdc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// Sink receives the events delivered by an Outbox. Send must return an
+// error if the events were not all received, so they are sent again.
+type Sink interface {
+	Send(ctx context.Context, events []ChangeEvent) error
+}
+
+// SinkFunc adapts a function to the Sink interface.
+type SinkFunc func(ctx context.Context, events []ChangeEvent) error
+
+// Send calls f(ctx, events).
+func (f SinkFunc) Send(ctx context.Context, events []ChangeEvent) error {
+	return f(ctx, events)
+}
+
+// Chan returns a Sink sending the events to ch, one by one. It blocks
+// until they are received or ctx is done.
+func Chan(ch chan<- ChangeEvent) Sink {
+	return SinkFunc(func(ctx context.Context, events []ChangeEvent) error {
+		for _, e := range events {
+			select {
+			case ch <- e:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	})
+}
+
+// JSONL is a Sink appending the events to a file, one JSON object per line.
+type JSONL struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewJSONL returns a JSONL sink writing to the file at path.
+func NewJSONL(path string) *JSONL {
+	return &JSONL{path: path}
+}
+
+// Send appends the events to the file and syncs it to disk.
+func (j *JSONL) Send(ctx context.Context, events []ChangeEvent) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	f, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, e := range events {
+		if err := enc.Encode(e); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Webhook is a Sink posting the events to a URL as a JSON array.
+type Webhook struct {
+	URL string
+	// Header is added to the requests, for example for authentication.
+	Header http.Header
+	// Client sends the requests. It defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// Send posts the events. Any response status other than 2xx is an error.
+func (w *Webhook) Send(ctx context.Context, events []ChangeEvent) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, vs := range w.Header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook %s: %s", w.URL, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package dataset
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"gopkg.in/yaml.v3"
+)
+
+// WriteJSON writes the dataset to w as indented JSON.
+func WriteJSON(w io.Writer, d *Dataset) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
+
+// ReadJSON reads a dataset written by WriteJSON.
+func ReadJSON(r io.Reader) (*Dataset, error) {
+	d := &Dataset{}
+	if err := json.NewDecoder(r).Decode(d); err != nil {
+		return nil, fmt.Errorf("dataset: decoding JSON: %w", err)
+	}
+	return d, nil
+}
+
+// ReadYAML reads a dataset from YAML. It has the same layout as the JSON form.
+func ReadYAML(r io.Reader) (*Dataset, error) {
+	d := &Dataset{}
+	if err := yaml.NewDecoder(r).Decode(d); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("dataset: decoding YAML: %w", err)
+	}
+	return d, nil
+}
+
+// LoadFixtures imports the datasets of the given YAML files, in order.
+// It is meant for seeding the databases of tests and demos.
+func LoadFixtures(ctx context.Context, client *ent.Client, paths ...string) error {
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		d, err := ReadYAML(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if err := Import(ctx, client, d); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// The files a dataset is split into in its CSV form, and their headers.
+// The first column of every file is the tenant of its rows.
+var csvFiles = []struct {
+	name   string
+	header []string
+}{
+	{"users.csv", []string{"tenant", "name", "age"}},
+	{"cars.csv", []string{"tenant", "model", "registered_at", "owner"}},
+	{"groups.csv", []string{"tenant", "name"}},
+	{"members.csv", []string{"tenant", "group", "user", "role"}},
+	{"registrations.csv", []string{"tenant", "car", "car_registered_at", "plate", "region", "valid_from", "valid_to", "owner"}},
+}
+
+// WriteCSV writes the dataset to the directory dir, one CSV file per
+// entity, one for the group members and one for the car registrations.
+func WriteCSV(dir string, d *Dataset) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	rows := make([][][]string, len(csvFiles))
+	for _, u := range d.Users {
+		rows[0] = append(rows[0], []string{strconv.Itoa(u.Tenant), u.Name, strconv.Itoa(u.Age)})
+	}
+	for _, c := range d.Cars {
+		tid, at := strconv.Itoa(c.Tenant), c.RegisteredAt.Format(time.RFC3339Nano)
+		rows[1] = append(rows[1], []string{tid, c.Model, at, c.Owner})
+		for _, r := range c.Registrations {
+			var to string // empty for the current registration
+			if r.ValidTo != nil {
+				to = r.ValidTo.Format(time.RFC3339Nano)
+			}
+			rows[4] = append(rows[4], []string{tid, c.Model, at, r.Plate, r.Region, r.ValidFrom.Format(time.RFC3339Nano), to, r.Owner})
+		}
+	}
+	for _, g := range d.Groups {
+		tid := strconv.Itoa(g.Tenant)
+		rows[2] = append(rows[2], []string{tid, g.Name})
+		for _, m := range g.Members {
+			rows[3] = append(rows[3], []string{tid, g.Name, m.User, m.Role})
+		}
+	}
+	for i, file := range csvFiles {
+		if err := writeCSVFile(filepath.Join(dir, file.name), file.header, rows[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCSVFile(path string, header []string, rows [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	w.Write(header)
+	w.WriteAll(rows) // WriteAll flushes and reports the first error.
+	if err := w.Error(); err != nil {
+		f.Close()
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// ReadCSV reads a dataset written by WriteCSV.
+func ReadCSV(dir string) (*Dataset, error) {
+	rows := make([][][]string, len(csvFiles))
+	tenants := make([][]int, len(csvFiles))
+	for i, file := range csvFiles {
+		var err error
+		path := filepath.Join(dir, file.name)
+		if rows[i], err = readCSVFile(path, file.header); err != nil {
+			return nil, err
+		}
+		// Split the tenants off, for the columns of the rows to be the
+		// ones of the dataset.
+		tenants[i] = make([]int, len(rows[i]))
+		for j, r := range rows[i] {
+			if tenants[i][j], err = strconv.Atoi(r[0]); err != nil {
+				return nil, fmt.Errorf("%s: row %d: bad tenant: %w", path, j+2, err)
+			}
+			rows[i][j] = r[1:]
+		}
+	}
+	d := &Dataset{}
+	for j, r := range rows[0] {
+		age, err := strconv.Atoi(r[1])
+		if err != nil {
+			return nil, fmt.Errorf("dataset: user %q: bad age: %w", r[0], err)
+		}
+		d.Users = append(d.Users, User{Tenant: tenants[0][j], Name: r[0], Age: age})
+	}
+	for j, r := range rows[1] {
+		at, err := time.Parse(time.RFC3339Nano, r[1])
+		if err != nil {
+			return nil, fmt.Errorf("dataset: car %q: bad registered_at: %w", r[0], err)
+		}
+		d.Cars = append(d.Cars, Car{Tenant: tenants[1][j], Model: r[0], RegisteredAt: at, Owner: r[2]})
+	}
+	cars := make(map[string]int)
+	for i, c := range d.Cars {
+		cars[tenantKey(c.Tenant, carKey(c.Model, c.RegisteredAt))] = i
+	}
+	for j, r := range rows[4] {
+		at, err := time.Parse(time.RFC3339Nano, r[1])
+		if err != nil {
+			return nil, fmt.Errorf("dataset: registration %q: bad car_registered_at: %w", r[2], err)
+		}
+		i, ok := cars[tenantKey(tenants[4][j], carKey(r[0], at))]
+		if !ok {
+			return nil, fmt.Errorf("dataset: registration %q of unknown car %q registered at %v", r[2], r[0], at)
+		}
+		reg := Registration{Plate: r[2], Region: r[3], Owner: r[6]}
+		if reg.ValidFrom, err = time.Parse(time.RFC3339Nano, r[4]); err != nil {
+			return nil, fmt.Errorf("dataset: registration %q: bad valid_from: %w", r[2], err)
+		}
+		if r[5] != "" {
+			to, err := time.Parse(time.RFC3339Nano, r[5])
+			if err != nil {
+				return nil, fmt.Errorf("dataset: registration %q: bad valid_to: %w", r[2], err)
+			}
+			reg.ValidTo = &to
+		}
+		d.Cars[i].Registrations = append(d.Cars[i].Registrations, reg)
+	}
+	index := make(map[string]int)
+	for j, r := range rows[2] {
+		index[tenantKey(tenants[2][j], r[0])] = len(d.Groups)
+		d.Groups = append(d.Groups, Group{Tenant: tenants[2][j], Name: r[0]})
+	}
+	for j, r := range rows[3] {
+		i, ok := index[tenantKey(tenants[3][j], r[0])]
+		if !ok {
+			return nil, fmt.Errorf("dataset: member %q of unknown group %q", r[1], r[0])
+		}
+		d.Groups[i].Members = append(d.Groups[i].Members, Member{User: r[1], Role: r[2]})
+	}
+	return d, nil
+}
+
+// readCSVFile returns the rows of the file after checking its header.
+func readCSVFile(path string, header []string) ([][]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = len(header)
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%s: missing header", path)
+	}
+	for i, col := range header {
+		if rows[0][i] != col {
+			return nil, fmt.Errorf("%s: unexpected column %q, want %q", path, rows[0][i], col)
+		}
+	}
+	return rows[1:], nil
+}
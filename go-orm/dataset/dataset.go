@@ -0,0 +1,165 @@
+// Package dataset exports the Users, Cars and Groups of a database
+// together with their edges and the registrations of the cars, and
+// imports them back.
+//
+// Entities are identified by natural keys instead of their ids, so a
+// Dataset can be imported into any database, and importing it twice
+// leaves the database unchanged:
+//
+//	User   tenant and name
+//	Car    tenant, model and registered_at
+//	Group  tenant and name
+//	Registration  its car and valid_from
+//
+// Edges refer to the entities by the same keys, and link the entities
+// of a tenant only. The tenant of the entities is exported and imported
+// when the context is not bound to one; otherwise, the data is the one
+// of the tenant of the context, and has no tenant.
+package dataset
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/group"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/registration"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+	"github.com/anjanashankar9/go-learning/go-orm/rule"
+)
+
+// Dataset is a snapshot of the entities of a database.
+type Dataset struct {
+	Users  []User  `json:"users" yaml:"users"`
+	Cars   []Car   `json:"cars" yaml:"cars"`
+	Groups []Group `json:"groups" yaml:"groups"`
+}
+
+// User is the exported form of an ent.User.
+type User struct {
+	// Tenant is the tenant of the user, of the car or of the group.
+	Tenant int    `json:"tenant,omitempty" yaml:"tenant,omitempty"`
+	Name   string `json:"name" yaml:"name"`
+	Age    int    `json:"age" yaml:"age"`
+}
+
+// Car is the exported form of an ent.Car.
+type Car struct {
+	Tenant       int       `json:"tenant,omitempty" yaml:"tenant,omitempty"`
+	Model        string    `json:"model" yaml:"model"`
+	RegisteredAt time.Time `json:"registered_at" yaml:"registered_at"`
+	// Owner is the name of the owner, if the car has one.
+	Owner string `json:"owner,omitempty" yaml:"owner,omitempty"`
+	// Registrations are the registrations of the car, by valid_from.
+	Registrations []Registration `json:"registrations,omitempty" yaml:"registrations,omitempty"`
+}
+
+// Registration is the exported form of an ent.Registration of a car.
+type Registration struct {
+	Plate     string    `json:"plate" yaml:"plate"`
+	Region    string    `json:"region" yaml:"region"`
+	ValidFrom time.Time `json:"valid_from" yaml:"valid_from"`
+	// ValidTo is nil for the current registration of the car.
+	ValidTo *time.Time `json:"valid_to,omitempty" yaml:"valid_to,omitempty"`
+	// Owner is the name of the owner of the car under the registration.
+	Owner string `json:"owner" yaml:"owner"`
+}
+
+// Group is the exported form of an ent.Group.
+type Group struct {
+	Tenant  int      `json:"tenant,omitempty" yaml:"tenant,omitempty"`
+	Name    string   `json:"name" yaml:"name"`
+	Members []Member `json:"members,omitempty" yaml:"members,omitempty"`
+}
+
+// Member is a user of a group.
+type Member struct {
+	// User is the name of the member.
+	User string `json:"user" yaml:"user"`
+	// Role is "admin" or "member". It defaults to "member".
+	Role string `json:"role,omitempty" yaml:"role,omitempty"`
+}
+
+// Export reads all the Users, Cars and Groups, their edges and the
+// registrations of the cars. The entities are ordered by their natural
+// keys. If the viewer is bound to a tenant, the entities are the ones
+// of the tenant, and have no tenant.
+func Export(ctx context.Context, client *ent.Client) (*Dataset, error) {
+	// exported returns the tenant of an entity to export.
+	exported := func(id int) int { return id }
+	if _, bound := rule.TenantOf(ctx); bound {
+		exported = func(int) int { return 0 }
+	}
+	users, err := client.User.Query().
+		Order(ent.Asc(user.FieldTenantID, user.FieldName, user.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed querying users: %w", err)
+	}
+	cars, err := client.Car.Query().
+		WithOwner().
+		WithRegistrations(func(q *ent.RegistrationQuery) {
+			q.WithOwner().Order(ent.Asc(registration.FieldValidFrom, registration.FieldID))
+		}).
+		Order(ent.Asc(car.FieldTenantID, car.FieldModel, car.FieldRegisteredAt, car.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed querying cars: %w", err)
+	}
+	groups, err := client.Group.Query().
+		WithMemberships(func(q *ent.MembershipQuery) {
+			q.WithUser()
+		}).
+		Order(ent.Asc(group.FieldTenantID, group.FieldName, group.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed querying groups: %w", err)
+	}
+
+	d := &Dataset{}
+	for _, u := range users {
+		d.Users = append(d.Users, User{Tenant: exported(u.TenantID), Name: u.Name, Age: u.Age})
+	}
+	for _, c := range cars {
+		ec := Car{Tenant: exported(c.TenantID), Model: c.Model, RegisteredAt: c.RegisteredAt}
+		if c.Edges.Owner != nil {
+			ec.Owner = c.Edges.Owner.Name
+		}
+		for _, r := range c.Edges.Registrations {
+			er := Registration{Plate: r.Plate, Region: r.Region, ValidFrom: r.ValidFrom, ValidTo: r.ValidTo}
+			if r.Edges.Owner != nil {
+				er.Owner = r.Edges.Owner.Name
+			}
+			ec.Registrations = append(ec.Registrations, er)
+		}
+		d.Cars = append(d.Cars, ec)
+	}
+	for _, g := range groups {
+		eg := Group{Tenant: exported(g.TenantID), Name: g.Name}
+		for _, m := range g.Edges.Memberships {
+			if m.Edges.User == nil {
+				continue
+			}
+			eg.Members = append(eg.Members, Member{User: m.Edges.User.Name, Role: m.Role.String()})
+		}
+		sort.Slice(eg.Members, func(i, j int) bool {
+			return eg.Members[i].User < eg.Members[j].User
+		})
+		d.Groups = append(d.Groups, eg)
+	}
+	return d, nil
+}
+
+// registrationKey returns the natural key of a registration of a car.
+func registrationKey(car string, validFrom time.Time) string {
+	return car + "\x00" + validFrom.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano)
+}
+
+// carKey returns the natural key of a car. Times are compared in UTC
+// and at the microsecond precision databases store them with.
+func carKey(model string, registeredAt time.Time) string {
+	return model + "\x00" + registeredAt.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano)
+}
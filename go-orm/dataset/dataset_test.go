@@ -0,0 +1,242 @@
+package dataset
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/enttest"
+	_ "github.com/anjanashankar9/go-learning/go-orm/ent/runtime"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+	"github.com/anjanashankar9/go-learning/go-orm/tenant"
+	"github.com/anjanashankar9/go-learning/go-orm/viewer"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openClient(t *testing.T) *ent.Client {
+	client := enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name()))
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func testDataset() *Dataset {
+	at := time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)
+	sold := at.AddDate(1, 0, 0)
+	return &Dataset{
+		Users: []User{{Name: "a8m", Age: 30}, {Name: "nati", Age: 28}},
+		Cars: []Car{
+			{Model: "Ford", RegisteredAt: at.AddDate(0, 1, 0)},
+			{Model: "Tesla", RegisteredAt: at, Owner: "nati", Registrations: []Registration{
+				{Plate: "AB-123-CD", Region: "CA", ValidFrom: at, ValidTo: &sold, Owner: "a8m"},
+				{Plate: "EF-456-GH", Region: "NY", ValidFrom: sold, Owner: "nati"},
+			}},
+		},
+		Groups: []Group{{Name: "GitHub", Members: []Member{{User: "a8m", Role: "admin"}, {User: "nati", Role: "member"}}}},
+	}
+}
+
+// tenantDataset returns the test dataset in the tenants 1 and 2, with
+// the same names in both.
+func tenantDataset() *Dataset {
+	d := &Dataset{}
+	for _, tid := range []int{1, 2} {
+		td := testDataset()
+		for i := range td.Users {
+			td.Users[i].Tenant = tid
+			td.Users[i].Age += tid
+		}
+		for i := range td.Cars {
+			td.Cars[i].Tenant = tid
+		}
+		for i := range td.Groups {
+			td.Groups[i].Tenant = tid
+		}
+		d.Users = append(d.Users, td.Users...)
+		d.Cars = append(d.Cars, td.Cars...)
+		d.Groups = append(d.Groups, td.Groups...)
+	}
+	return d
+}
+
+// normalize returns the dataset with its times in UTC, as read back
+// from the database.
+func normalize(d *Dataset) *Dataset {
+	for i := range d.Cars {
+		c := &d.Cars[i]
+		c.RegisteredAt = c.RegisteredAt.UTC()
+		for j := range c.Registrations {
+			r := &c.Registrations[j]
+			r.ValidFrom = r.ValidFrom.UTC()
+			if r.ValidTo != nil {
+				to := r.ValidTo.UTC()
+				r.ValidTo = &to
+			}
+		}
+	}
+	return d
+}
+
+func TestImportExport(t *testing.T) {
+	client := openClient(t)
+	ctx := viewer.AdminContext(context.Background())
+	want := testDataset()
+	for i := 0; i < 2; i++ { // the second import changes nothing
+		if err := Import(ctx, client, want); err != nil {
+			t.Fatalf("import %d: %v", i+1, err)
+		}
+		got, err := Export(ctx, client)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(normalize(got), want) {
+			t.Errorf("import %d: exported\n%+v\nwant\n%+v", i+1, got, want)
+		}
+	}
+	if n := client.Registration.Query().CountX(ctx); n != 2 {
+		t.Errorf("%d registrations, want 2", n)
+	}
+
+	// The current registration ends, and a new one starts.
+	end := want.Cars[1].Registrations[1].ValidFrom.AddDate(1, 0, 0)
+	want.Cars[1].Registrations[1].ValidTo = &end
+	want.Cars[1].Registrations = append(want.Cars[1].Registrations,
+		Registration{Plate: "IJ-789-KL", Region: "NY", ValidFrom: end, Owner: "a8m"})
+	if err := Import(ctx, client, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Export(ctx, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(normalize(got), want) {
+		t.Errorf("exported\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestImportTenants(t *testing.T) {
+	client := openClient(t)
+	admin := viewer.AdminContext(context.Background())
+	want := tenantDataset()
+	for i := 0; i < 2; i++ { // the second import changes nothing
+		if err := Import(admin, client, want); err != nil {
+			t.Fatalf("import %d: %v", i+1, err)
+		}
+		got, err := Export(admin, client)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(normalize(got), want) {
+			t.Errorf("import %d: exported\n%+v\nwant\n%+v", i+1, got, want)
+		}
+	}
+	for _, tid := range []int{1, 2} {
+		a8m := client.User.Query().Where(user.Name("a8m"), user.TenantID(tid)).OnlyX(admin)
+		if a8m.Age != 30+tid {
+			t.Errorf("a8m of tenant %d is %d, want %d", tid, a8m.Age, 30+tid)
+		}
+		for _, c := range a8m.QueryRegistrations().QueryCar().AllX(admin) {
+			if c.TenantID != tid {
+				t.Errorf("a8m of tenant %d has a registration of car %d of tenant %d", tid, c.ID, c.TenantID)
+			}
+		}
+	}
+
+	// The data of a tenant is exported without its tenant, and can be
+	// imported into another one.
+	one, err := Export(tenant.NewContext(admin, 1), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(normalize(one), testDatasetAged(1)) {
+		t.Errorf("tenant 1: exported\n%+v\nwant\n%+v", one, testDatasetAged(1))
+	}
+	if err := Import(tenant.NewContext(admin, 3), client, one); err != nil {
+		t.Fatal(err)
+	}
+	three, err := Export(tenant.NewContext(admin, 3), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(normalize(three), one) {
+		t.Errorf("tenant 3: exported\n%+v\nwant\n%+v", three, one)
+	}
+	if n := client.User.Query().Where(user.Name("a8m")).CountX(admin); n != 3 {
+		t.Errorf("%d users named a8m, want one per tenant", n)
+	}
+
+	// A dataset with tenants is not imported into the tenant of the context.
+	if err := Import(tenant.NewContext(admin, 3), client, want); err == nil {
+		t.Error("imported the data of tenants into a tenant")
+	}
+	// Nor are the edges between tenants.
+	cross := &Dataset{
+		Users: []User{{Tenant: 1, Name: "ariel", Age: 40}},
+		Cars:  []Car{{Tenant: 2, Model: "Fiat", RegisteredAt: time.Now(), Owner: "ariel"}},
+	}
+	if err := Import(admin, client, cross); err == nil {
+		t.Error("imported a car of tenant 2 with an owner of tenant 1")
+	}
+}
+
+// testDatasetAged returns the test dataset of tenantDataset, in the
+// tenant tid, without its tenant.
+func testDatasetAged(tid int) *Dataset {
+	d := testDataset()
+	for i := range d.Users {
+		d.Users[i].Age += tid
+	}
+	return d
+}
+
+func TestCodecs(t *testing.T) {
+	want := tenantDataset()
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadJSON(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(normalize(got), want) {
+		t.Errorf("JSON: read\n%+v\nwant\n%+v", got, want)
+	}
+
+	dir := t.TempDir()
+	if err := WriteCSV(dir, want); err != nil {
+		t.Fatal(err)
+	}
+	if got, err = ReadCSV(dir); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(normalize(got), want) {
+		t.Errorf("CSV: read\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestImportInvalid(t *testing.T) {
+	client := openClient(t)
+	ctx := viewer.AdminContext(context.Background())
+	for name, edit := range map[string]func(d *Dataset){
+		"duplicate registration": func(d *Dataset) {
+			c := &d.Cars[1]
+			c.Registrations = append(c.Registrations, c.Registrations[0])
+		},
+		"registration without owner": func(d *Dataset) { d.Cars[1].Registrations[0].Owner = "" },
+		"unknown owner":              func(d *Dataset) { d.Cars[1].Registrations[0].Owner = "nobody" },
+	} {
+		d := testDataset()
+		edit(d)
+		if err := Import(ctx, client, d); err == nil {
+			t.Errorf("%s: Import succeeded", name)
+		}
+	}
+	if n := client.User.Query().CountX(ctx); n != 0 {
+		t.Errorf("%d users after the failed imports, want 0", n)
+	}
+}
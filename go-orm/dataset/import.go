@@ -0,0 +1,502 @@
+package dataset
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/group"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/membership"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/registration"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+	"github.com/anjanashankar9/go-learning/go-orm/rule"
+	"github.com/anjanashankar9/go-learning/go-orm/tenant"
+	"github.com/anjanashankar9/go-learning/go-orm/txn"
+)
+
+// Import writes the dataset to the database in a single transaction.
+// Entities that do not exist yet are created in bulk, the fields of the
+// existing ones are updated, and the edges and the registrations of the
+// cars are added once all the entities exist. Import never deletes
+// entities or edges, so importing the same dataset again is a no-op.
+//
+// If the context is bound to a tenant, the dataset must have no tenant,
+// and is imported into the tenant of the context. Otherwise, the entities
+// of each tenant are imported into their tenant.
+func Import(ctx context.Context, client *ent.Client, d *Dataset) error {
+	_, bound := rule.TenantOf(ctx)
+	if err := d.validate(bound); err != nil {
+		return err
+	}
+	return txn.WithTx(ctx, client, func(tx *ent.Tx) error {
+		c := tx.Client()
+		if bound {
+			return importTenant(ctx, c, d)
+		}
+		for _, p := range d.byTenant() {
+			if err := importTenant(tenant.NewContext(ctx, p.tenant), c, p.d); err != nil {
+				return fmt.Errorf("tenant %d: %w", p.tenant, err)
+			}
+		}
+		return nil
+	})
+}
+
+// part is the data of a tenant.
+type part struct {
+	tenant int
+	d      *Dataset
+}
+
+// byTenant splits the dataset by tenant, in the order of the tenant ids.
+func (d *Dataset) byTenant() []part {
+	parts := make(map[int]*Dataset)
+	get := func(id int) *Dataset {
+		if parts[id] == nil {
+			parts[id] = &Dataset{}
+		}
+		return parts[id]
+	}
+	for _, u := range d.Users {
+		p := get(u.Tenant)
+		p.Users = append(p.Users, u)
+	}
+	for _, c := range d.Cars {
+		p := get(c.Tenant)
+		p.Cars = append(p.Cars, c)
+	}
+	for _, g := range d.Groups {
+		p := get(g.Tenant)
+		p.Groups = append(p.Groups, g)
+	}
+	ids := make([]int, 0, len(parts))
+	for id := range parts {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	ps := make([]part, 0, len(ids))
+	for _, id := range ids {
+		ps = append(ps, part{tenant: id, d: parts[id]})
+	}
+	return ps
+}
+
+// importTenant imports the dataset into the tenant of the context. The
+// privacy rules limit the lookups of the natural keys to the tenant.
+func importTenant(ctx context.Context, c *ent.Client, d *Dataset) error {
+	users, err := importUsers(ctx, c, d.Users)
+	if err != nil {
+		return err
+	}
+	cars, err := importCars(ctx, c, d.Cars)
+	if err != nil {
+		return err
+	}
+	groups, err := importGroups(ctx, c, d.Groups)
+	if err != nil {
+		return err
+	}
+	if err := linkOwners(ctx, c, d.Cars, cars, users); err != nil {
+		return err
+	}
+	if err := importRegistrations(ctx, c, d.Cars, cars, users); err != nil {
+		return err
+	}
+	return linkMembers(ctx, c, d.Groups, groups, users)
+}
+
+// validate checks that the natural keys of the dataset are unique in
+// their tenant, and that the dataset has no tenant if it is imported
+// into the tenant of the context.
+func (d *Dataset) validate(bound bool) error {
+	checkTenant := func(kind, name string, id int) error {
+		if bound && id != 0 {
+			return fmt.Errorf("dataset: %s %q has tenant %d, and the context is bound to a tenant", kind, name, id)
+		}
+		return nil
+	}
+	users := make(map[string]bool)
+	for _, u := range d.Users {
+		if err := checkTenant("user", u.Name, u.Tenant); err != nil {
+			return err
+		}
+		k := tenantKey(u.Tenant, u.Name)
+		if users[k] {
+			return fmt.Errorf("dataset: duplicate user %q", u.Name)
+		}
+		users[k] = true
+	}
+	cars := make(map[string]bool)
+	for _, c := range d.Cars {
+		if err := checkTenant("car", c.Model, c.Tenant); err != nil {
+			return err
+		}
+		k := tenantKey(c.Tenant, carKey(c.Model, c.RegisteredAt))
+		if cars[k] {
+			return fmt.Errorf("dataset: duplicate car %q registered at %v", c.Model, c.RegisteredAt)
+		}
+		cars[k] = true
+		regs := make(map[string]bool)
+		for _, r := range c.Registrations {
+			rk := registrationKey(k, r.ValidFrom)
+			if regs[rk] {
+				return fmt.Errorf("dataset: car %q has duplicate registration from %v", c.Model, r.ValidFrom)
+			}
+			regs[rk] = true
+			if r.Owner == "" {
+				return fmt.Errorf("dataset: car %q registration %q has no owner", c.Model, r.Plate)
+			}
+		}
+	}
+	groups := make(map[string]bool)
+	for _, g := range d.Groups {
+		if err := checkTenant("group", g.Name, g.Tenant); err != nil {
+			return err
+		}
+		k := tenantKey(g.Tenant, g.Name)
+		if groups[k] {
+			return fmt.Errorf("dataset: duplicate group %q", g.Name)
+		}
+		groups[k] = true
+		members := make(map[string]bool)
+		for _, m := range g.Members {
+			if members[m.User] {
+				return fmt.Errorf("dataset: group %q has duplicate member %q", g.Name, m.User)
+			}
+			members[m.User] = true
+			if m.Role == "" {
+				continue
+			}
+			if err := membership.RoleValidator(membership.Role(m.Role)); err != nil {
+				return fmt.Errorf("dataset: group %q member %q: %w", g.Name, m.User, err)
+			}
+		}
+	}
+	return nil
+}
+
+// tenantKey prefixes the natural key of an entity with its tenant.
+func tenantKey(tenant int, key string) string {
+	return fmt.Sprintf("%d/%s", tenant, key)
+}
+
+// usersByName returns the users of the tenant of the context with the
+// given names.
+func usersByName(ctx context.Context, c *ent.Client, names []string) (map[string]*ent.User, error) {
+	users, err := c.User.Query().
+		Where(user.NameIn(names...)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed querying users: %w", err)
+	}
+	byName := make(map[string]*ent.User)
+	for _, u := range users {
+		if _, ok := byName[u.Name]; ok {
+			return nil, fmt.Errorf("dataset: more than one user named %q", u.Name)
+		}
+		byName[u.Name] = u
+	}
+	return byName, nil
+}
+
+func importUsers(ctx context.Context, c *ent.Client, us []User) (map[string]*ent.User, error) {
+	names := make([]string, 0, len(us))
+	for _, u := range us {
+		names = append(names, u.Name)
+	}
+	existing, err := usersByName(ctx, c, names)
+	if err != nil {
+		return nil, err
+	}
+	var bulk []*ent.UserCreate
+	for _, u := range us {
+		old, ok := existing[u.Name]
+		if !ok {
+			bulk = append(bulk, c.User.Create().SetName(u.Name).SetAge(u.Age))
+			continue
+		}
+		if old.Age != u.Age {
+			if existing[u.Name], err = old.Update().SetAge(u.Age).Save(ctx); err != nil {
+				return nil, fmt.Errorf("failed updating user %q: %w", u.Name, err)
+			}
+		}
+	}
+	if len(bulk) > 0 {
+		created, err := c.User.CreateBulk(bulk...).Save(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed creating users: %w", err)
+		}
+		for _, u := range created {
+			existing[u.Name] = u
+		}
+	}
+	return existing, nil
+}
+
+func importCars(ctx context.Context, c *ent.Client, cs []Car) (map[string]*ent.Car, error) {
+	models := make([]string, 0, len(cs))
+	for _, ec := range cs {
+		models = append(models, ec.Model)
+	}
+	cars, err := c.Car.Query().
+		Where(car.ModelIn(models...)).
+		WithOwner().
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed querying cars: %w", err)
+	}
+	existing := make(map[string]*ent.Car)
+	for _, ec := range cars {
+		k := carKey(ec.Model, ec.RegisteredAt)
+		if _, ok := existing[k]; ok {
+			return nil, fmt.Errorf("dataset: more than one car %q registered at %v", ec.Model, ec.RegisteredAt)
+		}
+		existing[k] = ec
+	}
+	var bulk []*ent.CarCreate
+	for _, ec := range cs {
+		if _, ok := existing[carKey(ec.Model, ec.RegisteredAt)]; !ok {
+			bulk = append(bulk, c.Car.Create().SetModel(ec.Model).SetRegisteredAt(ec.RegisteredAt))
+		}
+	}
+	if len(bulk) > 0 {
+		created, err := c.Car.CreateBulk(bulk...).Save(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed creating cars: %w", err)
+		}
+		for _, ec := range created {
+			existing[carKey(ec.Model, ec.RegisteredAt)] = ec
+		}
+	}
+	return existing, nil
+}
+
+func importGroups(ctx context.Context, c *ent.Client, gs []Group) (map[string]*ent.Group, error) {
+	names := make([]string, 0, len(gs))
+	for _, g := range gs {
+		names = append(names, g.Name)
+	}
+	groups, err := c.Group.Query().
+		Where(group.NameIn(names...)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed querying groups: %w", err)
+	}
+	existing := make(map[string]*ent.Group)
+	for _, g := range groups {
+		if _, ok := existing[g.Name]; ok {
+			return nil, fmt.Errorf("dataset: more than one group named %q", g.Name)
+		}
+		existing[g.Name] = g
+	}
+	var bulk []*ent.GroupCreate
+	for _, g := range gs {
+		if _, ok := existing[g.Name]; !ok {
+			bulk = append(bulk, c.Group.Create().SetName(g.Name))
+		}
+	}
+	if len(bulk) > 0 {
+		created, err := c.Group.CreateBulk(bulk...).Save(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed creating groups: %w", err)
+		}
+		for _, g := range created {
+			existing[g.Name] = g
+		}
+	}
+	return existing, nil
+}
+
+// resolveUsers adds to users the ones referenced by name
+// that are not part of the dataset, but exist in the database.
+func resolveUsers(ctx context.Context, c *ent.Client, users map[string]*ent.User, names []string) error {
+	var missing []string
+	for _, name := range names {
+		if _, ok := users[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	found, err := usersByName(ctx, c, missing)
+	if err != nil {
+		return err
+	}
+	for _, name := range missing {
+		u, ok := found[name]
+		if !ok {
+			return fmt.Errorf("dataset: unknown user %q", name)
+		}
+		users[name] = u
+	}
+	return nil
+}
+
+func linkOwners(ctx context.Context, c *ent.Client, cs []Car, cars map[string]*ent.Car, users map[string]*ent.User) error {
+	var owners []string
+	for _, ec := range cs {
+		if ec.Owner != "" {
+			owners = append(owners, ec.Owner)
+		}
+	}
+	if err := resolveUsers(ctx, c, users, owners); err != nil {
+		return err
+	}
+	for _, ec := range cs {
+		if ec.Owner == "" {
+			continue
+		}
+		old := cars[carKey(ec.Model, ec.RegisteredAt)]
+		owner := users[ec.Owner]
+		if old.Edges.Owner != nil && old.Edges.Owner.ID == owner.ID {
+			continue
+		}
+		if err := c.Car.UpdateOneID(old.ID).SetOwnerID(owner.ID).Exec(ctx); err != nil {
+			return fmt.Errorf("failed setting owner of car %q: %w", ec.Model, err)
+		}
+	}
+	return nil
+}
+
+// importRegistrations creates the registrations of the cars that do not
+// exist yet, and updates the plate, region, end and owner of the others.
+func importRegistrations(ctx context.Context, c *ent.Client, cs []Car, cars map[string]*ent.Car, users map[string]*ent.User) error {
+	var owners []string
+	ids := make([]int, 0, len(cs))
+	for _, ec := range cs {
+		if len(ec.Registrations) == 0 {
+			continue
+		}
+		ids = append(ids, cars[carKey(ec.Model, ec.RegisteredAt)].ID)
+		for _, r := range ec.Registrations {
+			owners = append(owners, r.Owner)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := resolveUsers(ctx, c, users, owners); err != nil {
+		return err
+	}
+	regs, err := c.Registration.Query().
+		Where(registration.HasCarWith(car.IDIn(ids...))).
+		WithCar().
+		WithOwner().
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed querying registrations: %w", err)
+	}
+	existing := make(map[string]*ent.Registration)
+	for _, r := range regs {
+		k := registrationKey(carKey(r.Edges.Car.Model, r.Edges.Car.RegisteredAt), r.ValidFrom)
+		if _, ok := existing[k]; ok {
+			return fmt.Errorf("dataset: more than one registration of car %q from %v", r.Edges.Car.Model, r.ValidFrom)
+		}
+		existing[k] = r
+	}
+	var bulk []*ent.RegistrationCreate
+	for _, ec := range cs {
+		ck := carKey(ec.Model, ec.RegisteredAt)
+		for _, r := range ec.Registrations {
+			owner := users[r.Owner]
+			old, ok := existing[registrationKey(ck, r.ValidFrom)]
+			if !ok {
+				bulk = append(bulk, c.Registration.Create().
+					SetCarID(cars[ck].ID).
+					SetOwnerID(owner.ID).
+					SetPlate(r.Plate).
+					SetRegion(r.Region).
+					SetValidFrom(r.ValidFrom).
+					SetNillableValidTo(r.ValidTo))
+				continue
+			}
+			if old.Plate == r.Plate && old.Region == r.Region && sameTime(old.ValidTo, r.ValidTo) &&
+				old.Edges.Owner != nil && old.Edges.Owner.ID == owner.ID {
+				continue
+			}
+			u := old.Update().SetPlate(r.Plate).SetRegion(r.Region).SetOwnerID(owner.ID)
+			if r.ValidTo != nil {
+				u.SetValidTo(*r.ValidTo)
+			} else {
+				u.ClearValidTo()
+			}
+			if err := u.Exec(ctx); err != nil {
+				return fmt.Errorf("failed updating registration %q of car %q: %w", r.Plate, ec.Model, err)
+			}
+		}
+	}
+	if len(bulk) > 0 {
+		if err := c.Registration.CreateBulk(bulk...).Exec(ctx); err != nil {
+			return fmt.Errorf("failed creating registrations: %w", err)
+		}
+	}
+	return nil
+}
+
+// sameTime reports whether the optional times are both nil, or equal
+// at the precision of the database.
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Truncate(time.Microsecond).Equal(b.Truncate(time.Microsecond))
+}
+
+func linkMembers(ctx context.Context, c *ent.Client, gs []Group, groups map[string]*ent.Group, users map[string]*ent.User) error {
+	var names []string
+	ids := make([]int, 0, len(gs))
+	for _, g := range gs {
+		ids = append(ids, groups[g.Name].ID)
+		for _, m := range g.Members {
+			names = append(names, m.User)
+		}
+	}
+	if err := resolveUsers(ctx, c, users, names); err != nil {
+		return err
+	}
+	memberships, err := c.Membership.Query().
+		Where(membership.GroupIDIn(ids...)).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed querying memberships: %w", err)
+	}
+	type key struct{ group, user int }
+	existing := make(map[key]membership.Role)
+	for _, m := range memberships {
+		existing[key{m.GroupID, m.UserID}] = m.Role
+	}
+	var bulk []*ent.MembershipCreate
+	for _, g := range gs {
+		gid := groups[g.Name].ID
+		for _, m := range g.Members {
+			uid := users[m.User].ID
+			role := membership.DefaultRole
+			if m.Role != "" {
+				role = membership.Role(m.Role)
+			}
+			old, ok := existing[key{gid, uid}]
+			switch {
+			case !ok:
+				bulk = append(bulk, c.Membership.Create().SetGroupID(gid).SetUserID(uid).SetRole(role))
+			case old != role:
+				err := c.Membership.Update().
+					Where(membership.GroupID(gid), membership.UserID(uid)).
+					SetRole(role).
+					Exec(ctx)
+				if err != nil {
+					return fmt.Errorf("failed updating group %q member %q: %w", g.Name, m.User, err)
+				}
+			}
+		}
+	}
+	if len(bulk) > 0 {
+		if err := c.Membership.CreateBulk(bulk...).Exec(ctx); err != nil {
+			return fmt.Errorf("failed creating memberships: %w", err)
+		}
+	}
+	return nil
+}
@@ -17,6 +17,8 @@ type Car struct {
 	config `json:"-"`
 	// ID of the ent.
 	ID int `json:"id,omitempty"`
+	// TenantID holds the value of the "tenant_id" field.
+	TenantID int `json:"tenant_id,omitempty"`
 	// Model holds the value of the "model" field.
 	Model string `json:"model,omitempty"`
 	// RegisteredAt holds the value of the "registered_at" field.
@@ -31,9 +33,11 @@ type Car struct {
 type CarEdges struct {
 	// Owner holds the value of the owner edge.
 	Owner *User `json:"owner,omitempty"`
+	// Registrations holds the value of the registrations edge.
+	Registrations []*Registration `json:"registrations,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [1]bool
+	loadedTypes [2]bool
 }
 
 // OwnerOrErr returns the Owner value or an error if the edge
@@ -50,12 +54,21 @@ func (e CarEdges) OwnerOrErr() (*User, error) {
 	return nil, &NotLoadedError{edge: "owner"}
 }
 
+// RegistrationsOrErr returns the Registrations value or an error if the edge
+// was not loaded in eager-loading.
+func (e CarEdges) RegistrationsOrErr() ([]*Registration, error) {
+	if e.loadedTypes[1] {
+		return e.Registrations, nil
+	}
+	return nil, &NotLoadedError{edge: "registrations"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Car) scanValues(columns []string) ([]interface{}, error) {
 	values := make([]interface{}, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case car.FieldID:
+		case car.FieldID, car.FieldTenantID:
 			values[i] = new(sql.NullInt64)
 		case car.FieldModel:
 			values[i] = new(sql.NullString)
@@ -84,6 +97,12 @@ func (c *Car) assignValues(columns []string, values []interface{}) error {
 				return fmt.Errorf("unexpected type %T for field id", value)
 			}
 			c.ID = int(value.Int64)
+		case car.FieldTenantID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field tenant_id", values[i])
+			} else if value.Valid {
+				c.TenantID = int(value.Int64)
+			}
 		case car.FieldModel:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field model", values[i])
@@ -113,6 +132,11 @@ func (c *Car) QueryOwner() *UserQuery {
 	return (&CarClient{config: c.config}).QueryOwner(c)
 }
 
+// QueryRegistrations queries the "registrations" edge of the Car entity.
+func (c *Car) QueryRegistrations() *RegistrationQuery {
+	return (&CarClient{config: c.config}).QueryRegistrations(c)
+}
+
 // Update returns a builder for updating this Car.
 // Note that you need to call Car.Unwrap() before calling this method if this Car
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -136,6 +160,9 @@ func (c *Car) String() string {
 	var builder strings.Builder
 	builder.WriteString("Car(")
 	builder.WriteString(fmt.Sprintf("id=%v, ", c.ID))
+	builder.WriteString("tenant_id=")
+	builder.WriteString(fmt.Sprintf("%v", c.TenantID))
+	builder.WriteString(", ")
 	builder.WriteString("model=")
 	builder.WriteString(c.Model)
 	builder.WriteString(", ")
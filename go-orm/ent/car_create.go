@@ -11,6 +11,7 @@ import (
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
 	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/registration"
 	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
 )
 
@@ -21,6 +22,20 @@ type CarCreate struct {
 	hooks    []Hook
 }
 
+// SetTenantID sets the "tenant_id" field.
+func (cc *CarCreate) SetTenantID(i int) *CarCreate {
+	cc.mutation.SetTenantID(i)
+	return cc
+}
+
+// SetNillableTenantID sets the "tenant_id" field if the given value is not nil.
+func (cc *CarCreate) SetNillableTenantID(i *int) *CarCreate {
+	if i != nil {
+		cc.SetTenantID(*i)
+	}
+	return cc
+}
+
 // SetModel sets the "model" field.
 func (cc *CarCreate) SetModel(s string) *CarCreate {
 	cc.mutation.SetModel(s)
@@ -52,6 +67,21 @@ func (cc *CarCreate) SetOwner(u *User) *CarCreate {
 	return cc.SetOwnerID(u.ID)
 }
 
+// AddRegistrationIDs adds the "registrations" edge to the Registration entity by IDs.
+func (cc *CarCreate) AddRegistrationIDs(ids ...int) *CarCreate {
+	cc.mutation.AddRegistrationIDs(ids...)
+	return cc
+}
+
+// AddRegistrations adds the "registrations" edges to the Registration entity.
+func (cc *CarCreate) AddRegistrations(r ...*Registration) *CarCreate {
+	ids := make([]int, len(r))
+	for i := range r {
+		ids[i] = r[i].ID
+	}
+	return cc.AddRegistrationIDs(ids...)
+}
+
 // Mutation returns the CarMutation object of the builder.
 func (cc *CarCreate) Mutation() *CarMutation {
 	return cc.mutation
@@ -63,6 +93,9 @@ func (cc *CarCreate) Save(ctx context.Context) (*Car, error) {
 		err  error
 		node *Car
 	)
+	if err := cc.defaults(); err != nil {
+		return nil, err
+	}
 	if len(cc.hooks) == 0 {
 		if err = cc.check(); err != nil {
 			return nil, err
@@ -126,8 +159,20 @@ func (cc *CarCreate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (cc *CarCreate) defaults() error {
+	if _, ok := cc.mutation.TenantID(); !ok {
+		v := car.DefaultTenantID
+		cc.mutation.SetTenantID(v)
+	}
+	return nil
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (cc *CarCreate) check() error {
+	if _, ok := cc.mutation.TenantID(); !ok {
+		return &ValidationError{Name: "tenant_id", err: errors.New(`ent: missing required field "Car.tenant_id"`)}
+	}
 	if _, ok := cc.mutation.Model(); !ok {
 		return &ValidationError{Name: "model", err: errors.New(`ent: missing required field "Car.model"`)}
 	}
@@ -161,6 +206,14 @@ func (cc *CarCreate) createSpec() (*Car, *sqlgraph.CreateSpec) {
 			},
 		}
 	)
+	if value, ok := cc.mutation.TenantID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: car.FieldTenantID,
+		})
+		_node.TenantID = value
+	}
 	if value, ok := cc.mutation.Model(); ok {
 		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -197,6 +250,25 @@ func (cc *CarCreate) createSpec() (*Car, *sqlgraph.CreateSpec) {
 		_node.user_cars = &nodes[0]
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := cc.mutation.RegistrationsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   car.RegistrationsTable,
+			Columns: []string{car.RegistrationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: registration.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
@@ -214,6 +286,7 @@ func (ccb *CarCreateBulk) Save(ctx context.Context) ([]*Car, error) {
 	for i := range ccb.builders {
 		func(i int, root context.Context) {
 			builder := ccb.builders[i]
+			builder.defaults()
 			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
 				mutation, ok := m.(*CarMutation)
 				if !ok {
@@ -4,6 +4,8 @@ package ent
 
 import (
 	"context"
+	"database/sql/driver"
+	"errors"
 	"fmt"
 	"math"
 
@@ -12,6 +14,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
 	"github.com/anjanashankar9/go-learning/go-orm/ent/predicate"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/registration"
 	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
 )
 
@@ -25,8 +28,9 @@ type CarQuery struct {
 	fields     []string
 	predicates []predicate.Car
 	// eager-loading edges.
-	withOwner *UserQuery
-	withFKs   bool
+	withOwner         *UserQuery
+	withRegistrations *RegistrationQuery
+	withFKs           bool
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
 	path func(context.Context) (*sql.Selector, error)
@@ -85,6 +89,28 @@ func (cq *CarQuery) QueryOwner() *UserQuery {
 	return query
 }
 
+// QueryRegistrations chains the current query on the "registrations" edge.
+func (cq *CarQuery) QueryRegistrations() *RegistrationQuery {
+	query := &RegistrationQuery{config: cq.config}
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := cq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := cq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(car.Table, car.FieldID, selector),
+			sqlgraph.To(registration.Table, registration.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, car.RegistrationsTable, car.RegistrationsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(cq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
 // First returns the first Car entity from the query.
 // Returns a *NotFoundError when no Car was found.
 func (cq *CarQuery) First(ctx context.Context) (*Car, error) {
@@ -261,12 +287,13 @@ func (cq *CarQuery) Clone() *CarQuery {
 		return nil
 	}
 	return &CarQuery{
-		config:     cq.config,
-		limit:      cq.limit,
-		offset:     cq.offset,
-		order:      append([]OrderFunc{}, cq.order...),
-		predicates: append([]predicate.Car{}, cq.predicates...),
-		withOwner:  cq.withOwner.Clone(),
+		config:            cq.config,
+		limit:             cq.limit,
+		offset:            cq.offset,
+		order:             append([]OrderFunc{}, cq.order...),
+		predicates:        append([]predicate.Car{}, cq.predicates...),
+		withOwner:         cq.withOwner.Clone(),
+		withRegistrations: cq.withRegistrations.Clone(),
 		// clone intermediate query.
 		sql:    cq.sql.Clone(),
 		path:   cq.path,
@@ -285,21 +312,31 @@ func (cq *CarQuery) WithOwner(opts ...func(*UserQuery)) *CarQuery {
 	return cq
 }
 
+// WithRegistrations tells the query-builder to eager-load the nodes that are connected to
+// the "registrations" edge. The optional arguments are used to configure the query builder of the edge.
+func (cq *CarQuery) WithRegistrations(opts ...func(*RegistrationQuery)) *CarQuery {
+	query := &RegistrationQuery{config: cq.config}
+	for _, opt := range opts {
+		opt(query)
+	}
+	cq.withRegistrations = query
+	return cq
+}
+
 // GroupBy is used to group vertices by one or more fields/columns.
 // It is often used with aggregate functions, like: count, max, mean, min, sum.
 //
 // Example:
 //
 //	var v []struct {
-//		Model string `json:"model,omitempty"`
+//		TenantID int `json:"tenant_id,omitempty"`
 //		Count int `json:"count,omitempty"`
 //	}
 //
 //	client.Car.Query().
-//		GroupBy(car.FieldModel).
+//		GroupBy(car.FieldTenantID).
 //		Aggregate(ent.Count()).
 //		Scan(ctx, &v)
-//
 func (cq *CarQuery) GroupBy(field string, fields ...string) *CarGroupBy {
 	grbuild := &CarGroupBy{config: cq.config}
 	grbuild.fields = append([]string{field}, fields...)
@@ -320,13 +357,12 @@ func (cq *CarQuery) GroupBy(field string, fields ...string) *CarGroupBy {
 // Example:
 //
 //	var v []struct {
-//		Model string `json:"model,omitempty"`
+//		TenantID int `json:"tenant_id,omitempty"`
 //	}
 //
 //	client.Car.Query().
-//		Select(car.FieldModel).
+//		Select(car.FieldTenantID).
 //		Scan(ctx, &v)
-//
 func (cq *CarQuery) Select(fields ...string) *CarSelect {
 	cq.fields = append(cq.fields, fields...)
 	selbuild := &CarSelect{CarQuery: cq}
@@ -348,6 +384,12 @@ func (cq *CarQuery) prepareQuery(ctx context.Context) error {
 		}
 		cq.sql = prev
 	}
+	if car.Policy == nil {
+		return errors.New("ent: uninitialized car.Policy (forgotten import ent/runtime?)")
+	}
+	if err := car.Policy.EvalQuery(ctx, cq); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -356,8 +398,9 @@ func (cq *CarQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Car, err
 		nodes       = []*Car{}
 		withFKs     = cq.withFKs
 		_spec       = cq.querySpec()
-		loadedTypes = [1]bool{
+		loadedTypes = [2]bool{
 			cq.withOwner != nil,
+			cq.withRegistrations != nil,
 		}
 	)
 	if cq.withOwner != nil {
@@ -414,6 +457,35 @@ func (cq *CarQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Car, err
 		}
 	}
 
+	if query := cq.withRegistrations; query != nil {
+		fks := make([]driver.Value, 0, len(nodes))
+		nodeids := make(map[int]*Car)
+		for i := range nodes {
+			fks = append(fks, nodes[i].ID)
+			nodeids[nodes[i].ID] = nodes[i]
+			nodes[i].Edges.Registrations = []*Registration{}
+		}
+		query.withFKs = true
+		query.Where(predicate.Registration(func(s *sql.Selector) {
+			s.Where(sql.InValues(car.RegistrationsColumn, fks...))
+		}))
+		neighbors, err := query.All(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range neighbors {
+			fk := n.car_registrations
+			if fk == nil {
+				return nil, fmt.Errorf(`foreign-key "car_registrations" is nil for node %v`, n.ID)
+			}
+			node, ok := nodeids[*fk]
+			if !ok {
+				return nil, fmt.Errorf(`unexpected foreign-key "car_registrations" returned %v for node %v`, *fk, n.ID)
+			}
+			node.Edges.Registrations = append(node.Edges.Registrations, n)
+		}
+	}
+
 	return nodes, nil
 }
 
@@ -13,6 +13,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
 	"github.com/anjanashankar9/go-learning/go-orm/ent/predicate"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/registration"
 	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
 )
 
@@ -60,6 +61,21 @@ func (cu *CarUpdate) SetOwner(u *User) *CarUpdate {
 	return cu.SetOwnerID(u.ID)
 }
 
+// AddRegistrationIDs adds the "registrations" edge to the Registration entity by IDs.
+func (cu *CarUpdate) AddRegistrationIDs(ids ...int) *CarUpdate {
+	cu.mutation.AddRegistrationIDs(ids...)
+	return cu
+}
+
+// AddRegistrations adds the "registrations" edges to the Registration entity.
+func (cu *CarUpdate) AddRegistrations(r ...*Registration) *CarUpdate {
+	ids := make([]int, len(r))
+	for i := range r {
+		ids[i] = r[i].ID
+	}
+	return cu.AddRegistrationIDs(ids...)
+}
+
 // Mutation returns the CarMutation object of the builder.
 func (cu *CarUpdate) Mutation() *CarMutation {
 	return cu.mutation
@@ -71,6 +87,27 @@ func (cu *CarUpdate) ClearOwner() *CarUpdate {
 	return cu
 }
 
+// ClearRegistrations clears all "registrations" edges to the Registration entity.
+func (cu *CarUpdate) ClearRegistrations() *CarUpdate {
+	cu.mutation.ClearRegistrations()
+	return cu
+}
+
+// RemoveRegistrationIDs removes the "registrations" edge to Registration entities by IDs.
+func (cu *CarUpdate) RemoveRegistrationIDs(ids ...int) *CarUpdate {
+	cu.mutation.RemoveRegistrationIDs(ids...)
+	return cu
+}
+
+// RemoveRegistrations removes "registrations" edges to Registration entities.
+func (cu *CarUpdate) RemoveRegistrations(r ...*Registration) *CarUpdate {
+	ids := make([]int, len(r))
+	for i := range r {
+		ids[i] = r[i].ID
+	}
+	return cu.RemoveRegistrationIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (cu *CarUpdate) Save(ctx context.Context) (int, error) {
 	var (
@@ -192,6 +229,60 @@ func (cu *CarUpdate) sqlSave(ctx context.Context) (n int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if cu.mutation.RegistrationsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   car.RegistrationsTable,
+			Columns: []string{car.RegistrationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: registration.FieldID,
+				},
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := cu.mutation.RemovedRegistrationsIDs(); len(nodes) > 0 && !cu.mutation.RegistrationsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   car.RegistrationsTable,
+			Columns: []string{car.RegistrationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: registration.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := cu.mutation.RegistrationsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   car.RegistrationsTable,
+			Columns: []string{car.RegistrationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: registration.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if n, err = sqlgraph.UpdateNodes(ctx, cu.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{car.Label}
@@ -242,6 +333,21 @@ func (cuo *CarUpdateOne) SetOwner(u *User) *CarUpdateOne {
 	return cuo.SetOwnerID(u.ID)
 }
 
+// AddRegistrationIDs adds the "registrations" edge to the Registration entity by IDs.
+func (cuo *CarUpdateOne) AddRegistrationIDs(ids ...int) *CarUpdateOne {
+	cuo.mutation.AddRegistrationIDs(ids...)
+	return cuo
+}
+
+// AddRegistrations adds the "registrations" edges to the Registration entity.
+func (cuo *CarUpdateOne) AddRegistrations(r ...*Registration) *CarUpdateOne {
+	ids := make([]int, len(r))
+	for i := range r {
+		ids[i] = r[i].ID
+	}
+	return cuo.AddRegistrationIDs(ids...)
+}
+
 // Mutation returns the CarMutation object of the builder.
 func (cuo *CarUpdateOne) Mutation() *CarMutation {
 	return cuo.mutation
@@ -253,6 +359,27 @@ func (cuo *CarUpdateOne) ClearOwner() *CarUpdateOne {
 	return cuo
 }
 
+// ClearRegistrations clears all "registrations" edges to the Registration entity.
+func (cuo *CarUpdateOne) ClearRegistrations() *CarUpdateOne {
+	cuo.mutation.ClearRegistrations()
+	return cuo
+}
+
+// RemoveRegistrationIDs removes the "registrations" edge to Registration entities by IDs.
+func (cuo *CarUpdateOne) RemoveRegistrationIDs(ids ...int) *CarUpdateOne {
+	cuo.mutation.RemoveRegistrationIDs(ids...)
+	return cuo
+}
+
+// RemoveRegistrations removes "registrations" edges to Registration entities.
+func (cuo *CarUpdateOne) RemoveRegistrations(r ...*Registration) *CarUpdateOne {
+	ids := make([]int, len(r))
+	for i := range r {
+		ids[i] = r[i].ID
+	}
+	return cuo.RemoveRegistrationIDs(ids...)
+}
+
 // Select allows selecting one or more fields (columns) of the returned entity.
 // The default is selecting all fields defined in the entity schema.
 func (cuo *CarUpdateOne) Select(field string, fields ...string) *CarUpdateOne {
@@ -404,6 +531,60 @@ func (cuo *CarUpdateOne) sqlSave(ctx context.Context) (_node *Car, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if cuo.mutation.RegistrationsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   car.RegistrationsTable,
+			Columns: []string{car.RegistrationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: registration.FieldID,
+				},
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := cuo.mutation.RemovedRegistrationsIDs(); len(nodes) > 0 && !cuo.mutation.RegistrationsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   car.RegistrationsTable,
+			Columns: []string{car.RegistrationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: registration.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := cuo.mutation.RegistrationsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   car.RegistrationsTable,
+			Columns: []string{car.RegistrationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: registration.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Car{config: cuo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
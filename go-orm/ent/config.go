@@ -24,9 +24,12 @@ type config struct {
 
 // hooks per client, for fast access.
 type hooks struct {
-	Car   []ent.Hook
-	Group []ent.Hook
-	User  []ent.Hook
+	Car          []ent.Hook
+	Group        []ent.Hook
+	Membership   []ent.Hook
+	OutboxEvent  []ent.Hook
+	Registration []ent.Hook
+	User         []ent.Hook
 }
 
 // Options applies the options on the config object.
@@ -12,6 +12,9 @@ import (
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
 	"github.com/anjanashankar9/go-learning/go-orm/ent/group"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/membership"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/outboxevent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/registration"
 	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
 )
 
@@ -33,9 +36,12 @@ type OrderFunc func(*sql.Selector)
 // columnChecker returns a function indicates if the column exists in the given column.
 func columnChecker(table string) func(string) error {
 	checks := map[string]func(string) bool{
-		car.Table:   car.ValidColumn,
-		group.Table: group.ValidColumn,
-		user.Table:  user.ValidColumn,
+		car.Table:          car.ValidColumn,
+		group.Table:        group.ValidColumn,
+		membership.Table:   membership.ValidColumn,
+		outboxevent.Table:  outboxevent.ValidColumn,
+		registration.Table: registration.ValidColumn,
+		user.Table:         user.ValidColumn,
 	}
 	check, ok := checks[table]
 	if !ok {
@@ -85,7 +91,6 @@ type AggregateFunc func(*sql.Selector) string
 //	GroupBy(field1, field2).
 //	Aggregate(ent.As(ent.Sum(field1), "sum_field1"), (ent.As(ent.Sum(field2), "sum_field2")).
 //	Scan(ctx, &v)
-//
 func As(fn AggregateFunc, end string) AggregateFunc {
 	return func(s *sql.Selector) string {
 		return sql.As(fn(s), end)
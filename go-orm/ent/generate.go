@@ -1,3 +1,5 @@
 package ent
 
-//go:generate go run -mod=mod entgo.io/ent/cmd/ent generate ./schema
+//go:generate go run -mod=mod entgo.io/ent/cmd/ent generate --feature privacy,entql ./schema
+//go:generate go run -mod=mod ../schemadoc/gen.go -schema ./schema -o ../schemadoc/schemas.go
+//go:generate go run -mod=mod ../schemadoc -out ../docs
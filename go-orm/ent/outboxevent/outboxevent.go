@@ -0,0 +1,99 @@
+// Code generated by ent, DO NOT EDIT.
+
+package outboxevent
+
+import (
+	"fmt"
+	"time"
+
+	"entgo.io/ent"
+)
+
+const (
+	// Label holds the string label denoting the outboxevent type in the database.
+	Label = "outbox_event"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldOp holds the string denoting the op field in the database.
+	FieldOp = "op"
+	// FieldEntity holds the string denoting the entity field in the database.
+	FieldEntity = "entity"
+	// FieldEntityID holds the string denoting the entity_id field in the database.
+	FieldEntityID = "entity_id"
+	// FieldFields holds the string denoting the fields field in the database.
+	FieldFields = "fields"
+	// FieldAdded holds the string denoting the added field in the database.
+	FieldAdded = "added"
+	// FieldCleared holds the string denoting the cleared field in the database.
+	FieldCleared = "cleared"
+	// FieldEdges holds the string denoting the edges field in the database.
+	FieldEdges = "edges"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// FieldDeliveredAt holds the string denoting the delivered_at field in the database.
+	FieldDeliveredAt = "delivered_at"
+	// Table holds the table name of the outboxevent in the database.
+	Table = "outbox_events"
+)
+
+// Columns holds all SQL columns for outboxevent fields.
+var Columns = []string{
+	FieldID,
+	FieldOp,
+	FieldEntity,
+	FieldEntityID,
+	FieldFields,
+	FieldAdded,
+	FieldCleared,
+	FieldEdges,
+	FieldCreatedAt,
+	FieldDeliveredAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// Note that the variables below are initialized by the runtime
+// package on the initialization of the application. Therefore,
+// it should be imported in the main as follows:
+//
+//	import _ "github.com/anjanashankar9/go-learning/go-orm/ent/runtime"
+var (
+	Hooks  [2]ent.Hook
+	Policy ent.Policy
+	// EntityValidator is a validator for the "entity" field. It is called by the builders before save.
+	EntityValidator func(string) error
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+)
+
+// Op defines the type for the "op" enum field.
+type Op string
+
+// Op values.
+const (
+	OpCreate Op = "create"
+	OpUpdate Op = "update"
+	OpDelete Op = "delete"
+)
+
+func (_op Op) String() string {
+	return string(_op)
+}
+
+// OpValidator is a validator for the "op" field enum values. It is called by the builders before save.
+func OpValidator(_op Op) error {
+	switch _op {
+	case OpCreate, OpUpdate, OpDelete:
+		return nil
+	default:
+		return fmt.Errorf("outboxevent: invalid enum value for op field: %q", _op)
+	}
+}
@@ -0,0 +1,390 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/outboxevent"
+)
+
+// OutboxEventCreate is the builder for creating a OutboxEvent entity.
+type OutboxEventCreate struct {
+	config
+	mutation *OutboxEventMutation
+	hooks    []Hook
+}
+
+// SetOp sets the "op" field.
+func (oec *OutboxEventCreate) SetOp(o outboxevent.Op) *OutboxEventCreate {
+	oec.mutation.SetOp(o)
+	return oec
+}
+
+// SetEntity sets the "entity" field.
+func (oec *OutboxEventCreate) SetEntity(s string) *OutboxEventCreate {
+	oec.mutation.SetEntity(s)
+	return oec
+}
+
+// SetEntityID sets the "entity_id" field.
+func (oec *OutboxEventCreate) SetEntityID(i int) *OutboxEventCreate {
+	oec.mutation.SetEntityID(i)
+	return oec
+}
+
+// SetFields sets the "fields" field.
+func (oec *OutboxEventCreate) SetFields(m map[string]interface{}) *OutboxEventCreate {
+	oec.mutation.SetFields(m)
+	return oec
+}
+
+// SetAdded sets the "added" field.
+func (oec *OutboxEventCreate) SetAdded(m map[string]interface{}) *OutboxEventCreate {
+	oec.mutation.SetAdded(m)
+	return oec
+}
+
+// SetCleared sets the "cleared" field.
+func (oec *OutboxEventCreate) SetCleared(s []string) *OutboxEventCreate {
+	oec.mutation.SetCleared(s)
+	return oec
+}
+
+// SetEdges sets the "edges" field.
+func (oec *OutboxEventCreate) SetEdges(s []string) *OutboxEventCreate {
+	oec.mutation.SetEdges(s)
+	return oec
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (oec *OutboxEventCreate) SetCreatedAt(t time.Time) *OutboxEventCreate {
+	oec.mutation.SetCreatedAt(t)
+	return oec
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (oec *OutboxEventCreate) SetNillableCreatedAt(t *time.Time) *OutboxEventCreate {
+	if t != nil {
+		oec.SetCreatedAt(*t)
+	}
+	return oec
+}
+
+// SetDeliveredAt sets the "delivered_at" field.
+func (oec *OutboxEventCreate) SetDeliveredAt(t time.Time) *OutboxEventCreate {
+	oec.mutation.SetDeliveredAt(t)
+	return oec
+}
+
+// SetNillableDeliveredAt sets the "delivered_at" field if the given value is not nil.
+func (oec *OutboxEventCreate) SetNillableDeliveredAt(t *time.Time) *OutboxEventCreate {
+	if t != nil {
+		oec.SetDeliveredAt(*t)
+	}
+	return oec
+}
+
+// Mutation returns the OutboxEventMutation object of the builder.
+func (oec *OutboxEventCreate) Mutation() *OutboxEventMutation {
+	return oec.mutation
+}
+
+// Save creates the OutboxEvent in the database.
+func (oec *OutboxEventCreate) Save(ctx context.Context) (*OutboxEvent, error) {
+	var (
+		err  error
+		node *OutboxEvent
+	)
+	if err := oec.defaults(); err != nil {
+		return nil, err
+	}
+	if len(oec.hooks) == 0 {
+		if err = oec.check(); err != nil {
+			return nil, err
+		}
+		node, err = oec.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*OutboxEventMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = oec.check(); err != nil {
+				return nil, err
+			}
+			oec.mutation = mutation
+			if node, err = oec.sqlSave(ctx); err != nil {
+				return nil, err
+			}
+			mutation.id = &node.ID
+			mutation.done = true
+			return node, err
+		})
+		for i := len(oec.hooks) - 1; i >= 0; i-- {
+			if oec.hooks[i] == nil {
+				return nil, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = oec.hooks[i](mut)
+		}
+		v, err := mut.Mutate(ctx, oec.mutation)
+		if err != nil {
+			return nil, err
+		}
+		nv, ok := v.(*OutboxEvent)
+		if !ok {
+			return nil, fmt.Errorf("unexpected node type %T returned from OutboxEventMutation", v)
+		}
+		node = nv
+	}
+	return node, err
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (oec *OutboxEventCreate) SaveX(ctx context.Context) *OutboxEvent {
+	v, err := oec.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (oec *OutboxEventCreate) Exec(ctx context.Context) error {
+	_, err := oec.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (oec *OutboxEventCreate) ExecX(ctx context.Context) {
+	if err := oec.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (oec *OutboxEventCreate) defaults() error {
+	if _, ok := oec.mutation.CreatedAt(); !ok {
+		if outboxevent.DefaultCreatedAt == nil {
+			return fmt.Errorf("ent: uninitialized outboxevent.DefaultCreatedAt (forgotten import ent/runtime?)")
+		}
+		v := outboxevent.DefaultCreatedAt()
+		oec.mutation.SetCreatedAt(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (oec *OutboxEventCreate) check() error {
+	if _, ok := oec.mutation.GetOp(); !ok {
+		return &ValidationError{Name: "op", err: errors.New(`ent: missing required field "OutboxEvent.op"`)}
+	}
+	if v, ok := oec.mutation.GetOp(); ok {
+		if err := outboxevent.OpValidator(v); err != nil {
+			return &ValidationError{Name: "op", err: fmt.Errorf(`ent: validator failed for field "OutboxEvent.op": %w`, err)}
+		}
+	}
+	if _, ok := oec.mutation.Entity(); !ok {
+		return &ValidationError{Name: "entity", err: errors.New(`ent: missing required field "OutboxEvent.entity"`)}
+	}
+	if v, ok := oec.mutation.Entity(); ok {
+		if err := outboxevent.EntityValidator(v); err != nil {
+			return &ValidationError{Name: "entity", err: fmt.Errorf(`ent: validator failed for field "OutboxEvent.entity": %w`, err)}
+		}
+	}
+	if _, ok := oec.mutation.EntityID(); !ok {
+		return &ValidationError{Name: "entity_id", err: errors.New(`ent: missing required field "OutboxEvent.entity_id"`)}
+	}
+	if _, ok := oec.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "OutboxEvent.created_at"`)}
+	}
+	return nil
+}
+
+func (oec *OutboxEventCreate) sqlSave(ctx context.Context) (*OutboxEvent, error) {
+	_node, _spec := oec.createSpec()
+	if err := sqlgraph.CreateNode(ctx, oec.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	return _node, nil
+}
+
+func (oec *OutboxEventCreate) createSpec() (*OutboxEvent, *sqlgraph.CreateSpec) {
+	var (
+		_node = &OutboxEvent{config: oec.config}
+		_spec = &sqlgraph.CreateSpec{
+			Table: outboxevent.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: outboxevent.FieldID,
+			},
+		}
+	)
+	if value, ok := oec.mutation.GetOp(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeEnum,
+			Value:  value,
+			Column: outboxevent.FieldOp,
+		})
+		_node.Op = value
+	}
+	if value, ok := oec.mutation.Entity(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: outboxevent.FieldEntity,
+		})
+		_node.Entity = value
+	}
+	if value, ok := oec.mutation.EntityID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: outboxevent.FieldEntityID,
+		})
+		_node.EntityID = value
+	}
+	if value, ok := oec.mutation.GetFields(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: outboxevent.FieldFields,
+		})
+		_node.Fields = value
+	}
+	if value, ok := oec.mutation.Added(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: outboxevent.FieldAdded,
+		})
+		_node.Added = value
+	}
+	if value, ok := oec.mutation.Cleared(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: outboxevent.FieldCleared,
+		})
+		_node.Cleared = value
+	}
+	if value, ok := oec.mutation.Edges(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: outboxevent.FieldEdges,
+		})
+		_node.Edges = value
+	}
+	if value, ok := oec.mutation.CreatedAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: outboxevent.FieldCreatedAt,
+		})
+		_node.CreatedAt = value
+	}
+	if value, ok := oec.mutation.DeliveredAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: outboxevent.FieldDeliveredAt,
+		})
+		_node.DeliveredAt = &value
+	}
+	return _node, _spec
+}
+
+// OutboxEventCreateBulk is the builder for creating many OutboxEvent entities in bulk.
+type OutboxEventCreateBulk struct {
+	config
+	builders []*OutboxEventCreate
+}
+
+// Save creates the OutboxEvent entities in the database.
+func (oecb *OutboxEventCreateBulk) Save(ctx context.Context) ([]*OutboxEvent, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(oecb.builders))
+	nodes := make([]*OutboxEvent, len(oecb.builders))
+	mutators := make([]Mutator, len(oecb.builders))
+	for i := range oecb.builders {
+		func(i int, root context.Context) {
+			builder := oecb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*OutboxEventMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				nodes[i], specs[i] = builder.createSpec()
+				var err error
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, oecb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, oecb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, oecb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (oecb *OutboxEventCreateBulk) SaveX(ctx context.Context) []*OutboxEvent {
+	v, err := oecb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (oecb *OutboxEventCreateBulk) Exec(ctx context.Context) error {
+	_, err := oecb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (oecb *OutboxEventCreateBulk) ExecX(ctx context.Context) {
+	if err := oecb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,660 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/outboxevent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/predicate"
+)
+
+// OutboxEventUpdate is the builder for updating OutboxEvent entities.
+type OutboxEventUpdate struct {
+	config
+	hooks    []Hook
+	mutation *OutboxEventMutation
+}
+
+// Where appends a list predicates to the OutboxEventUpdate builder.
+func (oeu *OutboxEventUpdate) Where(ps ...predicate.OutboxEvent) *OutboxEventUpdate {
+	oeu.mutation.Where(ps...)
+	return oeu
+}
+
+// SetOp sets the "op" field.
+func (oeu *OutboxEventUpdate) SetOp(o outboxevent.Op) *OutboxEventUpdate {
+	oeu.mutation.SetOp(o)
+	return oeu
+}
+
+// SetEntity sets the "entity" field.
+func (oeu *OutboxEventUpdate) SetEntity(s string) *OutboxEventUpdate {
+	oeu.mutation.SetEntity(s)
+	return oeu
+}
+
+// SetEntityID sets the "entity_id" field.
+func (oeu *OutboxEventUpdate) SetEntityID(i int) *OutboxEventUpdate {
+	oeu.mutation.ResetEntityID()
+	oeu.mutation.SetEntityID(i)
+	return oeu
+}
+
+// AddEntityID adds i to the "entity_id" field.
+func (oeu *OutboxEventUpdate) AddEntityID(i int) *OutboxEventUpdate {
+	oeu.mutation.AddEntityID(i)
+	return oeu
+}
+
+// SetFields sets the "fields" field.
+func (oeu *OutboxEventUpdate) SetFields(m map[string]interface{}) *OutboxEventUpdate {
+	oeu.mutation.SetFields(m)
+	return oeu
+}
+
+// ClearFields clears the value of the "fields" field.
+func (oeu *OutboxEventUpdate) ClearFields() *OutboxEventUpdate {
+	oeu.mutation.ClearFields()
+	return oeu
+}
+
+// SetAdded sets the "added" field.
+func (oeu *OutboxEventUpdate) SetAdded(m map[string]interface{}) *OutboxEventUpdate {
+	oeu.mutation.SetAdded(m)
+	return oeu
+}
+
+// ClearAdded clears the value of the "added" field.
+func (oeu *OutboxEventUpdate) ClearAdded() *OutboxEventUpdate {
+	oeu.mutation.ClearAdded()
+	return oeu
+}
+
+// SetCleared sets the "cleared" field.
+func (oeu *OutboxEventUpdate) SetCleared(s []string) *OutboxEventUpdate {
+	oeu.mutation.SetCleared(s)
+	return oeu
+}
+
+// ClearCleared clears the value of the "cleared" field.
+func (oeu *OutboxEventUpdate) ClearCleared() *OutboxEventUpdate {
+	oeu.mutation.ClearCleared()
+	return oeu
+}
+
+// SetEdges sets the "edges" field.
+func (oeu *OutboxEventUpdate) SetEdges(s []string) *OutboxEventUpdate {
+	oeu.mutation.SetEdges(s)
+	return oeu
+}
+
+// ClearEdges clears the value of the "edges" field.
+func (oeu *OutboxEventUpdate) ClearEdges() *OutboxEventUpdate {
+	oeu.mutation.ClearEdges()
+	return oeu
+}
+
+// SetDeliveredAt sets the "delivered_at" field.
+func (oeu *OutboxEventUpdate) SetDeliveredAt(t time.Time) *OutboxEventUpdate {
+	oeu.mutation.SetDeliveredAt(t)
+	return oeu
+}
+
+// SetNillableDeliveredAt sets the "delivered_at" field if the given value is not nil.
+func (oeu *OutboxEventUpdate) SetNillableDeliveredAt(t *time.Time) *OutboxEventUpdate {
+	if t != nil {
+		oeu.SetDeliveredAt(*t)
+	}
+	return oeu
+}
+
+// ClearDeliveredAt clears the value of the "delivered_at" field.
+func (oeu *OutboxEventUpdate) ClearDeliveredAt() *OutboxEventUpdate {
+	oeu.mutation.ClearDeliveredAt()
+	return oeu
+}
+
+// Mutation returns the OutboxEventMutation object of the builder.
+func (oeu *OutboxEventUpdate) Mutation() *OutboxEventMutation {
+	return oeu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (oeu *OutboxEventUpdate) Save(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(oeu.hooks) == 0 {
+		if err = oeu.check(); err != nil {
+			return 0, err
+		}
+		affected, err = oeu.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*OutboxEventMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = oeu.check(); err != nil {
+				return 0, err
+			}
+			oeu.mutation = mutation
+			affected, err = oeu.sqlSave(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(oeu.hooks) - 1; i >= 0; i-- {
+			if oeu.hooks[i] == nil {
+				return 0, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = oeu.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, oeu.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (oeu *OutboxEventUpdate) SaveX(ctx context.Context) int {
+	affected, err := oeu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (oeu *OutboxEventUpdate) Exec(ctx context.Context) error {
+	_, err := oeu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (oeu *OutboxEventUpdate) ExecX(ctx context.Context) {
+	if err := oeu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (oeu *OutboxEventUpdate) check() error {
+	if v, ok := oeu.mutation.GetOp(); ok {
+		if err := outboxevent.OpValidator(v); err != nil {
+			return &ValidationError{Name: "op", err: fmt.Errorf(`ent: validator failed for field "OutboxEvent.op": %w`, err)}
+		}
+	}
+	if v, ok := oeu.mutation.Entity(); ok {
+		if err := outboxevent.EntityValidator(v); err != nil {
+			return &ValidationError{Name: "entity", err: fmt.Errorf(`ent: validator failed for field "OutboxEvent.entity": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (oeu *OutboxEventUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   outboxevent.Table,
+			Columns: outboxevent.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: outboxevent.FieldID,
+			},
+		},
+	}
+	if ps := oeu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := oeu.mutation.GetOp(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeEnum,
+			Value:  value,
+			Column: outboxevent.FieldOp,
+		})
+	}
+	if value, ok := oeu.mutation.Entity(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: outboxevent.FieldEntity,
+		})
+	}
+	if value, ok := oeu.mutation.EntityID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: outboxevent.FieldEntityID,
+		})
+	}
+	if value, ok := oeu.mutation.AddedEntityID(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: outboxevent.FieldEntityID,
+		})
+	}
+	if value, ok := oeu.mutation.GetFields(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: outboxevent.FieldFields,
+		})
+	}
+	if oeu.mutation.FieldsCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: outboxevent.FieldFields,
+		})
+	}
+	if value, ok := oeu.mutation.Added(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: outboxevent.FieldAdded,
+		})
+	}
+	if oeu.mutation.AddedCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: outboxevent.FieldAdded,
+		})
+	}
+	if value, ok := oeu.mutation.Cleared(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: outboxevent.FieldCleared,
+		})
+	}
+	if oeu.mutation.ClearedCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: outboxevent.FieldCleared,
+		})
+	}
+	if value, ok := oeu.mutation.Edges(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: outboxevent.FieldEdges,
+		})
+	}
+	if oeu.mutation.EdgesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: outboxevent.FieldEdges,
+		})
+	}
+	if value, ok := oeu.mutation.DeliveredAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: outboxevent.FieldDeliveredAt,
+		})
+	}
+	if oeu.mutation.DeliveredAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: outboxevent.FieldDeliveredAt,
+		})
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, oeu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{outboxevent.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// OutboxEventUpdateOne is the builder for updating a single OutboxEvent entity.
+type OutboxEventUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *OutboxEventMutation
+}
+
+// SetOp sets the "op" field.
+func (oeuo *OutboxEventUpdateOne) SetOp(o outboxevent.Op) *OutboxEventUpdateOne {
+	oeuo.mutation.SetOp(o)
+	return oeuo
+}
+
+// SetEntity sets the "entity" field.
+func (oeuo *OutboxEventUpdateOne) SetEntity(s string) *OutboxEventUpdateOne {
+	oeuo.mutation.SetEntity(s)
+	return oeuo
+}
+
+// SetEntityID sets the "entity_id" field.
+func (oeuo *OutboxEventUpdateOne) SetEntityID(i int) *OutboxEventUpdateOne {
+	oeuo.mutation.ResetEntityID()
+	oeuo.mutation.SetEntityID(i)
+	return oeuo
+}
+
+// AddEntityID adds i to the "entity_id" field.
+func (oeuo *OutboxEventUpdateOne) AddEntityID(i int) *OutboxEventUpdateOne {
+	oeuo.mutation.AddEntityID(i)
+	return oeuo
+}
+
+// SetFields sets the "fields" field.
+func (oeuo *OutboxEventUpdateOne) SetFields(m map[string]interface{}) *OutboxEventUpdateOne {
+	oeuo.mutation.SetFields(m)
+	return oeuo
+}
+
+// ClearFields clears the value of the "fields" field.
+func (oeuo *OutboxEventUpdateOne) ClearFields() *OutboxEventUpdateOne {
+	oeuo.mutation.ClearFields()
+	return oeuo
+}
+
+// SetAdded sets the "added" field.
+func (oeuo *OutboxEventUpdateOne) SetAdded(m map[string]interface{}) *OutboxEventUpdateOne {
+	oeuo.mutation.SetAdded(m)
+	return oeuo
+}
+
+// ClearAdded clears the value of the "added" field.
+func (oeuo *OutboxEventUpdateOne) ClearAdded() *OutboxEventUpdateOne {
+	oeuo.mutation.ClearAdded()
+	return oeuo
+}
+
+// SetCleared sets the "cleared" field.
+func (oeuo *OutboxEventUpdateOne) SetCleared(s []string) *OutboxEventUpdateOne {
+	oeuo.mutation.SetCleared(s)
+	return oeuo
+}
+
+// ClearCleared clears the value of the "cleared" field.
+func (oeuo *OutboxEventUpdateOne) ClearCleared() *OutboxEventUpdateOne {
+	oeuo.mutation.ClearCleared()
+	return oeuo
+}
+
+// SetEdges sets the "edges" field.
+func (oeuo *OutboxEventUpdateOne) SetEdges(s []string) *OutboxEventUpdateOne {
+	oeuo.mutation.SetEdges(s)
+	return oeuo
+}
+
+// ClearEdges clears the value of the "edges" field.
+func (oeuo *OutboxEventUpdateOne) ClearEdges() *OutboxEventUpdateOne {
+	oeuo.mutation.ClearEdges()
+	return oeuo
+}
+
+// SetDeliveredAt sets the "delivered_at" field.
+func (oeuo *OutboxEventUpdateOne) SetDeliveredAt(t time.Time) *OutboxEventUpdateOne {
+	oeuo.mutation.SetDeliveredAt(t)
+	return oeuo
+}
+
+// SetNillableDeliveredAt sets the "delivered_at" field if the given value is not nil.
+func (oeuo *OutboxEventUpdateOne) SetNillableDeliveredAt(t *time.Time) *OutboxEventUpdateOne {
+	if t != nil {
+		oeuo.SetDeliveredAt(*t)
+	}
+	return oeuo
+}
+
+// ClearDeliveredAt clears the value of the "delivered_at" field.
+func (oeuo *OutboxEventUpdateOne) ClearDeliveredAt() *OutboxEventUpdateOne {
+	oeuo.mutation.ClearDeliveredAt()
+	return oeuo
+}
+
+// Mutation returns the OutboxEventMutation object of the builder.
+func (oeuo *OutboxEventUpdateOne) Mutation() *OutboxEventMutation {
+	return oeuo.mutation
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (oeuo *OutboxEventUpdateOne) Select(field string, fields ...string) *OutboxEventUpdateOne {
+	oeuo.fields = append([]string{field}, fields...)
+	return oeuo
+}
+
+// Save executes the query and returns the updated OutboxEvent entity.
+func (oeuo *OutboxEventUpdateOne) Save(ctx context.Context) (*OutboxEvent, error) {
+	var (
+		err  error
+		node *OutboxEvent
+	)
+	if len(oeuo.hooks) == 0 {
+		if err = oeuo.check(); err != nil {
+			return nil, err
+		}
+		node, err = oeuo.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*OutboxEventMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = oeuo.check(); err != nil {
+				return nil, err
+			}
+			oeuo.mutation = mutation
+			node, err = oeuo.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(oeuo.hooks) - 1; i >= 0; i-- {
+			if oeuo.hooks[i] == nil {
+				return nil, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = oeuo.hooks[i](mut)
+		}
+		v, err := mut.Mutate(ctx, oeuo.mutation)
+		if err != nil {
+			return nil, err
+		}
+		nv, ok := v.(*OutboxEvent)
+		if !ok {
+			return nil, fmt.Errorf("unexpected node type %T returned from OutboxEventMutation", v)
+		}
+		node = nv
+	}
+	return node, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (oeuo *OutboxEventUpdateOne) SaveX(ctx context.Context) *OutboxEvent {
+	node, err := oeuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (oeuo *OutboxEventUpdateOne) Exec(ctx context.Context) error {
+	_, err := oeuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (oeuo *OutboxEventUpdateOne) ExecX(ctx context.Context) {
+	if err := oeuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (oeuo *OutboxEventUpdateOne) check() error {
+	if v, ok := oeuo.mutation.GetOp(); ok {
+		if err := outboxevent.OpValidator(v); err != nil {
+			return &ValidationError{Name: "op", err: fmt.Errorf(`ent: validator failed for field "OutboxEvent.op": %w`, err)}
+		}
+	}
+	if v, ok := oeuo.mutation.Entity(); ok {
+		if err := outboxevent.EntityValidator(v); err != nil {
+			return &ValidationError{Name: "entity", err: fmt.Errorf(`ent: validator failed for field "OutboxEvent.entity": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (oeuo *OutboxEventUpdateOne) sqlSave(ctx context.Context) (_node *OutboxEvent, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   outboxevent.Table,
+			Columns: outboxevent.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: outboxevent.FieldID,
+			},
+		},
+	}
+	id, ok := oeuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "OutboxEvent.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := oeuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, outboxevent.FieldID)
+		for _, f := range fields {
+			if !outboxevent.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != outboxevent.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := oeuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := oeuo.mutation.GetOp(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeEnum,
+			Value:  value,
+			Column: outboxevent.FieldOp,
+		})
+	}
+	if value, ok := oeuo.mutation.Entity(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: outboxevent.FieldEntity,
+		})
+	}
+	if value, ok := oeuo.mutation.EntityID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: outboxevent.FieldEntityID,
+		})
+	}
+	if value, ok := oeuo.mutation.AddedEntityID(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: outboxevent.FieldEntityID,
+		})
+	}
+	if value, ok := oeuo.mutation.GetFields(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: outboxevent.FieldFields,
+		})
+	}
+	if oeuo.mutation.FieldsCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: outboxevent.FieldFields,
+		})
+	}
+	if value, ok := oeuo.mutation.Added(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: outboxevent.FieldAdded,
+		})
+	}
+	if oeuo.mutation.AddedCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: outboxevent.FieldAdded,
+		})
+	}
+	if value, ok := oeuo.mutation.Cleared(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: outboxevent.FieldCleared,
+		})
+	}
+	if oeuo.mutation.ClearedCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: outboxevent.FieldCleared,
+		})
+	}
+	if value, ok := oeuo.mutation.Edges(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: outboxevent.FieldEdges,
+		})
+	}
+	if oeuo.mutation.EdgesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: outboxevent.FieldEdges,
+		})
+	}
+	if value, ok := oeuo.mutation.DeliveredAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: outboxevent.FieldDeliveredAt,
+		})
+	}
+	if oeuo.mutation.DeliveredAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: outboxevent.FieldDeliveredAt,
+		})
+	}
+	_node = &OutboxEvent{config: oeuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, oeuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{outboxevent.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	return _node, nil
+}
@@ -0,0 +1,212 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/registration"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+)
+
+// Registration is the model entity for the Registration schema.
+type Registration struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Plate holds the value of the "plate" field.
+	Plate string `json:"plate,omitempty"`
+	// Region holds the value of the "region" field.
+	Region string `json:"region,omitempty"`
+	// ValidFrom holds the value of the "valid_from" field.
+	ValidFrom time.Time `json:"valid_from,omitempty"`
+	// ValidTo holds the value of the "valid_to" field.
+	ValidTo *time.Time `json:"valid_to,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the RegistrationQuery when eager-loading is set.
+	Edges              RegistrationEdges `json:"edges"`
+	car_registrations  *int
+	user_registrations *int
+}
+
+// RegistrationEdges holds the relations/edges for other nodes in the graph.
+type RegistrationEdges struct {
+	// Car holds the value of the car edge.
+	Car *Car `json:"car,omitempty"`
+	// Owner holds the value of the owner edge.
+	Owner *User `json:"owner,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// CarOrErr returns the Car value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e RegistrationEdges) CarOrErr() (*Car, error) {
+	if e.loadedTypes[0] {
+		if e.Car == nil {
+			// The edge car was loaded in eager-loading,
+			// but was not found.
+			return nil, &NotFoundError{label: car.Label}
+		}
+		return e.Car, nil
+	}
+	return nil, &NotLoadedError{edge: "car"}
+}
+
+// OwnerOrErr returns the Owner value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e RegistrationEdges) OwnerOrErr() (*User, error) {
+	if e.loadedTypes[1] {
+		if e.Owner == nil {
+			// The edge owner was loaded in eager-loading,
+			// but was not found.
+			return nil, &NotFoundError{label: user.Label}
+		}
+		return e.Owner, nil
+	}
+	return nil, &NotLoadedError{edge: "owner"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Registration) scanValues(columns []string) ([]interface{}, error) {
+	values := make([]interface{}, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case registration.FieldID:
+			values[i] = new(sql.NullInt64)
+		case registration.FieldPlate, registration.FieldRegion:
+			values[i] = new(sql.NullString)
+		case registration.FieldValidFrom, registration.FieldValidTo:
+			values[i] = new(sql.NullTime)
+		case registration.ForeignKeys[0]: // car_registrations
+			values[i] = new(sql.NullInt64)
+		case registration.ForeignKeys[1]: // user_registrations
+			values[i] = new(sql.NullInt64)
+		default:
+			return nil, fmt.Errorf("unexpected column %q for type Registration", columns[i])
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Registration fields.
+func (r *Registration) assignValues(columns []string, values []interface{}) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case registration.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			r.ID = int(value.Int64)
+		case registration.FieldPlate:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field plate", values[i])
+			} else if value.Valid {
+				r.Plate = value.String
+			}
+		case registration.FieldRegion:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field region", values[i])
+			} else if value.Valid {
+				r.Region = value.String
+			}
+		case registration.FieldValidFrom:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field valid_from", values[i])
+			} else if value.Valid {
+				r.ValidFrom = value.Time
+			}
+		case registration.FieldValidTo:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field valid_to", values[i])
+			} else if value.Valid {
+				r.ValidTo = new(time.Time)
+				*r.ValidTo = value.Time
+			}
+		case registration.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field car_registrations", value)
+			} else if value.Valid {
+				r.car_registrations = new(int)
+				*r.car_registrations = int(value.Int64)
+			}
+		case registration.ForeignKeys[1]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field user_registrations", value)
+			} else if value.Valid {
+				r.user_registrations = new(int)
+				*r.user_registrations = int(value.Int64)
+			}
+		}
+	}
+	return nil
+}
+
+// QueryCar queries the "car" edge of the Registration entity.
+func (r *Registration) QueryCar() *CarQuery {
+	return (&RegistrationClient{config: r.config}).QueryCar(r)
+}
+
+// QueryOwner queries the "owner" edge of the Registration entity.
+func (r *Registration) QueryOwner() *UserQuery {
+	return (&RegistrationClient{config: r.config}).QueryOwner(r)
+}
+
+// Update returns a builder for updating this Registration.
+// Note that you need to call Registration.Unwrap() before calling this method if this Registration
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (r *Registration) Update() *RegistrationUpdateOne {
+	return (&RegistrationClient{config: r.config}).UpdateOne(r)
+}
+
+// Unwrap unwraps the Registration entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (r *Registration) Unwrap() *Registration {
+	_tx, ok := r.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Registration is not a transactional entity")
+	}
+	r.config.driver = _tx.drv
+	return r
+}
+
+// String implements the fmt.Stringer.
+func (r *Registration) String() string {
+	var builder strings.Builder
+	builder.WriteString("Registration(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", r.ID))
+	builder.WriteString("plate=")
+	builder.WriteString(r.Plate)
+	builder.WriteString(", ")
+	builder.WriteString("region=")
+	builder.WriteString(r.Region)
+	builder.WriteString(", ")
+	builder.WriteString("valid_from=")
+	builder.WriteString(r.ValidFrom.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := r.ValidTo; v != nil {
+		builder.WriteString("valid_to=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Registrations is a parsable slice of Registration.
+type Registrations []*Registration
+
+func (r Registrations) config(cfg config) {
+	for _i := range r {
+		r[_i].config = cfg
+	}
+}
@@ -0,0 +1,91 @@
+// Code generated by ent, DO NOT EDIT.
+
+package registration
+
+import (
+	"time"
+
+	"entgo.io/ent"
+)
+
+const (
+	// Label holds the string label denoting the registration type in the database.
+	Label = "registration"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldPlate holds the string denoting the plate field in the database.
+	FieldPlate = "plate"
+	// FieldRegion holds the string denoting the region field in the database.
+	FieldRegion = "region"
+	// FieldValidFrom holds the string denoting the valid_from field in the database.
+	FieldValidFrom = "valid_from"
+	// FieldValidTo holds the string denoting the valid_to field in the database.
+	FieldValidTo = "valid_to"
+	// EdgeCar holds the string denoting the car edge name in mutations.
+	EdgeCar = "car"
+	// EdgeOwner holds the string denoting the owner edge name in mutations.
+	EdgeOwner = "owner"
+	// Table holds the table name of the registration in the database.
+	Table = "registrations"
+	// CarTable is the table that holds the car relation/edge.
+	CarTable = "registrations"
+	// CarInverseTable is the table name for the Car entity.
+	// It exists in this package in order to avoid circular dependency with the "car" package.
+	CarInverseTable = "cars"
+	// CarColumn is the table column denoting the car relation/edge.
+	CarColumn = "car_registrations"
+	// OwnerTable is the table that holds the owner relation/edge.
+	OwnerTable = "registrations"
+	// OwnerInverseTable is the table name for the User entity.
+	// It exists in this package in order to avoid circular dependency with the "user" package.
+	OwnerInverseTable = "users"
+	// OwnerColumn is the table column denoting the owner relation/edge.
+	OwnerColumn = "user_registrations"
+)
+
+// Columns holds all SQL columns for registration fields.
+var Columns = []string{
+	FieldID,
+	FieldPlate,
+	FieldRegion,
+	FieldValidFrom,
+	FieldValidTo,
+}
+
+// ForeignKeys holds the SQL foreign-keys that are owned by the "registrations"
+// table and are not defined as standalone fields in the schema.
+var ForeignKeys = []string{
+	"car_registrations",
+	"user_registrations",
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	for i := range ForeignKeys {
+		if column == ForeignKeys[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// Note that the variables below are initialized by the runtime
+// package on the initialization of the application. Therefore,
+// it should be imported in the main as follows:
+//
+//	import _ "github.com/anjanashankar9/go-learning/go-orm/ent/runtime"
+var (
+	Hooks  [3]ent.Hook
+	Policy ent.Policy
+	// PlateValidator is a validator for the "plate" field. It is called by the builders before save.
+	PlateValidator func(string) error
+	// RegionValidator is a validator for the "region" field. It is called by the builders before save.
+	RegionValidator func(string) error
+	// DefaultValidFrom holds the default value on creation for the "valid_from" field.
+	DefaultValidFrom func() time.Time
+)
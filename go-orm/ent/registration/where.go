@@ -0,0 +1,586 @@
+// Code generated by ent, DO NOT EDIT.
+
+package registration
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldID), id))
+	})
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.In(s.C(FieldID), v...))
+	})
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.NotIn(s.C(FieldID), v...))
+	})
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldID), id))
+	})
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldID), id))
+	})
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldID), id))
+	})
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldID), id))
+	})
+}
+
+// Plate applies equality check predicate on the "plate" field. It's identical to PlateEQ.
+func Plate(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldPlate), v))
+	})
+}
+
+// Region applies equality check predicate on the "region" field. It's identical to RegionEQ.
+func Region(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldRegion), v))
+	})
+}
+
+// ValidFrom applies equality check predicate on the "valid_from" field. It's identical to ValidFromEQ.
+func ValidFrom(v time.Time) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldValidFrom), v))
+	})
+}
+
+// ValidTo applies equality check predicate on the "valid_to" field. It's identical to ValidToEQ.
+func ValidTo(v time.Time) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldValidTo), v))
+	})
+}
+
+// PlateEQ applies the EQ predicate on the "plate" field.
+func PlateEQ(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldPlate), v))
+	})
+}
+
+// PlateNEQ applies the NEQ predicate on the "plate" field.
+func PlateNEQ(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldPlate), v))
+	})
+}
+
+// PlateIn applies the In predicate on the "plate" field.
+func PlateIn(vs ...string) predicate.Registration {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Registration(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldPlate), v...))
+	})
+}
+
+// PlateNotIn applies the NotIn predicate on the "plate" field.
+func PlateNotIn(vs ...string) predicate.Registration {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Registration(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldPlate), v...))
+	})
+}
+
+// PlateGT applies the GT predicate on the "plate" field.
+func PlateGT(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldPlate), v))
+	})
+}
+
+// PlateGTE applies the GTE predicate on the "plate" field.
+func PlateGTE(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldPlate), v))
+	})
+}
+
+// PlateLT applies the LT predicate on the "plate" field.
+func PlateLT(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldPlate), v))
+	})
+}
+
+// PlateLTE applies the LTE predicate on the "plate" field.
+func PlateLTE(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldPlate), v))
+	})
+}
+
+// PlateContains applies the Contains predicate on the "plate" field.
+func PlateContains(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldPlate), v))
+	})
+}
+
+// PlateHasPrefix applies the HasPrefix predicate on the "plate" field.
+func PlateHasPrefix(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldPlate), v))
+	})
+}
+
+// PlateHasSuffix applies the HasSuffix predicate on the "plate" field.
+func PlateHasSuffix(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldPlate), v))
+	})
+}
+
+// PlateEqualFold applies the EqualFold predicate on the "plate" field.
+func PlateEqualFold(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldPlate), v))
+	})
+}
+
+// PlateContainsFold applies the ContainsFold predicate on the "plate" field.
+func PlateContainsFold(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldPlate), v))
+	})
+}
+
+// RegionEQ applies the EQ predicate on the "region" field.
+func RegionEQ(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldRegion), v))
+	})
+}
+
+// RegionNEQ applies the NEQ predicate on the "region" field.
+func RegionNEQ(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldRegion), v))
+	})
+}
+
+// RegionIn applies the In predicate on the "region" field.
+func RegionIn(vs ...string) predicate.Registration {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Registration(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldRegion), v...))
+	})
+}
+
+// RegionNotIn applies the NotIn predicate on the "region" field.
+func RegionNotIn(vs ...string) predicate.Registration {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Registration(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldRegion), v...))
+	})
+}
+
+// RegionGT applies the GT predicate on the "region" field.
+func RegionGT(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldRegion), v))
+	})
+}
+
+// RegionGTE applies the GTE predicate on the "region" field.
+func RegionGTE(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldRegion), v))
+	})
+}
+
+// RegionLT applies the LT predicate on the "region" field.
+func RegionLT(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldRegion), v))
+	})
+}
+
+// RegionLTE applies the LTE predicate on the "region" field.
+func RegionLTE(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldRegion), v))
+	})
+}
+
+// RegionContains applies the Contains predicate on the "region" field.
+func RegionContains(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldRegion), v))
+	})
+}
+
+// RegionHasPrefix applies the HasPrefix predicate on the "region" field.
+func RegionHasPrefix(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldRegion), v))
+	})
+}
+
+// RegionHasSuffix applies the HasSuffix predicate on the "region" field.
+func RegionHasSuffix(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldRegion), v))
+	})
+}
+
+// RegionEqualFold applies the EqualFold predicate on the "region" field.
+func RegionEqualFold(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldRegion), v))
+	})
+}
+
+// RegionContainsFold applies the ContainsFold predicate on the "region" field.
+func RegionContainsFold(v string) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldRegion), v))
+	})
+}
+
+// ValidFromEQ applies the EQ predicate on the "valid_from" field.
+func ValidFromEQ(v time.Time) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldValidFrom), v))
+	})
+}
+
+// ValidFromNEQ applies the NEQ predicate on the "valid_from" field.
+func ValidFromNEQ(v time.Time) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldValidFrom), v))
+	})
+}
+
+// ValidFromIn applies the In predicate on the "valid_from" field.
+func ValidFromIn(vs ...time.Time) predicate.Registration {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Registration(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldValidFrom), v...))
+	})
+}
+
+// ValidFromNotIn applies the NotIn predicate on the "valid_from" field.
+func ValidFromNotIn(vs ...time.Time) predicate.Registration {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Registration(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldValidFrom), v...))
+	})
+}
+
+// ValidFromGT applies the GT predicate on the "valid_from" field.
+func ValidFromGT(v time.Time) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldValidFrom), v))
+	})
+}
+
+// ValidFromGTE applies the GTE predicate on the "valid_from" field.
+func ValidFromGTE(v time.Time) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldValidFrom), v))
+	})
+}
+
+// ValidFromLT applies the LT predicate on the "valid_from" field.
+func ValidFromLT(v time.Time) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldValidFrom), v))
+	})
+}
+
+// ValidFromLTE applies the LTE predicate on the "valid_from" field.
+func ValidFromLTE(v time.Time) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldValidFrom), v))
+	})
+}
+
+// ValidToEQ applies the EQ predicate on the "valid_to" field.
+func ValidToEQ(v time.Time) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldValidTo), v))
+	})
+}
+
+// ValidToNEQ applies the NEQ predicate on the "valid_to" field.
+func ValidToNEQ(v time.Time) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldValidTo), v))
+	})
+}
+
+// ValidToIn applies the In predicate on the "valid_to" field.
+func ValidToIn(vs ...time.Time) predicate.Registration {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Registration(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldValidTo), v...))
+	})
+}
+
+// ValidToNotIn applies the NotIn predicate on the "valid_to" field.
+func ValidToNotIn(vs ...time.Time) predicate.Registration {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Registration(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldValidTo), v...))
+	})
+}
+
+// ValidToGT applies the GT predicate on the "valid_to" field.
+func ValidToGT(v time.Time) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldValidTo), v))
+	})
+}
+
+// ValidToGTE applies the GTE predicate on the "valid_to" field.
+func ValidToGTE(v time.Time) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldValidTo), v))
+	})
+}
+
+// ValidToLT applies the LT predicate on the "valid_to" field.
+func ValidToLT(v time.Time) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldValidTo), v))
+	})
+}
+
+// ValidToLTE applies the LTE predicate on the "valid_to" field.
+func ValidToLTE(v time.Time) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldValidTo), v))
+	})
+}
+
+// ValidToIsNil applies the IsNil predicate on the "valid_to" field.
+func ValidToIsNil() predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldValidTo)))
+	})
+}
+
+// ValidToNotNil applies the NotNil predicate on the "valid_to" field.
+func ValidToNotNil() predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldValidTo)))
+	})
+}
+
+// HasCar applies the HasEdge predicate on the "car" edge.
+func HasCar() predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.To(CarTable, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, CarTable, CarColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasCarWith applies the HasEdge predicate on the "car" edge with a given conditions (other predicates).
+func HasCarWith(preds ...predicate.Car) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.To(CarInverseTable, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, CarTable, CarColumn),
+		)
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasOwner applies the HasEdge predicate on the "owner" edge.
+func HasOwner() predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.To(OwnerTable, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, OwnerTable, OwnerColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasOwnerWith applies the HasEdge predicate on the "owner" edge with a given conditions (other predicates).
+func HasOwnerWith(preds ...predicate.User) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.To(OwnerInverseTable, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, OwnerTable, OwnerColumn),
+		)
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.Registration) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for _, p := range predicates {
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.Registration) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for i, p := range predicates {
+			if i > 0 {
+				s1.Or()
+			}
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.Registration) predicate.Registration {
+	return predicate.Registration(func(s *sql.Selector) {
+		p(s.Not())
+	})
+}
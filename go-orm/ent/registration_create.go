@@ -0,0 +1,387 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/registration"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+)
+
+// RegistrationCreate is the builder for creating a Registration entity.
+type RegistrationCreate struct {
+	config
+	mutation *RegistrationMutation
+	hooks    []Hook
+}
+
+// SetPlate sets the "plate" field.
+func (rc *RegistrationCreate) SetPlate(s string) *RegistrationCreate {
+	rc.mutation.SetPlate(s)
+	return rc
+}
+
+// SetRegion sets the "region" field.
+func (rc *RegistrationCreate) SetRegion(s string) *RegistrationCreate {
+	rc.mutation.SetRegion(s)
+	return rc
+}
+
+// SetValidFrom sets the "valid_from" field.
+func (rc *RegistrationCreate) SetValidFrom(t time.Time) *RegistrationCreate {
+	rc.mutation.SetValidFrom(t)
+	return rc
+}
+
+// SetNillableValidFrom sets the "valid_from" field if the given value is not nil.
+func (rc *RegistrationCreate) SetNillableValidFrom(t *time.Time) *RegistrationCreate {
+	if t != nil {
+		rc.SetValidFrom(*t)
+	}
+	return rc
+}
+
+// SetValidTo sets the "valid_to" field.
+func (rc *RegistrationCreate) SetValidTo(t time.Time) *RegistrationCreate {
+	rc.mutation.SetValidTo(t)
+	return rc
+}
+
+// SetNillableValidTo sets the "valid_to" field if the given value is not nil.
+func (rc *RegistrationCreate) SetNillableValidTo(t *time.Time) *RegistrationCreate {
+	if t != nil {
+		rc.SetValidTo(*t)
+	}
+	return rc
+}
+
+// SetCarID sets the "car" edge to the Car entity by ID.
+func (rc *RegistrationCreate) SetCarID(id int) *RegistrationCreate {
+	rc.mutation.SetCarID(id)
+	return rc
+}
+
+// SetCar sets the "car" edge to the Car entity.
+func (rc *RegistrationCreate) SetCar(c *Car) *RegistrationCreate {
+	return rc.SetCarID(c.ID)
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (rc *RegistrationCreate) SetOwnerID(id int) *RegistrationCreate {
+	rc.mutation.SetOwnerID(id)
+	return rc
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (rc *RegistrationCreate) SetOwner(u *User) *RegistrationCreate {
+	return rc.SetOwnerID(u.ID)
+}
+
+// Mutation returns the RegistrationMutation object of the builder.
+func (rc *RegistrationCreate) Mutation() *RegistrationMutation {
+	return rc.mutation
+}
+
+// Save creates the Registration in the database.
+func (rc *RegistrationCreate) Save(ctx context.Context) (*Registration, error) {
+	var (
+		err  error
+		node *Registration
+	)
+	if err := rc.defaults(); err != nil {
+		return nil, err
+	}
+	if len(rc.hooks) == 0 {
+		if err = rc.check(); err != nil {
+			return nil, err
+		}
+		node, err = rc.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*RegistrationMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = rc.check(); err != nil {
+				return nil, err
+			}
+			rc.mutation = mutation
+			if node, err = rc.sqlSave(ctx); err != nil {
+				return nil, err
+			}
+			mutation.id = &node.ID
+			mutation.done = true
+			return node, err
+		})
+		for i := len(rc.hooks) - 1; i >= 0; i-- {
+			if rc.hooks[i] == nil {
+				return nil, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = rc.hooks[i](mut)
+		}
+		v, err := mut.Mutate(ctx, rc.mutation)
+		if err != nil {
+			return nil, err
+		}
+		nv, ok := v.(*Registration)
+		if !ok {
+			return nil, fmt.Errorf("unexpected node type %T returned from RegistrationMutation", v)
+		}
+		node = nv
+	}
+	return node, err
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (rc *RegistrationCreate) SaveX(ctx context.Context) *Registration {
+	v, err := rc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (rc *RegistrationCreate) Exec(ctx context.Context) error {
+	_, err := rc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rc *RegistrationCreate) ExecX(ctx context.Context) {
+	if err := rc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (rc *RegistrationCreate) defaults() error {
+	if _, ok := rc.mutation.ValidFrom(); !ok {
+		if registration.DefaultValidFrom == nil {
+			return fmt.Errorf("ent: uninitialized registration.DefaultValidFrom (forgotten import ent/runtime?)")
+		}
+		v := registration.DefaultValidFrom()
+		rc.mutation.SetValidFrom(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (rc *RegistrationCreate) check() error {
+	if _, ok := rc.mutation.Plate(); !ok {
+		return &ValidationError{Name: "plate", err: errors.New(`ent: missing required field "Registration.plate"`)}
+	}
+	if v, ok := rc.mutation.Plate(); ok {
+		if err := registration.PlateValidator(v); err != nil {
+			return &ValidationError{Name: "plate", err: fmt.Errorf(`ent: validator failed for field "Registration.plate": %w`, err)}
+		}
+	}
+	if _, ok := rc.mutation.Region(); !ok {
+		return &ValidationError{Name: "region", err: errors.New(`ent: missing required field "Registration.region"`)}
+	}
+	if v, ok := rc.mutation.Region(); ok {
+		if err := registration.RegionValidator(v); err != nil {
+			return &ValidationError{Name: "region", err: fmt.Errorf(`ent: validator failed for field "Registration.region": %w`, err)}
+		}
+	}
+	if _, ok := rc.mutation.ValidFrom(); !ok {
+		return &ValidationError{Name: "valid_from", err: errors.New(`ent: missing required field "Registration.valid_from"`)}
+	}
+	if _, ok := rc.mutation.CarID(); !ok {
+		return &ValidationError{Name: "car", err: errors.New(`ent: missing required edge "Registration.car"`)}
+	}
+	if _, ok := rc.mutation.OwnerID(); !ok {
+		return &ValidationError{Name: "owner", err: errors.New(`ent: missing required edge "Registration.owner"`)}
+	}
+	return nil
+}
+
+func (rc *RegistrationCreate) sqlSave(ctx context.Context) (*Registration, error) {
+	_node, _spec := rc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, rc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	return _node, nil
+}
+
+func (rc *RegistrationCreate) createSpec() (*Registration, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Registration{config: rc.config}
+		_spec = &sqlgraph.CreateSpec{
+			Table: registration.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: registration.FieldID,
+			},
+		}
+	)
+	if value, ok := rc.mutation.Plate(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: registration.FieldPlate,
+		})
+		_node.Plate = value
+	}
+	if value, ok := rc.mutation.Region(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: registration.FieldRegion,
+		})
+		_node.Region = value
+	}
+	if value, ok := rc.mutation.ValidFrom(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: registration.FieldValidFrom,
+		})
+		_node.ValidFrom = value
+	}
+	if value, ok := rc.mutation.ValidTo(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: registration.FieldValidTo,
+		})
+		_node.ValidTo = &value
+	}
+	if nodes := rc.mutation.CarIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   registration.CarTable,
+			Columns: []string{registration.CarColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: car.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.car_registrations = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := rc.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   registration.OwnerTable,
+			Columns: []string{registration.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: user.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.user_registrations = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// RegistrationCreateBulk is the builder for creating many Registration entities in bulk.
+type RegistrationCreateBulk struct {
+	config
+	builders []*RegistrationCreate
+}
+
+// Save creates the Registration entities in the database.
+func (rcb *RegistrationCreateBulk) Save(ctx context.Context) ([]*Registration, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(rcb.builders))
+	nodes := make([]*Registration, len(rcb.builders))
+	mutators := make([]Mutator, len(rcb.builders))
+	for i := range rcb.builders {
+		func(i int, root context.Context) {
+			builder := rcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*RegistrationMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				nodes[i], specs[i] = builder.createSpec()
+				var err error
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, rcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, rcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, rcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (rcb *RegistrationCreateBulk) SaveX(ctx context.Context) []*Registration {
+	v, err := rcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (rcb *RegistrationCreateBulk) Exec(ctx context.Context) error {
+	_, err := rcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rcb *RegistrationCreateBulk) ExecX(ctx context.Context) {
+	if err := rcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
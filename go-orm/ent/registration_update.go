@@ -0,0 +1,670 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/predicate"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/registration"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+)
+
+// RegistrationUpdate is the builder for updating Registration entities.
+type RegistrationUpdate struct {
+	config
+	hooks    []Hook
+	mutation *RegistrationMutation
+}
+
+// Where appends a list predicates to the RegistrationUpdate builder.
+func (ru *RegistrationUpdate) Where(ps ...predicate.Registration) *RegistrationUpdate {
+	ru.mutation.Where(ps...)
+	return ru
+}
+
+// SetPlate sets the "plate" field.
+func (ru *RegistrationUpdate) SetPlate(s string) *RegistrationUpdate {
+	ru.mutation.SetPlate(s)
+	return ru
+}
+
+// SetRegion sets the "region" field.
+func (ru *RegistrationUpdate) SetRegion(s string) *RegistrationUpdate {
+	ru.mutation.SetRegion(s)
+	return ru
+}
+
+// SetValidFrom sets the "valid_from" field.
+func (ru *RegistrationUpdate) SetValidFrom(t time.Time) *RegistrationUpdate {
+	ru.mutation.SetValidFrom(t)
+	return ru
+}
+
+// SetNillableValidFrom sets the "valid_from" field if the given value is not nil.
+func (ru *RegistrationUpdate) SetNillableValidFrom(t *time.Time) *RegistrationUpdate {
+	if t != nil {
+		ru.SetValidFrom(*t)
+	}
+	return ru
+}
+
+// SetValidTo sets the "valid_to" field.
+func (ru *RegistrationUpdate) SetValidTo(t time.Time) *RegistrationUpdate {
+	ru.mutation.SetValidTo(t)
+	return ru
+}
+
+// SetNillableValidTo sets the "valid_to" field if the given value is not nil.
+func (ru *RegistrationUpdate) SetNillableValidTo(t *time.Time) *RegistrationUpdate {
+	if t != nil {
+		ru.SetValidTo(*t)
+	}
+	return ru
+}
+
+// ClearValidTo clears the value of the "valid_to" field.
+func (ru *RegistrationUpdate) ClearValidTo() *RegistrationUpdate {
+	ru.mutation.ClearValidTo()
+	return ru
+}
+
+// SetCarID sets the "car" edge to the Car entity by ID.
+func (ru *RegistrationUpdate) SetCarID(id int) *RegistrationUpdate {
+	ru.mutation.SetCarID(id)
+	return ru
+}
+
+// SetCar sets the "car" edge to the Car entity.
+func (ru *RegistrationUpdate) SetCar(c *Car) *RegistrationUpdate {
+	return ru.SetCarID(c.ID)
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (ru *RegistrationUpdate) SetOwnerID(id int) *RegistrationUpdate {
+	ru.mutation.SetOwnerID(id)
+	return ru
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (ru *RegistrationUpdate) SetOwner(u *User) *RegistrationUpdate {
+	return ru.SetOwnerID(u.ID)
+}
+
+// Mutation returns the RegistrationMutation object of the builder.
+func (ru *RegistrationUpdate) Mutation() *RegistrationMutation {
+	return ru.mutation
+}
+
+// ClearCar clears the "car" edge to the Car entity.
+func (ru *RegistrationUpdate) ClearCar() *RegistrationUpdate {
+	ru.mutation.ClearCar()
+	return ru
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (ru *RegistrationUpdate) ClearOwner() *RegistrationUpdate {
+	ru.mutation.ClearOwner()
+	return ru
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (ru *RegistrationUpdate) Save(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(ru.hooks) == 0 {
+		if err = ru.check(); err != nil {
+			return 0, err
+		}
+		affected, err = ru.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*RegistrationMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = ru.check(); err != nil {
+				return 0, err
+			}
+			ru.mutation = mutation
+			affected, err = ru.sqlSave(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(ru.hooks) - 1; i >= 0; i-- {
+			if ru.hooks[i] == nil {
+				return 0, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = ru.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, ru.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ru *RegistrationUpdate) SaveX(ctx context.Context) int {
+	affected, err := ru.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (ru *RegistrationUpdate) Exec(ctx context.Context) error {
+	_, err := ru.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ru *RegistrationUpdate) ExecX(ctx context.Context) {
+	if err := ru.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ru *RegistrationUpdate) check() error {
+	if v, ok := ru.mutation.Plate(); ok {
+		if err := registration.PlateValidator(v); err != nil {
+			return &ValidationError{Name: "plate", err: fmt.Errorf(`ent: validator failed for field "Registration.plate": %w`, err)}
+		}
+	}
+	if v, ok := ru.mutation.Region(); ok {
+		if err := registration.RegionValidator(v); err != nil {
+			return &ValidationError{Name: "region", err: fmt.Errorf(`ent: validator failed for field "Registration.region": %w`, err)}
+		}
+	}
+	if _, ok := ru.mutation.CarID(); ru.mutation.CarCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Registration.car"`)
+	}
+	if _, ok := ru.mutation.OwnerID(); ru.mutation.OwnerCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Registration.owner"`)
+	}
+	return nil
+}
+
+func (ru *RegistrationUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   registration.Table,
+			Columns: registration.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: registration.FieldID,
+			},
+		},
+	}
+	if ps := ru.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ru.mutation.Plate(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: registration.FieldPlate,
+		})
+	}
+	if value, ok := ru.mutation.Region(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: registration.FieldRegion,
+		})
+	}
+	if value, ok := ru.mutation.ValidFrom(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: registration.FieldValidFrom,
+		})
+	}
+	if value, ok := ru.mutation.ValidTo(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: registration.FieldValidTo,
+		})
+	}
+	if ru.mutation.ValidToCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: registration.FieldValidTo,
+		})
+	}
+	if ru.mutation.CarCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   registration.CarTable,
+			Columns: []string{registration.CarColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: car.FieldID,
+				},
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ru.mutation.CarIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   registration.CarTable,
+			Columns: []string{registration.CarColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: car.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if ru.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   registration.OwnerTable,
+			Columns: []string{registration.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: user.FieldID,
+				},
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ru.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   registration.OwnerTable,
+			Columns: []string{registration.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: user.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, ru.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{registration.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// RegistrationUpdateOne is the builder for updating a single Registration entity.
+type RegistrationUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *RegistrationMutation
+}
+
+// SetPlate sets the "plate" field.
+func (ruo *RegistrationUpdateOne) SetPlate(s string) *RegistrationUpdateOne {
+	ruo.mutation.SetPlate(s)
+	return ruo
+}
+
+// SetRegion sets the "region" field.
+func (ruo *RegistrationUpdateOne) SetRegion(s string) *RegistrationUpdateOne {
+	ruo.mutation.SetRegion(s)
+	return ruo
+}
+
+// SetValidFrom sets the "valid_from" field.
+func (ruo *RegistrationUpdateOne) SetValidFrom(t time.Time) *RegistrationUpdateOne {
+	ruo.mutation.SetValidFrom(t)
+	return ruo
+}
+
+// SetNillableValidFrom sets the "valid_from" field if the given value is not nil.
+func (ruo *RegistrationUpdateOne) SetNillableValidFrom(t *time.Time) *RegistrationUpdateOne {
+	if t != nil {
+		ruo.SetValidFrom(*t)
+	}
+	return ruo
+}
+
+// SetValidTo sets the "valid_to" field.
+func (ruo *RegistrationUpdateOne) SetValidTo(t time.Time) *RegistrationUpdateOne {
+	ruo.mutation.SetValidTo(t)
+	return ruo
+}
+
+// SetNillableValidTo sets the "valid_to" field if the given value is not nil.
+func (ruo *RegistrationUpdateOne) SetNillableValidTo(t *time.Time) *RegistrationUpdateOne {
+	if t != nil {
+		ruo.SetValidTo(*t)
+	}
+	return ruo
+}
+
+// ClearValidTo clears the value of the "valid_to" field.
+func (ruo *RegistrationUpdateOne) ClearValidTo() *RegistrationUpdateOne {
+	ruo.mutation.ClearValidTo()
+	return ruo
+}
+
+// SetCarID sets the "car" edge to the Car entity by ID.
+func (ruo *RegistrationUpdateOne) SetCarID(id int) *RegistrationUpdateOne {
+	ruo.mutation.SetCarID(id)
+	return ruo
+}
+
+// SetCar sets the "car" edge to the Car entity.
+func (ruo *RegistrationUpdateOne) SetCar(c *Car) *RegistrationUpdateOne {
+	return ruo.SetCarID(c.ID)
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (ruo *RegistrationUpdateOne) SetOwnerID(id int) *RegistrationUpdateOne {
+	ruo.mutation.SetOwnerID(id)
+	return ruo
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (ruo *RegistrationUpdateOne) SetOwner(u *User) *RegistrationUpdateOne {
+	return ruo.SetOwnerID(u.ID)
+}
+
+// Mutation returns the RegistrationMutation object of the builder.
+func (ruo *RegistrationUpdateOne) Mutation() *RegistrationMutation {
+	return ruo.mutation
+}
+
+// ClearCar clears the "car" edge to the Car entity.
+func (ruo *RegistrationUpdateOne) ClearCar() *RegistrationUpdateOne {
+	ruo.mutation.ClearCar()
+	return ruo
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (ruo *RegistrationUpdateOne) ClearOwner() *RegistrationUpdateOne {
+	ruo.mutation.ClearOwner()
+	return ruo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (ruo *RegistrationUpdateOne) Select(field string, fields ...string) *RegistrationUpdateOne {
+	ruo.fields = append([]string{field}, fields...)
+	return ruo
+}
+
+// Save executes the query and returns the updated Registration entity.
+func (ruo *RegistrationUpdateOne) Save(ctx context.Context) (*Registration, error) {
+	var (
+		err  error
+		node *Registration
+	)
+	if len(ruo.hooks) == 0 {
+		if err = ruo.check(); err != nil {
+			return nil, err
+		}
+		node, err = ruo.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*RegistrationMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = ruo.check(); err != nil {
+				return nil, err
+			}
+			ruo.mutation = mutation
+			node, err = ruo.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(ruo.hooks) - 1; i >= 0; i-- {
+			if ruo.hooks[i] == nil {
+				return nil, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = ruo.hooks[i](mut)
+		}
+		v, err := mut.Mutate(ctx, ruo.mutation)
+		if err != nil {
+			return nil, err
+		}
+		nv, ok := v.(*Registration)
+		if !ok {
+			return nil, fmt.Errorf("unexpected node type %T returned from RegistrationMutation", v)
+		}
+		node = nv
+	}
+	return node, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ruo *RegistrationUpdateOne) SaveX(ctx context.Context) *Registration {
+	node, err := ruo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (ruo *RegistrationUpdateOne) Exec(ctx context.Context) error {
+	_, err := ruo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ruo *RegistrationUpdateOne) ExecX(ctx context.Context) {
+	if err := ruo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ruo *RegistrationUpdateOne) check() error {
+	if v, ok := ruo.mutation.Plate(); ok {
+		if err := registration.PlateValidator(v); err != nil {
+			return &ValidationError{Name: "plate", err: fmt.Errorf(`ent: validator failed for field "Registration.plate": %w`, err)}
+		}
+	}
+	if v, ok := ruo.mutation.Region(); ok {
+		if err := registration.RegionValidator(v); err != nil {
+			return &ValidationError{Name: "region", err: fmt.Errorf(`ent: validator failed for field "Registration.region": %w`, err)}
+		}
+	}
+	if _, ok := ruo.mutation.CarID(); ruo.mutation.CarCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Registration.car"`)
+	}
+	if _, ok := ruo.mutation.OwnerID(); ruo.mutation.OwnerCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Registration.owner"`)
+	}
+	return nil
+}
+
+func (ruo *RegistrationUpdateOne) sqlSave(ctx context.Context) (_node *Registration, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   registration.Table,
+			Columns: registration.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: registration.FieldID,
+			},
+		},
+	}
+	id, ok := ruo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Registration.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := ruo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, registration.FieldID)
+		for _, f := range fields {
+			if !registration.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != registration.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := ruo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ruo.mutation.Plate(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: registration.FieldPlate,
+		})
+	}
+	if value, ok := ruo.mutation.Region(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: registration.FieldRegion,
+		})
+	}
+	if value, ok := ruo.mutation.ValidFrom(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: registration.FieldValidFrom,
+		})
+	}
+	if value, ok := ruo.mutation.ValidTo(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: registration.FieldValidTo,
+		})
+	}
+	if ruo.mutation.ValidToCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: registration.FieldValidTo,
+		})
+	}
+	if ruo.mutation.CarCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   registration.CarTable,
+			Columns: []string{registration.CarColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: car.FieldID,
+				},
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ruo.mutation.CarIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   registration.CarTable,
+			Columns: []string{registration.CarColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: car.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if ruo.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   registration.OwnerTable,
+			Columns: []string{registration.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: user.FieldID,
+				},
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ruo.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   registration.OwnerTable,
+			Columns: []string{registration.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: &sqlgraph.FieldSpec{
+					Type:   field.TypeInt,
+					Column: user.FieldID,
+				},
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Registration{config: ruo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, ruo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{registration.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	return _node, nil
+}
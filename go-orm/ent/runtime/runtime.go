@@ -2,7 +2,189 @@
 
 package runtime
 
-// The schema-stitching logic is generated in github.com/anjanashankar9/go-learning/go-orm/ent/runtime.go
+import (
+	"context"
+	"time"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/group"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/membership"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/outboxevent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/registration"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/schema"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+
+	"entgo.io/ent"
+	"entgo.io/ent/privacy"
+)
+
+// The init function reads all schema descriptors with runtime code
+// (default values, validators, hooks and policies) and stitches it
+// to their package variables.
+func init() {
+	carMixin := schema.Car{}.Mixin()
+	car.Policy = privacy.NewPolicies(carMixin[0], schema.Car{})
+	car.Hooks[0] = func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if err := car.Policy.EvalMutation(ctx, m); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+	carMixinHooks0 := carMixin[0].Hooks()
+	carHooks := schema.Car{}.Hooks()
+
+	car.Hooks[1] = carMixinHooks0[0]
+
+	car.Hooks[2] = carMixinHooks0[1]
+
+	car.Hooks[3] = carHooks[0]
+	carMixinFields0 := carMixin[0].Fields()
+	_ = carMixinFields0
+	carFields := schema.Car{}.Fields()
+	_ = carFields
+	// carDescTenantID is the schema descriptor for tenant_id field.
+	carDescTenantID := carMixinFields0[0].Descriptor()
+	// car.DefaultTenantID holds the default value on creation for the tenant_id field.
+	car.DefaultTenantID = carDescTenantID.Default.(int)
+	groupMixin := schema.Group{}.Mixin()
+	group.Policy = privacy.NewPolicies(groupMixin[0], schema.Group{})
+	group.Hooks[0] = func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if err := group.Policy.EvalMutation(ctx, m); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+	groupMixinHooks0 := groupMixin[0].Hooks()
+	groupHooks := schema.Group{}.Hooks()
+
+	group.Hooks[1] = groupMixinHooks0[0]
+
+	group.Hooks[2] = groupMixinHooks0[1]
+
+	group.Hooks[3] = groupHooks[0]
+
+	group.Hooks[4] = groupHooks[1]
+	groupMixinFields0 := groupMixin[0].Fields()
+	_ = groupMixinFields0
+	groupFields := schema.Group{}.Fields()
+	_ = groupFields
+	// groupDescTenantID is the schema descriptor for tenant_id field.
+	groupDescTenantID := groupMixinFields0[0].Descriptor()
+	// group.DefaultTenantID holds the default value on creation for the tenant_id field.
+	group.DefaultTenantID = groupDescTenantID.Default.(int)
+	// groupDescName is the schema descriptor for name field.
+	groupDescName := groupFields[0].Descriptor()
+	// group.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	group.NameValidator = groupDescName.Validators[0].(func(string) error)
+	membership.Policy = privacy.NewPolicies(schema.Membership{})
+	membership.Hooks[0] = func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if err := membership.Policy.EvalMutation(ctx, m); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+	membershipHooks := schema.Membership{}.Hooks()
+
+	membership.Hooks[1] = membershipHooks[0]
+
+	membership.Hooks[2] = membershipHooks[1]
+	membershipFields := schema.Membership{}.Fields()
+	_ = membershipFields
+	// membershipDescJoinedAt is the schema descriptor for joined_at field.
+	membershipDescJoinedAt := membershipFields[1].Descriptor()
+	// membership.DefaultJoinedAt holds the default value on creation for the joined_at field.
+	membership.DefaultJoinedAt = membershipDescJoinedAt.Default.(func() time.Time)
+	outboxevent.Policy = privacy.NewPolicies(schema.OutboxEvent{})
+	outboxevent.Hooks[0] = func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if err := outboxevent.Policy.EvalMutation(ctx, m); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+	outboxeventHooks := schema.OutboxEvent{}.Hooks()
+
+	outboxevent.Hooks[1] = outboxeventHooks[0]
+	outboxeventFields := schema.OutboxEvent{}.Fields()
+	_ = outboxeventFields
+	// outboxeventDescEntity is the schema descriptor for entity field.
+	outboxeventDescEntity := outboxeventFields[1].Descriptor()
+	// outboxevent.EntityValidator is a validator for the "entity" field. It is called by the builders before save.
+	outboxevent.EntityValidator = outboxeventDescEntity.Validators[0].(func(string) error)
+	// outboxeventDescCreatedAt is the schema descriptor for created_at field.
+	outboxeventDescCreatedAt := outboxeventFields[7].Descriptor()
+	// outboxevent.DefaultCreatedAt holds the default value on creation for the created_at field.
+	outboxevent.DefaultCreatedAt = outboxeventDescCreatedAt.Default.(func() time.Time)
+	registration.Policy = privacy.NewPolicies(schema.Registration{})
+	registration.Hooks[0] = func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if err := registration.Policy.EvalMutation(ctx, m); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+	registrationHooks := schema.Registration{}.Hooks()
+
+	registration.Hooks[1] = registrationHooks[0]
+
+	registration.Hooks[2] = registrationHooks[1]
+	registrationFields := schema.Registration{}.Fields()
+	_ = registrationFields
+	// registrationDescPlate is the schema descriptor for plate field.
+	registrationDescPlate := registrationFields[0].Descriptor()
+	// registration.PlateValidator is a validator for the "plate" field. It is called by the builders before save.
+	registration.PlateValidator = registrationDescPlate.Validators[0].(func(string) error)
+	// registrationDescRegion is the schema descriptor for region field.
+	registrationDescRegion := registrationFields[1].Descriptor()
+	// registration.RegionValidator is a validator for the "region" field. It is called by the builders before save.
+	registration.RegionValidator = registrationDescRegion.Validators[0].(func(string) error)
+	// registrationDescValidFrom is the schema descriptor for valid_from field.
+	registrationDescValidFrom := registrationFields[2].Descriptor()
+	// registration.DefaultValidFrom holds the default value on creation for the valid_from field.
+	registration.DefaultValidFrom = registrationDescValidFrom.Default.(func() time.Time)
+	userMixin := schema.User{}.Mixin()
+	user.Policy = privacy.NewPolicies(userMixin[0], schema.User{})
+	user.Hooks[0] = func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if err := user.Policy.EvalMutation(ctx, m); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+	userMixinHooks0 := userMixin[0].Hooks()
+	userHooks := schema.User{}.Hooks()
+
+	user.Hooks[1] = userMixinHooks0[0]
+
+	user.Hooks[2] = userMixinHooks0[1]
+
+	user.Hooks[3] = userHooks[0]
+	userMixinFields0 := userMixin[0].Fields()
+	_ = userMixinFields0
+	userFields := schema.User{}.Fields()
+	_ = userFields
+	// userDescTenantID is the schema descriptor for tenant_id field.
+	userDescTenantID := userMixinFields0[0].Descriptor()
+	// user.DefaultTenantID holds the default value on creation for the tenant_id field.
+	user.DefaultTenantID = userDescTenantID.Default.(int)
+	// userDescAge is the schema descriptor for age field.
+	userDescAge := userFields[0].Descriptor()
+	// user.AgeValidator is a validator for the "age" field. It is called by the builders before save.
+	user.AgeValidator = userDescAge.Validators[0].(func(int) error)
+	// userDescName is the schema descriptor for name field.
+	userDescName := userFields[1].Descriptor()
+	// user.DefaultName holds the default value on creation for the name field.
+	user.DefaultName = userDescName.Default.(string)
+}
 
 const (
 	Version = "v0.11.1"                                         // Version of ent codegen.
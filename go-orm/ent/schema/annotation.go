@@ -0,0 +1,16 @@
+package schema
+
+// Search is a schema annotation marking the text fields of an
+// entity that can be searched. See package search for its use.
+type Search struct {
+	// Fields are the searchable fields.
+	Fields []string
+	// Config is the Postgres text search configuration used for
+	// the tsvector indexes. It defaults to "simple".
+	Config string
+}
+
+// Name implements the schema.Annotation interface.
+func (Search) Name() string {
+	return "Search"
+}
@@ -2,8 +2,12 @@ package schema
 
 import (
 	"entgo.io/ent"
+	"entgo.io/ent/schema"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/privacy"
+	"github.com/anjanashankar9/go-learning/go-orm/observe"
+	"github.com/anjanashankar9/go-learning/go-orm/rule"
 )
 
 // Car holds the schema definition for the Car entity.
@@ -11,6 +15,13 @@ type Car struct {
 	ent.Schema
 }
 
+// Mixin of the Car.
+func (Car) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TenantMixin{},
+	}
+}
+
 // Fields of the Car.
 func (Car) Fields() []ent.Field {
 	return []ent.Field{
@@ -30,5 +41,40 @@ func (Car) Edges() []ent.Edge {
 			// setting the edge to unique, ensure
 			// that a car can have only one owner.
 			Unique(),
+		// The registration history of the car. Each ownership
+		// transfer closes the current registration and opens a new one.
+		edge.To("registrations", Registration.Type),
+	}
+}
+
+// Annotations of the Car.
+func (Car) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		// The model of a car can be searched with typo tolerance.
+		Search{Fields: []string{"model"}},
+	}
+}
+
+// Hooks of the Car.
+func (Car) Hooks() []ent.Hook {
+	return []ent.Hook{
+		// Last, see observe.Hook.
+		observe.Hook(),
+	}
+}
+
+// Policy defines the privacy policy of the Car.
+func (Car) Policy() ent.Policy {
+	return privacy.Policy{
+		Mutation: privacy.MutationPolicy{
+			rule.DenyIfNoViewer(),
+			rule.AllowIfAdmin(),
+			rule.LimitCarMutationsToOwner(),
+		},
+		Query: privacy.QueryPolicy{
+			rule.DenyIfNoViewer(),
+			rule.AllowIfAdmin(),
+			rule.FilterCarsByOwner(),
+		},
 	}
 }
@@ -4,6 +4,9 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/privacy"
+	"github.com/anjanashankar9/go-learning/go-orm/observe"
+	"github.com/anjanashankar9/go-learning/go-orm/rule"
 	"regexp"
 )
 
@@ -12,6 +15,13 @@ type Group struct {
 	ent.Schema
 }
 
+// Mixin of the Group.
+func (Group) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TenantMixin{},
+	}
+}
+
 // Fields of the Group.
 func (Group) Fields() []ent.Field {
 	return []ent.Field{
@@ -24,6 +34,34 @@ func (Group) Fields() []ent.Field {
 // Edges of the Group.
 func (Group) Edges() []ent.Edge {
 	return []ent.Edge{
-		edge.To("users", User.Type),
+		// The users of a group are joined through the Membership
+		// edge schema, which records their role and joining time.
+		edge.To("users", User.Type).
+			Through("memberships", Membership.Type),
+	}
+}
+
+// Hooks of the Group.
+func (Group) Hooks() []ent.Hook {
+	return []ent.Hook{
+		rule.AddCreatorAsAdmin(),
+		// Last, see observe.Hook.
+		observe.Hook(),
+	}
+}
+
+// Policy defines the privacy policy of the Group.
+func (Group) Policy() ent.Policy {
+	return privacy.Policy{
+		Mutation: privacy.MutationPolicy{
+			rule.DenyIfNoViewer(),
+			rule.AllowIfAdmin(),
+			rule.RequireGroupAdmin(),
+		},
+		Query: privacy.QueryPolicy{
+			rule.DenyIfNoViewer(),
+			rule.AllowIfAdmin(),
+			rule.FilterGroupsByMember(),
+		},
 	}
 }
@@ -0,0 +1,82 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/privacy"
+	"github.com/anjanashankar9/go-learning/go-orm/observe"
+	"github.com/anjanashankar9/go-learning/go-orm/rule"
+)
+
+// Membership holds the schema definition for the Membership entity.
+// It is the edge schema of the Group-User edge.
+type Membership struct {
+	ent.Schema
+}
+
+// Annotations of the Membership.
+func (Membership) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		// A user can be a member of a group only once.
+		field.ID("group_id", "user_id"),
+	}
+}
+
+// Fields of the Membership.
+func (Membership) Fields() []ent.Field {
+	return []ent.Field{
+		field.Enum("role").
+			Values("admin", "member").
+			Default("member"),
+		field.Time("joined_at").
+			Default(time.Now).
+			Immutable(),
+		field.Int("group_id"),
+		field.Int("user_id"),
+	}
+}
+
+// Edges of the Membership.
+func (Membership) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("group", Group.Type).
+			Required().
+			Unique().
+			Field("group_id"),
+		edge.To("user", User.Type).
+			Required().
+			Unique().
+			Field("user_id"),
+	}
+}
+
+// Hooks of the Membership.
+func (Membership) Hooks() []ent.Hook {
+	return []ent.Hook{
+		// The memberships link entities of the same tenant only.
+		rule.DenyCrossTenantEdges(),
+		// Last, see observe.Hook.
+		observe.Hook(),
+	}
+}
+
+// Policy defines the privacy policy of the Membership.
+func (Membership) Policy() ent.Policy {
+	return privacy.Policy{
+		Mutation: privacy.MutationPolicy{
+			rule.DenyIfNoViewer(),
+			rule.AllowIfAdmin(),
+			rule.RequireMembershipGroupAdmin(),
+		},
+		Query: privacy.QueryPolicy{
+			rule.DenyIfNoViewer(),
+			rule.FilterMembershipsByTenant(),
+			rule.AllowIfAdmin(),
+			rule.FilterMembershipsByGroup(),
+		},
+	}
+}
@@ -0,0 +1,84 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/privacy"
+	"github.com/anjanashankar9/go-learning/go-orm/observe"
+	"github.com/anjanashankar9/go-learning/go-orm/rule"
+)
+
+// OutboxEvent holds the schema definition for the OutboxEvent entity.
+// An outbox event records a change made to another entity. It is written
+// in the transaction of the change, and delivered to the consumers once
+// the transaction commits.
+type OutboxEvent struct {
+	ent.Schema
+}
+
+// Fields of the OutboxEvent.
+func (OutboxEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.Enum("op").
+			Values("create", "update", "delete"),
+		// entity is the type of the changed entity, and entity_id its id.
+		field.String("entity").
+			NotEmpty(),
+		field.Int("entity_id"),
+		// fields holds the values of the fields set by the change, added
+		// the values added to numeric fields, and cleared the names of
+		// the fields it cleared.
+		field.JSON("fields", map[string]interface{}{}).
+			Optional(),
+		field.JSON("added", map[string]interface{}{}).
+			Optional(),
+		field.Strings("cleared").
+			Optional(),
+		// edges holds the names of the edges the change added or removed.
+		field.Strings("edges").
+			Optional(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		// delivered_at is nil until the event is delivered.
+		field.Time("delivered_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Indexes of the OutboxEvent.
+func (OutboxEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("delivered_at"),
+	}
+}
+
+// Hooks of the OutboxEvent.
+func (OutboxEvent) Hooks() []ent.Hook {
+	return []ent.Hook{
+		// Last, see observe.Hook.
+		observe.Hook(),
+	}
+}
+
+// Policy defines the privacy policy of the OutboxEvent. Events are
+// written and delivered by the cdc package, which is not subject to it,
+// so only admins can read or change them.
+func (OutboxEvent) Policy() ent.Policy {
+	return privacy.Policy{
+		Mutation: privacy.MutationPolicy{
+			rule.DenyIfNoViewer(),
+			rule.AllowIfAdmin(),
+			privacy.AlwaysDenyRule(),
+		},
+		Query: privacy.QueryPolicy{
+			rule.DenyIfNoViewer(),
+			rule.AllowIfAdmin(),
+			privacy.AlwaysDenyRule(),
+		},
+	}
+}
@@ -0,0 +1,85 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/privacy"
+	"github.com/anjanashankar9/go-learning/go-orm/observe"
+	"github.com/anjanashankar9/go-learning/go-orm/rule"
+)
+
+// Registration holds the schema definition for the Registration entity.
+// A registration records who owned a car, and under which plate,
+// for the period between valid_from and valid_to.
+type Registration struct {
+	ent.Schema
+}
+
+// Fields of the Registration.
+func (Registration) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("plate").
+			NotEmpty(),
+		field.String("region").
+			NotEmpty(),
+		field.Time("valid_from").
+			Default(time.Now),
+		// valid_to is nil for the current registration of a car.
+		field.Time("valid_to").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Edges of the Registration.
+func (Registration) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("car", Car.Type).
+			Ref("registrations").
+			Unique().
+			Required(),
+		edge.From("owner", User.Type).
+			Ref("registrations").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the Registration.
+func (Registration) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("valid_from").
+			Edges("car"),
+	}
+}
+
+// Hooks of the Registration.
+func (Registration) Hooks() []ent.Hook {
+	return []ent.Hook{
+		// The registrations link entities of the same tenant only.
+		rule.DenyCrossTenantEdges(),
+		// Last, see observe.Hook.
+		observe.Hook(),
+	}
+}
+
+// Policy defines the privacy policy of the Registration.
+func (Registration) Policy() ent.Policy {
+	return privacy.Policy{
+		Mutation: privacy.MutationPolicy{
+			rule.DenyIfNoViewer(),
+			rule.AllowIfAdmin(),
+			rule.LimitRegistrationMutationsToCarOwner(),
+		},
+		Query: privacy.QueryPolicy{
+			rule.DenyIfNoViewer(),
+			rule.FilterRegistrationsByTenant(),
+			rule.AllowIfAdmin(),
+			rule.FilterRegistrationsByOwner(),
+		},
+	}
+}
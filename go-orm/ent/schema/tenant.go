@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"entgo.io/ent/schema/mixin"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/privacy"
+	"github.com/anjanashankar9/go-learning/go-orm/rule"
+	"github.com/anjanashankar9/go-learning/go-orm/tenant"
+)
+
+// TenantMixin makes the entities of a schema belong to a tenant.
+// The tenant of a new entity is taken from the context, and the
+// entities of other tenants can be neither read nor changed.
+type TenantMixin struct {
+	mixin.Schema
+}
+
+// Fields of the TenantMixin.
+func (TenantMixin) Fields() []ent.Field {
+	return []ent.Field{
+		// The existing rows are moved to the default tenant.
+		field.Int("tenant_id").
+			Default(tenant.Default).
+			Immutable(),
+	}
+}
+
+// Indexes of the TenantMixin.
+func (TenantMixin) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("tenant_id"),
+	}
+}
+
+// Hooks of the TenantMixin.
+func (TenantMixin) Hooks() []ent.Hook {
+	return []ent.Hook{
+		rule.SetTenant(),
+		rule.DenyCrossTenantEdges(),
+	}
+}
+
+// Policy of the TenantMixin. It is evaluated before the
+// policy of the schema, and applies to the admins bound to a tenant too.
+func (TenantMixin) Policy() ent.Policy {
+	return privacy.Policy{
+		Mutation: privacy.MutationPolicy{
+			rule.FilterTenant(),
+		},
+		Query: privacy.QueryPolicy{
+			rule.FilterTenant(),
+		},
+	}
+}
@@ -16,6 +16,12 @@ type Tx struct {
 	Car *CarClient
 	// Group is the client for interacting with the Group builders.
 	Group *GroupClient
+	// Membership is the client for interacting with the Membership builders.
+	Membership *MembershipClient
+	// OutboxEvent is the client for interacting with the OutboxEvent builders.
+	OutboxEvent *OutboxEventClient
+	// Registration is the client for interacting with the Registration builders.
+	Registration *RegistrationClient
 	// User is the client for interacting with the User builders.
 	User *UserClient
 
@@ -155,6 +161,9 @@ func (tx *Tx) Client() *Client {
 func (tx *Tx) init() {
 	tx.Car = NewCarClient(tx.config)
 	tx.Group = NewGroupClient(tx.config)
+	tx.Membership = NewMembershipClient(tx.config)
+	tx.OutboxEvent = NewOutboxEventClient(tx.config)
+	tx.Registration = NewRegistrationClient(tx.config)
 	tx.User = NewUserClient(tx.config)
 }
 
@@ -5,6 +5,8 @@ go 1.18
 require (
 	entgo.io/ent v0.11.1
 	github.com/lib/pq v1.10.5
+	github.com/mattn/go-sqlite3 v1.14.13
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
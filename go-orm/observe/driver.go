@@ -0,0 +1,271 @@
+// Package observe wraps an ent driver to trace the SQL statements it runs.
+//
+// Every statement is timed and described by a Stat, holding its row count
+// and the ent operation and entity type it ran for. Stats are passed to a
+// trace function, aggregated by Metrics, and written to a slow-query log
+// when they take longer than a threshold:
+//
+//	drv, err := sql.Open(dialect.Postgres, dsn)
+//	if err != nil {
+//		return err
+//	}
+//	metrics := observe.NewMetrics()
+//	client := ent.NewClient(ent.Driver(observe.NewDriver(drv,
+//		observe.WithMetrics(metrics),
+//		observe.SlowQueries(100*time.Millisecond, log.Default()),
+//	)))
+//
+// The operation and type of the statements run by mutations are taken from
+// the mutation by Hook, the last hook of each schema. Other statements,
+// those of the privacy rules and hooks of the mutations included, are
+// queries, whose type is found from the table they read.
+package observe
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+)
+
+// OpQuery is the Op of the statements not run by a mutation.
+const OpQuery = "Query"
+
+// Stat describes an executed statement.
+type Stat struct {
+	// Op is the ent operation, such as "OpCreate", or OpQuery.
+	Op string
+	// Type is the entity type, such as "User". It is empty for
+	// statements not touching the table of an entity.
+	Type string
+	// Query and Args are the statement and its arguments. Args are
+	// not redacted, use Redact before writing them anywhere.
+	Query string
+	Args  []interface{}
+	// Duration is the time taken by the statement. For queries,
+	// it includes reading the rows.
+	Duration time.Duration
+	// Rows is the number of rows read by a query,
+	// or affected by another statement.
+	Rows int64
+	// Tx reports whether the statement ran in a transaction.
+	Tx  bool
+	Err error
+}
+
+// Driver is a dialect.Driver reporting the statements of the driver it wraps.
+type Driver struct {
+	dialect.Driver
+	trace     func(context.Context, Stat)
+	metrics   *Metrics
+	slow      time.Duration
+	slowLog   *log.Logger
+	slowQuery bool
+}
+
+// Option configures a Driver.
+type Option func(*Driver)
+
+// Trace sets a function called with the Stat of every statement.
+func Trace(f func(context.Context, Stat)) Option {
+	return func(d *Driver) {
+		d.trace = f
+	}
+}
+
+// WithMetrics records the Stats of the statements in m.
+func WithMetrics(m *Metrics) Option {
+	return func(d *Driver) {
+		d.metrics = m
+	}
+}
+
+// SlowQueries logs the statements taking at least threshold to l,
+// with their arguments redacted.
+func SlowQueries(threshold time.Duration, l *log.Logger) Option {
+	return func(d *Driver) {
+		d.slow = threshold
+		d.slowLog = l
+		d.slowQuery = true
+	}
+}
+
+// NewDriver returns a Driver wrapping drv.
+func NewDriver(drv dialect.Driver, opts ...Option) *Driver {
+	d := &Driver{Driver: drv}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Exec implements the dialect.Driver.Exec method.
+func (d *Driver) Exec(ctx context.Context, query string, args, v interface{}) error {
+	return d.exec(ctx, d.Driver, false, query, args, v)
+}
+
+// Query implements the dialect.Driver.Query method.
+func (d *Driver) Query(ctx context.Context, query string, args, v interface{}) error {
+	return d.query(ctx, d.Driver, false, query, args, v)
+}
+
+// Tx starts a transaction whose statements are reported.
+func (d *Driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, drv: d}, nil
+}
+
+// BeginTx starts a transaction with options, if the wrapped driver
+// supports them. ent.Client.BeginTx requires this method.
+func (d *Driver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("observe: Driver.BeginTx is not supported")
+	}
+	tx, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, drv: d}, nil
+}
+
+// Tx is a dialect.Tx reporting its statements to the Driver it comes from.
+type Tx struct {
+	dialect.Tx
+	drv *Driver
+}
+
+// Exec implements the dialect.Tx.Exec method.
+func (tx *Tx) Exec(ctx context.Context, query string, args, v interface{}) error {
+	return tx.drv.exec(ctx, tx.Tx, true, query, args, v)
+}
+
+// Query implements the dialect.Tx.Query method.
+func (tx *Tx) Query(ctx context.Context, query string, args, v interface{}) error {
+	return tx.drv.query(ctx, tx.Tx, true, query, args, v)
+}
+
+func (d *Driver) exec(ctx context.Context, eq dialect.ExecQuerier, tx bool, query string, args, v interface{}) error {
+	start := time.Now()
+	err := eq.Exec(ctx, query, args, v)
+	s := d.stat(ctx, tx, query, args)
+	s.Duration = time.Since(start)
+	s.Err = err
+	if res, ok := v.(*sql.Result); ok && err == nil && *res != nil {
+		s.Rows, _ = (*res).RowsAffected()
+	}
+	d.report(ctx, s)
+	return err
+}
+
+// query reports the statement once its rows are closed, so it can
+// count them. The rows of statements not reading the table of an
+// entity are left unwrapped, as the schema migration needs them to
+// be *sql.Rows, and are not counted.
+func (d *Driver) query(ctx context.Context, eq dialect.ExecQuerier, tx bool, query string, args, v interface{}) error {
+	start := time.Now()
+	err := eq.Query(ctx, query, args, v)
+	s := d.stat(ctx, tx, query, args)
+	rows, ok := v.(*entsql.Rows)
+	if err != nil || !ok || s.Type == "" {
+		s.Duration = time.Since(start)
+		s.Err = err
+		d.report(ctx, s)
+		return err
+	}
+	rows.ColumnScanner = &countRows{ColumnScanner: rows.ColumnScanner, done: func(n int64, err error) {
+		s.Duration = time.Since(start)
+		s.Rows = n
+		s.Err = err
+		d.report(ctx, s)
+	}}
+	return nil
+}
+
+func (d *Driver) stat(ctx context.Context, tx bool, query string, args interface{}) Stat {
+	s := Stat{Op: OpQuery, Query: query, Tx: tx}
+	s.Args, _ = args.([]interface{})
+	if m, ok := ctx.Value(mutationKey{}).(mutationInfo); ok {
+		s.Op, s.Type = m.op, m.typ
+	} else {
+		s.Type = tableType(query)
+	}
+	return s
+}
+
+func (d *Driver) report(ctx context.Context, s Stat) {
+	if d.trace != nil {
+		d.trace(ctx, s)
+	}
+	if d.metrics != nil {
+		d.metrics.Observe(s)
+	}
+	if d.slowQuery && s.Duration >= d.slow {
+		l := d.slowLog
+		if l == nil {
+			l = log.Default()
+		}
+		l.Printf("slow query: op=%s type=%s duration=%s rows=%d query=%q args=%s",
+			s.Op, s.Type, s.Duration, s.Rows, s.Query, Redact(s.Args))
+	}
+}
+
+// countRows counts the rows read from the wrapped scanner,
+// and calls done when it is closed.
+type countRows struct {
+	entsql.ColumnScanner
+	n    int64
+	done func(int64, error)
+}
+
+func (r *countRows) Next() bool {
+	if r.ColumnScanner.Next() {
+		r.n++
+		return true
+	}
+	return false
+}
+
+func (r *countRows) Close() error {
+	err := r.ColumnScanner.Close()
+	if r.done != nil {
+		serr := r.ColumnScanner.Err()
+		if serr == nil {
+			serr = err
+		}
+		r.done(r.n, serr)
+		r.done = nil
+	}
+	return err
+}
+
+type mutationKey struct{}
+
+type mutationInfo struct {
+	op, typ string
+}
+
+// Hook returns a hook adding the operation and type of the mutations
+// to their context, for the Stats of the statements they run. It is
+// meant to be the last hook of a schema: ent runs the privacy policy,
+// the hooks of the mixins and the hooks before it first, and the
+// statements they run are not the mutation's. Added to a client with
+// Use, it would run first, and label them too.
+func Hook() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			ctx = context.WithValue(ctx, mutationKey{}, mutationInfo{op: m.Op().String(), typ: m.Type()})
+			return next.Mutate(ctx, m)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package observe_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	_ "github.com/anjanashankar9/go-learning/go-orm/ent/runtime"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+	"github.com/anjanashankar9/go-learning/go-orm/observe"
+	"github.com/anjanashankar9/go-learning/go-orm/viewer"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// openClient returns a client of an in-memory SQLite database, through
+// a Driver with the options, once the schema is created.
+func openClient(t *testing.T, opts ...observe.Option) *ent.Client {
+	t.Helper()
+	drv, err := entsql.Open(dialect.SQLite, fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := ent.NewClient(ent.Driver(observe.NewDriver(drv, opts...)))
+	t.Cleanup(func() { client.Close() })
+	if err := client.Schema.Create(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// stats records the Stats of the statements.
+type stats []observe.Stat
+
+func (s *stats) trace(_ context.Context, st observe.Stat) { *s = append(*s, st) }
+
+func TestDriverLabels(t *testing.T) {
+	var got stats
+	client := openClient(t, observe.Trace(got.trace))
+	ctx := viewer.AdminContext(context.Background())
+	a8m := client.User.Create().SetName("a8m").SetAge(30).SaveX(ctx)
+	c := client.Car.Create().SetModel("Tesla").SetRegisteredAt(time.Now()).SetOwner(a8m).SaveX(ctx)
+
+	// The policy of the cars reads the car before the update: its query
+	// is not the mutation's.
+	got = nil
+	client.Car.UpdateOneID(c.ID).SetModel("Ford").ExecX(viewer.UserContext(context.Background(), a8m.ID))
+	var ops []string
+	for _, s := range got {
+		verb := strings.Fields(s.Query)[0]
+		ops = append(ops, fmt.Sprintf("%s %s %s", s.Op, s.Type, verb))
+		if s.Op == "OpUpdateOne" && !s.Tx {
+			t.Errorf("%q ran out of the transaction of the update", s.Query)
+		}
+	}
+	want := []string{"Query Car SELECT", "OpUpdateOne Car UPDATE", "OpUpdateOne Car SELECT"}
+	if fmt.Sprint(ops) != fmt.Sprint(want) {
+		t.Errorf("statements = %q, want %q", ops, want)
+	}
+
+	got = nil
+	n := client.User.Query().Where(user.Name("a8m")).CountX(ctx)
+	if len(got) != 1 || got[0].Op != observe.OpQuery || got[0].Type != ent.TypeUser || got[0].Rows != 1 || n != 1 {
+		t.Errorf("stats of a count = %+v", got)
+	}
+	got = nil
+	client.User.Query().AllX(ctx)
+	if len(got) != 1 || got[0].Rows != 1 {
+		t.Errorf("stats of a query = %+v, want 1 row", got)
+	}
+	got = nil
+	client.User.Delete().Where(user.Name("nobody")).ExecX(ctx)
+	if len(got) != 1 || got[0].Op != "OpDelete" || got[0].Rows != 0 {
+		t.Errorf("stats of a delete = %+v, want 0 rows", got)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	m := observe.NewMetrics(time.Millisecond, time.Second)
+	client := openClient(t, observe.WithMetrics(m))
+	ctx := viewer.AdminContext(context.Background())
+	for i := 0; i < 3; i++ {
+		client.User.Create().SetName(fmt.Sprint("user", i)).SetAge(30).ExecX(ctx)
+	}
+	client.User.Query().AllX(ctx)
+	// The hook of the tenants reads the owner, who does not exist: the
+	// query of the hook is a query of the users, the insert fails.
+	if err := client.Car.Create().SetModel("Tesla").SetRegisteredAt(time.Now()).SetOwnerID(1000).Exec(ctx); err == nil {
+		t.Fatal("created a car of an unknown owner")
+	}
+
+	var creates, queries, failed *observe.Series
+	for _, s := range m.Snapshot() {
+		s := s
+		switch {
+		case s.Type == ent.TypeUser && s.Op == "OpCreate":
+			creates = &s
+		case s.Type == ent.TypeUser && s.Op == observe.OpQuery:
+			queries = &s
+		case s.Type == ent.TypeCar && s.Op == "OpCreate":
+			failed = &s
+		}
+	}
+	if creates == nil || creates.Count != 3 || creates.Rows != 3 || creates.Buckets[1].Count != 3 {
+		t.Errorf("creates = %+v, want 3 statements of 1 row", creates)
+	}
+	if queries == nil || queries.Count != 2 || queries.Rows != 3 {
+		t.Errorf("queries = %+v, want 2 statements of 3 rows", queries)
+	}
+	if failed == nil || failed.Errors != 1 {
+		t.Errorf("car creates = %+v, want 1 error", failed)
+	}
+
+	var buf bytes.Buffer
+	if _, err := m.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		`ent_statements_total{op="OpCreate",type="User"} 3`,
+		`ent_statement_rows_total{op="Query",type="User"} 3`,
+		`ent_statement_duration_seconds_bucket{op="OpCreate",type="User",le="1"} 3`,
+		`ent_statement_duration_seconds_bucket{op="OpCreate",type="User",le="+Inf"} 3`,
+		`ent_statement_duration_seconds_count{op="Query",type="User"} 2`,
+		`ent_statement_errors_total{op="OpCreate",type="Car"} 1`,
+	} {
+		if !strings.Contains(buf.String(), line+"\n") {
+			t.Errorf("metrics have no line %q:\n%s", line, buf.String())
+		}
+	}
+}
+
+func TestSlowQueries(t *testing.T) {
+	var buf bytes.Buffer
+	client := openClient(t, observe.SlowQueries(0, log.New(&buf, "", 0)))
+	ctx := viewer.AdminContext(context.Background())
+	client.User.Create().SetName("secret name").SetAge(42).ExecX(ctx)
+	out := buf.String()
+	if !strings.Contains(out, "slow query: op=OpCreate type=User") || !strings.Contains(out, "args=[$1=") {
+		t.Errorf("slow query log = %q, want the create", out)
+	}
+	if strings.Contains(out, "secret name") {
+		t.Errorf("slow query log = %q, has the arguments", out)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	if got, want := observe.Redact([]interface{}{"a8m", 30, nil}), "[$1=string $2=int $3=<nil>]"; got != want {
+		t.Errorf("Redact = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,174 @@
+package observe
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are the upper bounds of the duration histogram buckets.
+var DefaultBuckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+}
+
+// Metrics aggregates Stats into counters and duration histograms, by
+// operation and entity type. It is an http.Handler serving them in the
+// Prometheus text format.
+type Metrics struct {
+	buckets []time.Duration
+
+	mu     sync.Mutex
+	series map[seriesKey]*Series
+}
+
+type seriesKey struct {
+	op, typ string
+}
+
+// Series holds the metrics of the statements of one operation and type.
+type Series struct {
+	Op, Type string
+	// Count, Errors and Rows are the number of statements,
+	// of failed statements, and of rows read or affected.
+	Count, Errors, Rows int64
+	// Sum is the total duration of the statements.
+	Sum time.Duration
+	// Buckets holds, for each of the histogram bounds, the number of
+	// statements that took at most that long. Like in Prometheus, the
+	// counts are cumulative.
+	Buckets []Bucket
+}
+
+// Bucket is a bucket of a duration histogram.
+type Bucket struct {
+	Le    time.Duration
+	Count int64
+}
+
+// NewMetrics returns empty Metrics with the given histogram buckets,
+// or DefaultBuckets if none are given.
+func NewMetrics(buckets ...time.Duration) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]time.Duration(nil), buckets...)
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i] < buckets[j] })
+	return &Metrics{buckets: buckets, series: make(map[seriesKey]*Series)}
+}
+
+// Observe records the statement described by s.
+func (m *Metrics) Observe(s Stat) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	k := seriesKey{s.Op, s.Type}
+	ser, ok := m.series[k]
+	if !ok {
+		ser = &Series{Op: s.Op, Type: s.Type, Buckets: make([]Bucket, len(m.buckets))}
+		for i, le := range m.buckets {
+			ser.Buckets[i].Le = le
+		}
+		m.series[k] = ser
+	}
+	ser.Count++
+	if s.Err != nil {
+		ser.Errors++
+	}
+	ser.Rows += s.Rows
+	ser.Sum += s.Duration
+	for i := range ser.Buckets {
+		if s.Duration <= ser.Buckets[i].Le {
+			ser.Buckets[i].Count++
+		}
+	}
+}
+
+// Snapshot returns a copy of the series, sorted by type and operation.
+func (m *Metrics) Snapshot() []Series {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ss := make([]Series, 0, len(m.series))
+	for _, ser := range m.series {
+		s := *ser
+		s.Buckets = append([]Bucket(nil), ser.Buckets...)
+		ss = append(ss, s)
+	}
+	sort.Slice(ss, func(i, j int) bool {
+		if ss[i].Type != ss[j].Type {
+			return ss[i].Type < ss[j].Type
+		}
+		return ss[i].Op < ss[j].Op
+	})
+	return ss
+}
+
+// WriteTo writes the metrics to w in the Prometheus text format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	cw := &countWriter{w: bufio.NewWriter(w)}
+	ss := m.Snapshot()
+	counters := []struct {
+		name, help string
+		value      func(Series) int64
+	}{
+		{"ent_statements_total", "Number of SQL statements.", func(s Series) int64 { return s.Count }},
+		{"ent_statement_errors_total", "Number of failed SQL statements.", func(s Series) int64 { return s.Errors }},
+		{"ent_statement_rows_total", "Number of rows read or affected by SQL statements.", func(s Series) int64 { return s.Rows }},
+	}
+	for _, c := range counters {
+		fmt.Fprintf(cw, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+		for _, s := range ss {
+			fmt.Fprintf(cw, "%s{%s} %d\n", c.name, labels(s), c.value(s))
+		}
+	}
+	const h = "ent_statement_duration_seconds"
+	fmt.Fprintf(cw, "# HELP %s Duration of SQL statements.\n# TYPE %s histogram\n", h, h)
+	for _, s := range ss {
+		for _, b := range s.Buckets {
+			fmt.Fprintf(cw, "%s_bucket{%s,le=\"%g\"} %d\n", h, labels(s), b.Le.Seconds(), b.Count)
+		}
+		fmt.Fprintf(cw, "%s_bucket{%s,le=\"+Inf\"} %d\n", h, labels(s), s.Count)
+		fmt.Fprintf(cw, "%s_sum{%s} %g\n", h, labels(s), s.Sum.Seconds())
+		fmt.Fprintf(cw, "%s_count{%s} %d\n", h, labels(s), s.Count)
+	}
+	if cw.err != nil {
+		return cw.n, cw.err
+	}
+	return cw.n, cw.w.Flush()
+}
+
+// ServeHTTP serves the metrics in the Prometheus text format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	m.WriteTo(w)
+}
+
+func labels(s Series) string {
+	return fmt.Sprintf("op=%q,type=%q", s.Op, s.Type)
+}
+
+// countWriter counts the bytes written and keeps the first error.
+type countWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (cw *countWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	cw.err = err
+	return n, err
+}
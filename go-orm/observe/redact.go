@@ -0,0 +1,45 @@
+package observe
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/group"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/membership"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/registration"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+)
+
+// Redact describes the arguments of a statement without their values,
+// keeping only their position and type, as in "[$1=string $2=int]".
+func Redact(args []interface{}) string {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		parts[i] = fmt.Sprintf("$%d=%T", i+1, arg)
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// tables maps the tables of the entities to their type.
+var tables = map[string]string{
+	car.Table:          ent.TypeCar,
+	group.Table:        ent.TypeGroup,
+	membership.Table:   ent.TypeMembership,
+	registration.Table: ent.TypeRegistration,
+	user.Table:         ent.TypeUser,
+}
+
+// tableRE matches the table a statement reads or writes first.
+var tableRE = regexp.MustCompile("(?i)\\b(?:FROM|INTO|UPDATE)\\s+[`\"]?(\\w+)")
+
+// tableType returns the type of the entity whose table
+// the query reads or writes, or "" if there is none.
+func tableType(query string) string {
+	if m := tableRE.FindStringSubmatch(query); m != nil {
+		return tables[m[1]]
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+// Package ownership answers questions about the registration
+// history of cars, and records ownership transfers.
+package ownership
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/predicate"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/registration"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+	"github.com/anjanashankar9/go-learning/go-orm/txn"
+)
+
+// ValidAt returns a predicate matching the registrations valid at t.
+// A registration is valid from valid_from (inclusive) to valid_to (exclusive).
+func ValidAt(t time.Time) predicate.Registration {
+	return registration.And(
+		registration.ValidFromLTE(t),
+		registration.Or(
+			registration.ValidToIsNil(),
+			registration.ValidToGT(t),
+		),
+	)
+}
+
+// OwnerAt returns the owner of the car at time t.
+// It returns a *ent.NotFoundError if the car was not registered at t.
+func OwnerAt(ctx context.Context, client *ent.Client, carID int, t time.Time) (*ent.User, error) {
+	return client.Registration.Query().
+		Where(registration.HasCarWith(car.ID(carID)), ValidAt(t)).
+		QueryOwner().
+		Only(ctx)
+}
+
+// CarsOwnedAt returns the cars the user owned at time t.
+func CarsOwnedAt(ctx context.Context, client *ent.Client, userID int, t time.Time) ([]*ent.Car, error) {
+	return client.Car.Query().
+		Where(car.HasRegistrationsWith(
+			registration.HasOwnerWith(user.ID(userID)),
+			ValidAt(t),
+		)).
+		All(ctx)
+}
+
+// History returns the registrations of the car, oldest first,
+// with the owner of each registration loaded.
+func History(ctx context.Context, client *ent.Client, carID int) ([]*ent.Registration, error) {
+	return client.Registration.Query().
+		Where(registration.HasCarWith(car.ID(carID))).
+		WithOwner().
+		Order(ent.Asc(registration.FieldValidFrom)).
+		All(ctx)
+}
+
+// Transfer records the transfer of the car to the user with the id to at
+// time at, under the given plate and region. The current registration of
+// the car is closed, a new one is opened and the owner of the car is updated.
+// Registering a car for the first time is a transfer to its current owner.
+func Transfer(ctx context.Context, client *ent.Client, carID, to int, plate, region string, at time.Time) (*ent.Registration, error) {
+	var r *ent.Registration
+	err := txn.WithTx(ctx, client, func(tx *ent.Tx) (err error) {
+		r, err = transfer(ctx, tx.Client(), carID, to, plate, region, at)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.Unwrap(), nil
+}
+
+func transfer(ctx context.Context, client *ent.Client, carID, to int, plate, region string, at time.Time) (*ent.Registration, error) {
+	current := registration.And(
+		registration.HasCarWith(car.ID(carID)),
+		registration.ValidToIsNil(),
+	)
+	// A transfer must follow the start of the registration it closes,
+	// which would otherwise be closed before, or as soon as, it begins.
+	late, err := client.Registration.Query().
+		Where(current, registration.ValidFromGTE(at)).
+		Exist(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed querying car registration: %w", err)
+	}
+	if late {
+		return nil, fmt.Errorf("car %d was registered at or after %v", carID, at)
+	}
+	if _, err := client.Registration.Update().
+		Where(current).
+		SetValidTo(at).
+		Save(ctx); err != nil {
+		return nil, fmt.Errorf("failed closing car registration: %w", err)
+	}
+	r, err := client.Registration.Create().
+		SetCarID(carID).
+		SetOwnerID(to).
+		SetPlate(plate).
+		SetRegion(region).
+		SetValidFrom(at).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating car registration: %w", err)
+	}
+	// The car changes hands last, since the
+	// previous owner is the one allowed to transfer it.
+	if err := client.Car.UpdateOneID(carID).
+		SetOwnerID(to).
+		Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed updating car owner: %w", err)
+	}
+	return r, nil
+}
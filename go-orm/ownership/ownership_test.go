@@ -0,0 +1,167 @@
+package ownership
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/enttest"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/registration"
+	_ "github.com/anjanashankar9/go-learning/go-orm/ent/runtime"
+	"github.com/anjanashankar9/go-learning/go-orm/viewer"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openClient(t *testing.T) *ent.Client {
+	client := enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name()))
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+var (
+	t0 = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 = t0.AddDate(1, 0, 0)
+	t2 = t1.AddDate(1, 0, 0)
+)
+
+// seed creates a car registered to ada at t0, transferred to bob at t1
+// and to cid at t2.
+func seed(t *testing.T, client *ent.Client) (context.Context, *ent.Car, []*ent.User) {
+	t.Helper()
+	ctx := viewer.AdminContext(context.Background())
+	var users []*ent.User
+	for _, name := range []string{"ada", "bob", "cid"} {
+		users = append(users, client.User.Create().SetName(name).SetAge(30).SaveX(ctx))
+	}
+	c := client.Car.Create().SetModel("Tesla").SetRegisteredAt(t0).SetOwner(users[0]).SaveX(ctx)
+	for i, at := range []time.Time{t0, t1, t2} {
+		if _, err := Transfer(ctx, client, c.ID, users[i].ID, fmt.Sprint("PLATE ", i), "UK", at); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return ctx, c, users
+}
+
+func TestOwnerAt(t *testing.T) {
+	client := openClient(t)
+	ctx, c, users := seed(t, client)
+	for _, tt := range []struct {
+		at   time.Time
+		want int // index of the owner, or -1
+	}{
+		{t0.Add(-time.Nanosecond), -1},
+		{t0, 0},
+		{t1.Add(-time.Nanosecond), 0},
+		{t1, 1},
+		{t2.Add(-time.Nanosecond), 1},
+		{t2, 2},
+		{t2.AddDate(10, 0, 0), 2},
+	} {
+		u, err := OwnerAt(ctx, client, c.ID, tt.at)
+		switch {
+		case tt.want == -1:
+			if !ent.IsNotFound(err) {
+				t.Errorf("owner at %v = %v, %v, want not found", tt.at, u, err)
+			}
+		case err != nil || u.ID != users[tt.want].ID:
+			t.Errorf("owner at %v = %v, %v, want %s", tt.at, u, err, users[tt.want].Name)
+		}
+	}
+}
+
+func TestCarsOwnedAt(t *testing.T) {
+	client := openClient(t)
+	ctx, c, users := seed(t, client)
+	for _, tt := range []struct {
+		user int
+		at   time.Time
+		want int
+	}{
+		{0, t0, 1},
+		{0, t1.Add(-time.Nanosecond), 1},
+		{0, t1, 0},
+		{1, t1, 1},
+		{1, t2, 0},
+		{2, t2, 1},
+		{2, t1, 0},
+	} {
+		cars, err := CarsOwnedAt(ctx, client, users[tt.user].ID, tt.at)
+		if err != nil || len(cars) != tt.want || tt.want == 1 && cars[0].ID != c.ID {
+			t.Errorf("cars of %s at %v = %v, %v, want %d", users[tt.user].Name, tt.at, cars, err, tt.want)
+		}
+	}
+	// A registration is valid to valid_to exclusive: one at each instant.
+	for _, at := range []time.Time{t1, t2} {
+		if n := client.Registration.Query().Where(ValidAt(at)).CountX(ctx); n != 1 {
+			t.Errorf("%d registrations valid at %v, want 1", n, at)
+		}
+	}
+}
+
+func TestHistory(t *testing.T) {
+	client := openClient(t)
+	ctx, c, users := seed(t, client)
+	rs, err := History(ctx, client, c.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rs) != 3 {
+		t.Fatalf("%d registrations, want 3", len(rs))
+	}
+	for i, r := range rs {
+		from := []time.Time{t0, t1, t2}[i]
+		if r.Edges.Owner == nil || r.Edges.Owner.ID != users[i].ID || !r.ValidFrom.Equal(from) {
+			t.Errorf("registration %d = %v, owner %v, want %s from %v", i, r, r.Edges.Owner, users[i].Name, from)
+		}
+		if i < 2 && (r.ValidTo == nil || !r.ValidTo.Equal(rs[i+1].ValidFrom)) {
+			t.Errorf("registration %d valid to %v, want the start of the next", i, r.ValidTo)
+		}
+	}
+	if rs[2].ValidTo != nil {
+		t.Errorf("current registration valid to %v, want nil", rs[2].ValidTo)
+	}
+	if owner := c.QueryOwner().OnlyX(ctx); owner.ID != users[2].ID {
+		t.Errorf("car owned by %s, want cid", owner.Name)
+	}
+}
+
+func TestTransferNotAfterCurrent(t *testing.T) {
+	client := openClient(t)
+	ctx, c, users := seed(t, client)
+	// A transfer at the start of the current registration would close it
+	// as an empty one, and one before it would close it before it begins.
+	for _, at := range []time.Time{t2, t1} {
+		if _, err := Transfer(ctx, client, c.ID, users[0].ID, "ADA 2", "UK", at); err == nil {
+			t.Errorf("transfer at %v accepted", at)
+		}
+	}
+	if n := client.Registration.Query().CountX(ctx); n != 3 {
+		t.Errorf("%d registrations, want 3", n)
+	}
+	if _, err := Transfer(ctx, client, c.ID, users[0].ID, "ADA 2", "UK", t2.Add(time.Nanosecond)); err != nil {
+		t.Errorf("transfer right after the current registration = %v", err)
+	}
+}
+
+func TestTransferRollback(t *testing.T) {
+	client := openClient(t)
+	ctx, c, users := seed(t, client)
+	// The current registration is closed, but the new one is of
+	// a user that does not exist: the closing is rolled back.
+	if _, err := Transfer(ctx, client, c.ID, 1000, "NONE", "UK", t2.AddDate(1, 0, 0)); err == nil {
+		t.Fatal("transfer to a missing user accepted")
+	}
+	r, err := client.Registration.Query().Where(registration.ValidToIsNil()).Only(ctx)
+	if err != nil || !r.ValidFrom.Equal(t2) {
+		t.Errorf("current registration = %v, %v, want the one from %v", r, err, t2)
+	}
+	if n := client.Registration.Query().CountX(ctx); n != 3 {
+		t.Errorf("%d registrations, want 3", n)
+	}
+	if owner := c.QueryOwner().OnlyX(ctx); owner.ID != users[2].ID {
+		t.Errorf("car owned by %s, want cid", owner.Name)
+	}
+}
@@ -0,0 +1,73 @@
+package pagination
+
+import (
+	"time"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/group"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/predicate"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+)
+
+func userID(u *ent.User) int   { return u.ID }
+func carID(c *ent.Car) int     { return c.ID }
+func groupID(g *ent.Group) int { return g.ID }
+
+// Orderings for the User entity.
+var (
+	UserByID = Order[*ent.User, predicate.User, int]{
+		Field: user.FieldID,
+		Value: userID,
+		ID:    userID,
+	}
+	UserByName = Order[*ent.User, predicate.User, string]{
+		Field: user.FieldName,
+		Value: func(u *ent.User) string { return u.Name },
+		ID:    userID,
+	}
+	UserByAge = Order[*ent.User, predicate.User, int]{
+		Field: user.FieldAge,
+		Value: func(u *ent.User) int { return u.Age },
+		ID:    userID,
+	}
+)
+
+// Orderings for the Car entity.
+var (
+	CarByID = Order[*ent.Car, predicate.Car, int]{
+		Field: car.FieldID,
+		Value: carID,
+		ID:    carID,
+	}
+	CarByModel = Order[*ent.Car, predicate.Car, string]{
+		Field: car.FieldModel,
+		Value: func(c *ent.Car) string { return c.Model },
+		ID:    carID,
+	}
+	CarByRegisteredAt = Order[*ent.Car, predicate.Car, time.Time]{
+		Field: car.FieldRegisteredAt,
+		Value: func(c *ent.Car) time.Time { return c.RegisteredAt },
+		ID:    carID,
+	}
+)
+
+// Orderings for the Group entity.
+var (
+	GroupByID = Order[*ent.Group, predicate.Group, int]{
+		Field: group.FieldID,
+		Value: groupID,
+		ID:    groupID,
+	}
+	GroupByName = Order[*ent.Group, predicate.Group, string]{
+		Field: group.FieldName,
+		Value: func(g *ent.Group) string { return g.Name },
+		ID:    groupID,
+	}
+)
+
+// Reverse returns o with the ordering flipped.
+func (o Order[T, P, V]) Reverse() Order[T, P, V] {
+	o.Desc = !o.Desc
+	return o
+}
@@ -0,0 +1,218 @@
+// Package pagination implements keyset (cursor based) pagination on top of
+// the ent query builders.
+//
+// Unlike Limit/Offset, a keyset page is located by the ordering values of
+// the last row that was seen, so pages stay stable while rows are inserted
+// or deleted and the database never has to skip over the previous pages.
+package pagination
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+)
+
+// DefaultLimit is the page size used when Args.Limit is not set.
+const DefaultLimit = 20
+
+// idColumn is the tie-breaker column shared by all the entities.
+const idColumn = "id"
+
+// ErrInvalidCursor is returned when a cursor could not be decoded.
+var ErrInvalidCursor = errors.New("pagination: invalid cursor")
+
+// Query is implemented by the generated query builders
+// (*ent.UserQuery, *ent.CarQuery and *ent.GroupQuery).
+// T is the entity type, Q the builder itself and P its predicate type.
+type Query[T any, Q any, P ~func(*sql.Selector)] interface {
+	Where(...P) Q
+	Order(...ent.OrderFunc) Q
+	Limit(int) Q
+	Clone() Q
+	All(context.Context) ([]T, error)
+	IDs(context.Context) ([]int, error)
+}
+
+// Order describes the key a paginated query is sorted by.
+// Rows with equal values are ordered by their id, so the ordering is total.
+type Order[T any, P ~func(*sql.Selector), V any] struct {
+	// Field is the column the query is sorted by.
+	Field string
+	// Desc reverses the ordering.
+	Desc bool
+	// Value returns the value of Field for an entity.
+	Value func(T) V
+	// ID returns the id of an entity.
+	ID func(T) int
+}
+
+// Args selects a page. At most one of After and Before may be set.
+type Args struct {
+	// Limit is the maximum number of rows in the page.
+	Limit int
+	// After returns the rows that follow the given cursor.
+	After string
+	// Before returns the rows that precede the given cursor.
+	Before string
+}
+
+// Page is one page of results.
+type Page[T any] struct {
+	Items []T
+	// StartCursor and EndCursor point at the first and the last item.
+	StartCursor string
+	EndCursor   string
+	HasNext     bool
+	HasPrev     bool
+}
+
+// cursor is the decoded form of the opaque cursor strings.
+type cursor[V any] struct {
+	ID    int `json:"i"`
+	Value V   `json:"v"`
+}
+
+// Paginate returns the page of q selected by args, sorted by o.
+// Eager loading (WithCars, WithGroups, ...) and any other filters
+// configured on q are preserved, so q should not have an ordering
+// or a limit of its own.
+func Paginate[T any, Q Query[T, Q, P], P ~func(*sql.Selector), V any](ctx context.Context, q Q, o Order[T, P, V], args Args) (*Page[T], error) {
+	if args.After != "" && args.Before != "" {
+		return nil, fmt.Errorf("pagination: after and before are mutually exclusive")
+	}
+	limit := args.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	// Walking backwards is the same as walking forwards with the
+	// ordering flipped, the rows are put back in order afterwards.
+	backward := args.Before != ""
+	desc := o.Desc != backward
+	raw := args.After
+	if backward {
+		raw = args.Before
+	}
+	// The rows on the other side of the cursor, before the
+	// filters of the page are added to q.
+	var rest Q
+	if raw != "" {
+		c, err := decodeCursor[V](raw)
+		if err != nil {
+			return nil, err
+		}
+		rest = q.Clone().Where(P(from(o.Field, c, !desc)))
+		q = q.Where(P(after(o.Field, c, desc)))
+	}
+	if desc {
+		q = q.Order(ent.Desc(o.Field, idColumn))
+	} else {
+		q = q.Order(ent.Asc(o.Field, idColumn))
+	}
+
+	// Fetch one extra row to find out if there is another page.
+	items, err := q.Limit(limit + 1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	more := len(items) > limit
+	if more {
+		items = items[:limit]
+	}
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	// The cursor row may have been deleted since, or the page be
+	// empty, so whether there are rows behind the cursor is asked.
+	var behind bool
+	if raw != "" {
+		// Not Exist, which counts all the rows.
+		ids, err := rest.Limit(1).IDs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		behind = len(ids) > 0
+	}
+	p := &Page[T]{Items: items}
+	if backward {
+		p.HasPrev, p.HasNext = more, behind
+	} else {
+		p.HasNext, p.HasPrev = more, behind
+	}
+	if len(items) > 0 {
+		if p.StartCursor, err = o.cursor(items[0]); err != nil {
+			return nil, err
+		}
+		if p.EndCursor, err = o.cursor(items[len(items)-1]); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// cursor returns the opaque cursor pointing at v.
+func (o Order[T, P, V]) cursor(v T) (string, error) {
+	b, err := json.Marshal(cursor[V]{ID: o.ID(v), Value: o.Value(v)})
+	if err != nil {
+		return "", fmt.Errorf("pagination: encoding cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeCursor[V any](s string) (cursor[V], error) {
+	var c cursor[V]
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return c, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	return c, nil
+}
+
+// after returns a predicate matching the rows that come after c in the
+// (field, id) ordering:
+//
+//	field >= v AND (field > v OR id > id)
+//
+// which is field > v OR (field = v AND id > id), in a form that lets
+// the database use an index of field.
+func after[V any](field string, c cursor[V], desc bool) func(*sql.Selector) {
+	return keyset(field, c, desc, false)
+}
+
+// from is like after, but matches the row of c too:
+//
+//	field >= v AND (field > v OR id >= id)
+func from[V any](field string, c cursor[V], desc bool) func(*sql.Selector) {
+	return keyset(field, c, desc, true)
+}
+
+func keyset[V any](field string, c cursor[V], desc, inclusive bool) func(*sql.Selector) {
+	gt, gte := sql.GT, sql.GTE
+	if desc {
+		gt, gte = sql.LT, sql.LTE
+	}
+	idCmp := gt
+	if inclusive {
+		idCmp = gte
+	}
+	return func(s *sql.Selector) {
+		s.Where(sql.And(
+			gte(s.C(field), c.Value),
+			sql.Or(
+				gt(s.C(field), c.Value),
+				idCmp(s.C(idColumn), c.ID),
+			),
+		))
+	}
+}
@@ -0,0 +1,191 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/enttest"
+	_ "github.com/anjanashankar9/go-learning/go-orm/ent/runtime"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+	"github.com/anjanashankar9/go-learning/go-orm/viewer"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// openClient returns a client of an in-memory SQLite database of its own.
+func openClient(t enttest.TestingT, name string) *ent.Client {
+	return enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", name))
+}
+
+// seedUsers creates n users, named user00000, user00001...
+func seedUsers(ctx context.Context, client *ent.Client, n int) error {
+	const batch = 1000
+	for i := 0; i < n; i += batch {
+		var bulk []*ent.UserCreate
+		for j := i; j < i+batch && j < n; j++ {
+			bulk = append(bulk, client.User.Create().SetName(fmt.Sprintf("user%05d", j)).SetAge(j%90+1))
+		}
+		if _, err := client.User.CreateBulk(bulk...).Save(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func names(users []*ent.User) []string {
+	var s []string
+	for _, u := range users {
+		s = append(s, u.Name)
+	}
+	return s
+}
+
+func TestPaginate(t *testing.T) {
+	client := openClient(t, t.Name())
+	defer client.Close()
+	ctx := viewer.AdminContext(context.Background())
+	if err := seedUsers(ctx, client, 25); err != nil {
+		t.Fatal(err)
+	}
+	page := func(args Args) *Page[*ent.User] {
+		t.Helper()
+		p, err := Paginate(ctx, client.User.Query(), UserByName, args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	check := func(p *Page[*ent.User], first string, n int, hasPrev, hasNext bool) {
+		t.Helper()
+		if len(p.Items) != n || n > 0 && p.Items[0].Name != first {
+			t.Errorf("page = %v, want %d users from %s", names(p.Items), n, first)
+		}
+		if p.HasPrev != hasPrev || p.HasNext != hasNext {
+			t.Errorf("page from %s: HasPrev, HasNext = %v, %v, want %v, %v", first, p.HasPrev, p.HasNext, hasPrev, hasNext)
+		}
+	}
+
+	// Forwards.
+	p1 := page(Args{Limit: 10})
+	check(p1, "user00000", 10, false, true)
+	p2 := page(Args{Limit: 10, After: p1.EndCursor})
+	check(p2, "user00010", 10, true, true)
+	p3 := page(Args{Limit: 10, After: p2.EndCursor})
+	check(p3, "user00020", 5, true, false)
+	check(page(Args{Limit: 10, After: p3.EndCursor}), "", 0, true, false)
+
+	// Backwards, from the last page.
+	b2 := page(Args{Limit: 10, Before: p3.StartCursor})
+	check(b2, "user00010", 10, true, true)
+	b1 := page(Args{Limit: 10, Before: b2.StartCursor})
+	check(b1, "user00000", 10, false, true)
+	check(page(Args{Limit: 10, Before: b1.StartCursor}), "", 0, false, true)
+	check(page(Args{Limit: 20, Before: p2.EndCursor}), "user00000", 19, false, true)
+
+	// The cursor row deleted: the flags come from the rows left.
+	last := p3.Items[len(p3.Items)-1]
+	if err := client.User.DeleteOne(last).Exec(ctx); err != nil {
+		t.Fatal(err)
+	}
+	check(page(Args{Limit: 10, Before: p3.EndCursor}), "user00014", 10, true, false)
+
+	// Descending.
+	d, err := Paginate(ctx, client.User.Query(), UserByName.Reverse(), Args{Limit: 10, After: p2.StartCursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	check(d, "user00009", 10, true, false)
+	if _, err := Paginate(ctx, client.User.Query(), UserByName, Args{After: "not a cursor"}); err == nil {
+		t.Error("Paginate accepted an invalid cursor")
+	}
+}
+
+func TestPaginateEagerLoading(t *testing.T) {
+	client := openClient(t, t.Name())
+	defer client.Close()
+	ctx := viewer.AdminContext(context.Background())
+	if err := seedUsers(ctx, client, 3); err != nil {
+		t.Fatal(err)
+	}
+	u := client.User.Query().Where(user.Name("user00001")).OnlyX(ctx)
+	client.Car.Create().SetModel("Tesla").SetRegisteredAt(time.Now()).SetOwner(u).ExecX(ctx)
+	p, err := Paginate(ctx, client.User.Query().WithCars().Where(user.NameNEQ("user00000")), UserByName, Args{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Items) != 1 || p.Items[0].Name != "user00001" || len(p.Items[0].Edges.Cars) != 1 {
+		t.Fatalf("page = %v, want user00001 with its car", p.Items)
+	}
+	if p.HasPrev || !p.HasNext {
+		t.Errorf("HasPrev, HasNext = %v, %v, want false, true", p.HasPrev, p.HasNext)
+	}
+}
+
+// benchRows is the number of users of the benchmarks.
+const benchRows = 100000
+
+var bench struct {
+	once   sync.Once
+	client *ent.Client
+	err    error
+}
+
+// benchClient returns the client of a database of benchRows users,
+// shared by the benchmarks.
+func benchClient(b *testing.B) *ent.Client {
+	bench.once.Do(func() {
+		bench.client = openClient(b, "bench")
+		bench.err = seedUsers(viewer.AdminContext(context.Background()), bench.client, benchRows)
+	})
+	if bench.err != nil {
+		b.Fatal(bench.err)
+	}
+	return bench.client
+}
+
+// The benchmarks read the page of 20 users at a depth of the table,
+// by keyset and by offset, ordered by id.
+var benchDepths = []int{0, 1000, 10000, 50000, 99000}
+
+func BenchmarkKeyset(b *testing.B) {
+	client := benchClient(b)
+	ctx := viewer.AdminContext(context.Background())
+	for _, depth := range benchDepths {
+		var args Args
+		if depth > 0 {
+			u := client.User.Query().Order(ent.Asc(user.FieldID)).Offset(depth - 1).FirstX(ctx)
+			c, err := UserByID.cursor(u)
+			if err != nil {
+				b.Fatal(err)
+			}
+			args.After = c
+		}
+		args.Limit = 20
+		b.Run(fmt.Sprint(depth), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := Paginate(ctx, client.User.Query(), UserByID, args); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkOffset(b *testing.B) {
+	client := benchClient(b)
+	ctx := viewer.AdminContext(context.Background())
+	for _, depth := range benchDepths {
+		b.Run(fmt.Sprint(depth), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := client.User.Query().Order(ent.Asc(user.FieldID)).Offset(depth).Limit(20).All(ctx)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package replica
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+	"time"
+)
+
+// LagPostgres measures the lag of a Postgres replica as the time since
+// the last transaction it replayed. It is zero on a primary, and on a
+// replica that replayed all the WAL it received: when the primary has no
+// writes, the last transaction replayed gets old, but the replica is not
+// behind.
+func LagPostgres(ctx context.Context, db *sql.DB) (time.Duration, error) {
+	var seconds float64
+	err := db.QueryRowContext(ctx, `SELECT CASE
+	WHEN NOT pg_is_in_recovery() THEN 0
+	WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END`).Scan(&seconds)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// watchLag checks the lag of the replicas every interval until Close.
+func (d *Driver) watchLag(lag func(context.Context, *sql.DB) (time.Duration, error), max, interval time.Duration) {
+	defer d.wg.Done()
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-d.stop:
+			return
+		case <-t.C:
+			d.checkLag(lag, max)
+		}
+	}
+}
+
+// checkLag marks the replicas that lag by more than max, or whose lag
+// cannot be measured, as unhealthy, and the others as healthy.
+func (d *Driver) checkLag(lag func(context.Context, *sql.DB) (time.Duration, error), max time.Duration) {
+	for _, r := range d.replicas {
+		ctx, cancel := context.WithTimeout(context.Background(), max)
+		l, err := lag(ctx, r.drv.DB())
+		cancel()
+		var healthy int32
+		if err == nil && l <= max {
+			healthy = 1
+		}
+		atomic.StoreInt32(&r.healthy, healthy)
+	}
+}
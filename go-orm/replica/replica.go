@@ -0,0 +1,282 @@
+// Package replica opens an ent driver over a primary database and its
+// read replicas.
+//
+// Writes and transactions go to the primary. Reads outside transactions
+// go to the replicas in turn, skipping those that lag too far behind, and
+// to the primary when no replica is usable:
+//
+//	drv, err := replica.Open(replica.Config{
+//		Driver:   dialect.Postgres,
+//		Primary:  "host=primary ...",
+//		Replicas: []string{"host=replica1 ...", "host=replica2 ..."},
+//		Pool:     replica.Pool{MaxOpenConns: 20, MaxIdleConns: 5},
+//		MaxLag:   5 * time.Second,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	client := ent.NewClient(ent.Driver(drv))
+//
+// Use Primary to read from the primary, for example right after a write.
+package replica
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+)
+
+// Config describes the databases of a Driver.
+type Config struct {
+	// Driver is the database/sql driver name, such as "postgres".
+	Driver string
+	// Primary and Replicas are the data source names of the databases.
+	Primary  string
+	Replicas []string
+	// Pool configures the connection pool of each database.
+	Pool Pool
+	// Timeout bounds the time a statement may run, if not zero.
+	Timeout time.Duration
+	// MaxLag is the replication lag beyond which a replica is not read
+	// from. Lag is checked every LagInterval, and not at all if MaxLag
+	// is zero or the dialect has no way of measuring it.
+	MaxLag      time.Duration
+	LagInterval time.Duration
+	// Lag measures the lag of a replica. It defaults to LagPostgres
+	// for Postgres.
+	Lag func(ctx context.Context, db *sql.DB) (time.Duration, error)
+}
+
+// Pool configures a database/sql connection pool.
+// Zero values keep the database/sql defaults.
+type Pool struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// DefaultLagInterval is the LagInterval used when Config.LagInterval is not set.
+const DefaultLagInterval = time.Second
+
+// Driver is a dialect.Driver routing the statements between
+// a primary database and its replicas.
+type Driver struct {
+	primary  *entsql.Driver
+	replicas []*replica
+	timeout  time.Duration
+	next     uint32
+
+	stop      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// replica is a replica database and its health.
+type replica struct {
+	drv *entsql.Driver
+	// healthy is 1 when the replica can be read from.
+	healthy int32
+}
+
+// Open opens the databases of cfg and returns a Driver over them.
+func Open(cfg Config) (*Driver, error) {
+	primary, err := open(cfg.Driver, cfg.Primary, cfg.Pool)
+	if err != nil {
+		return nil, fmt.Errorf("replica: opening primary: %w", err)
+	}
+	d := &Driver{primary: primary, timeout: cfg.Timeout, stop: make(chan struct{})}
+	for i, dsn := range cfg.Replicas {
+		drv, err := open(cfg.Driver, dsn, cfg.Pool)
+		if err != nil {
+			d.Close()
+			return nil, fmt.Errorf("replica: opening replica %d: %w", i, err)
+		}
+		d.replicas = append(d.replicas, &replica{drv: drv, healthy: 1})
+	}
+	lag := cfg.Lag
+	if lag == nil && cfg.Driver == dialect.Postgres {
+		lag = LagPostgres
+	}
+	if cfg.MaxLag > 0 && lag != nil && len(d.replicas) > 0 {
+		interval := cfg.LagInterval
+		if interval <= 0 {
+			interval = DefaultLagInterval
+		}
+		d.checkLag(lag, cfg.MaxLag)
+		d.wg.Add(1)
+		go d.watchLag(lag, cfg.MaxLag, interval)
+	}
+	return d, nil
+}
+
+func open(driver, dsn string, p Pool) (*entsql.Driver, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if p.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(p.MaxOpenConns)
+	}
+	if p.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(p.MaxIdleConns)
+	}
+	if p.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(p.ConnMaxLifetime)
+	}
+	if p.ConnMaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(p.ConnMaxIdleTime)
+	}
+	return entsql.OpenDB(driver, db), nil
+}
+
+// Exec runs the statement on the primary.
+func (d *Driver) Exec(ctx context.Context, query string, args, v interface{}) error {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+	return d.primary.Exec(ctx, query, args, v)
+}
+
+// Query runs the statement on a replica if it is a read, and on the
+// primary otherwise, since writes returning rows go through Query too.
+func (d *Driver) Query(ctx context.Context, query string, args, v interface{}) error {
+	ctx, cancel := d.withTimeout(ctx)
+	drv := d.primary
+	if isRead(query) && !usePrimary(ctx) {
+		drv = d.reader()
+	}
+	err := drv.Query(ctx, query, args, v)
+	// The rows are read after Query returns, so the context is canceled
+	// when they are closed. The schema migration needs the rows of the
+	// catalog to be *sql.Rows: their context is released at its deadline.
+	rows, ok := v.(*entsql.Rows)
+	switch {
+	case err != nil || !ok:
+		cancel()
+	case d.timeout > 0 && !isCatalog(query):
+		rows.ColumnScanner = &cancelRows{ColumnScanner: rows.ColumnScanner, cancel: cancel}
+	}
+	return err
+}
+
+// cancelRows cancels the context of its
+// statement when the rows are closed.
+type cancelRows struct {
+	entsql.ColumnScanner
+	cancel context.CancelFunc
+}
+
+func (r *cancelRows) Close() error {
+	err := r.ColumnScanner.Close()
+	r.cancel()
+	return err
+}
+
+// Tx starts a transaction on the primary.
+func (d *Driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	return d.primary.Tx(ctx)
+}
+
+// BeginTx starts a transaction with options on the primary.
+func (d *Driver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	return d.primary.BeginTx(ctx, opts)
+}
+
+// Dialect returns the dialect of the databases.
+func (d *Driver) Dialect() string {
+	return d.primary.Dialect()
+}
+
+// Close stops checking the lag and closes all the databases.
+// Only the first call closes them, and the others return nil.
+func (d *Driver) Close() error {
+	var err error
+	d.closeOnce.Do(func() { err = d.close() })
+	return err
+}
+
+func (d *Driver) close() error {
+	close(d.stop)
+	d.wg.Wait()
+	var errs []string
+	for _, drv := range d.drivers() {
+		if err := drv.Close(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New("replica: closing: " + strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// DB returns the primary database.
+func (d *Driver) DB() *sql.DB {
+	return d.primary.DB()
+}
+
+func (d *Driver) drivers() []*entsql.Driver {
+	drvs := []*entsql.Driver{d.primary}
+	for _, r := range d.replicas {
+		drvs = append(drvs, r.drv)
+	}
+	return drvs
+}
+
+// reader returns the next healthy replica, or the primary if there is none.
+func (d *Driver) reader() *entsql.Driver {
+	n := len(d.replicas)
+	start := atomic.AddUint32(&d.next, 1)
+	for i := 0; i < n; i++ {
+		r := d.replicas[(int(start)+i)%n]
+		if atomic.LoadInt32(&r.healthy) == 1 {
+			return r.drv
+		}
+	}
+	return d.primary
+}
+
+func (d *Driver) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if d.timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d.timeout)
+}
+
+// isRead reports whether the statement only reads.
+func isRead(query string) bool {
+	q := strings.TrimSpace(query)
+	return len(q) >= 6 && strings.EqualFold(q[:6], "SELECT")
+}
+
+// catalogRE matches the statements reading the catalog of the database:
+// its tables and views, and the SHOW and PRAGMA statements.
+var catalogRE = regexp.MustCompile(`(?i)\b(?:information_schema|pg_\w+|sqlite_\w+|pragma_\w+)\b|^\s*(?:SHOW|PRAGMA)\b`)
+
+// isCatalog reports whether the statement reads the catalog
+// of the database, as the schema migration does.
+func isCatalog(query string) bool {
+	return catalogRE.MatchString(query)
+}
+
+type primaryKey struct{}
+
+// Primary returns a context whose queries are run on the primary,
+// to read the writes that replicas may not have received yet.
+func Primary(parent context.Context) context.Context {
+	return context.WithValue(parent, primaryKey{}, true)
+}
+
+func usePrimary(ctx context.Context) bool {
+	v, _ := ctx.Value(primaryKey{}).(bool)
+	return v
+}
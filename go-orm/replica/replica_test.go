@@ -0,0 +1,289 @@
+package replica
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// openFiles creates the SQLite files of a primary and n replicas, each
+// with a table naming the database, and returns their data source names.
+func openFiles(t *testing.T, n int) (primary string, replicas []string) {
+	t.Helper()
+	dir := t.TempDir()
+	names := []string{"primary"}
+	for i := 0; i < n; i++ {
+		names = append(names, "replica"+string(rune('1'+i)))
+	}
+	var dsns []string
+	for _, name := range names {
+		dsn := "file:" + filepath.Join(dir, name+".db") + "?_fk=1"
+		db, err := sql.Open(dialect.SQLite, dsn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = db.Exec(`CREATE TABLE db (name TEXT); INSERT INTO db VALUES (?)`, name)
+		db.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		dsns = append(dsns, dsn)
+	}
+	return dsns[0], dsns[1:]
+}
+
+// name returns the name of the database the query of the table runs on.
+func name(t *testing.T, ctx context.Context, drv dialect.ExecQuerier) string {
+	t.Helper()
+	var rows entsql.Rows
+	if err := drv.Query(ctx, "SELECT name FROM db", []interface{}{}, &rows); err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var names []string
+	if err := entsql.ScanSlice(&rows, &names); err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 1 {
+		t.Fatalf("names = %q, want one", names)
+	}
+	return names[0]
+}
+
+func TestRouting(t *testing.T) {
+	primary, replicas := openFiles(t, 2)
+	d, err := Open(Config{Driver: dialect.SQLite, Primary: primary, Replicas: replicas, Pool: Pool{MaxOpenConns: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	ctx := context.Background()
+
+	// Reads go to the replicas in turn.
+	seen := make(map[string]int)
+	for i := 0; i < 4; i++ {
+		seen[name(t, ctx, d)]++
+	}
+	if seen["replica1"] != 2 || seen["replica2"] != 2 {
+		t.Errorf("reads went to %v, want 2 on each replica", seen)
+	}
+	if got := name(t, Primary(ctx), d); got != "primary" {
+		t.Errorf("read with Primary went to %s", got)
+	}
+
+	// Writes, and writes returning rows, go to the primary.
+	if err := d.Exec(ctx, "UPDATE db SET name = ?", []interface{}{"written"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	var rows entsql.Rows
+	if err := d.Query(ctx, "INSERT INTO db VALUES (?) RETURNING name", []interface{}{"returned"}, &rows); err != nil {
+		t.Fatal(err)
+	}
+	var returned []string
+	if err := entsql.ScanSlice(&rows, &returned); err != nil || len(returned) != 1 {
+		t.Errorf("returned %q (%v), want one name", returned, err)
+	}
+	rows.Close()
+	var n int
+	if err := d.DB().QueryRow(`SELECT COUNT(*) FROM db WHERE name IN ('written', 'returned')`).Scan(&n); err != nil || n != 2 {
+		t.Errorf("primary has %d of the rows written (%v), want 2", n, err)
+	}
+
+	// Transactions run on the primary, reads included.
+	tx, err := d.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Exec(ctx, "DELETE FROM db WHERE name = ?", []interface{}{"returned"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := name(t, ctx, tx); got != "written" {
+		t.Errorf("read in a transaction went to %s", got)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLag(t *testing.T) {
+	primary, replicas := openFiles(t, 2)
+	var mu sync.Mutex
+	lags := make(map[*sql.DB]time.Duration)
+	errs := make(map[*sql.DB]error)
+	lagOf := func(ctx context.Context, db *sql.DB) (time.Duration, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return lags[db], errs[db]
+	}
+	d, err := Open(Config{
+		Driver:      dialect.SQLite,
+		Primary:     primary,
+		Replicas:    replicas,
+		MaxLag:      time.Second,
+		LagInterval: time.Hour, // the test checks the lag itself
+		Lag:         lagOf,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	ctx := context.Background()
+	set := func(i int, lag time.Duration, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		db := d.replicas[i].drv.DB()
+		lags[db], errs[db] = lag, err
+	}
+	check := func(want ...string) {
+		t.Helper()
+		d.checkLag(lagOf, time.Second)
+		seen := make(map[string]bool)
+		for i := 0; i < 4; i++ {
+			seen[name(t, ctx, d)] = true
+		}
+		var got []string
+		for n := range seen {
+			got = append(got, n)
+		}
+		sort.Strings(got)
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("reads went to %q, want %q", got, want)
+		}
+	}
+	check("replica1", "replica2")
+	set(0, 2*time.Second, nil)
+	check("replica2")
+	set(1, 0, errors.New("connection refused"))
+	check("primary")
+	set(0, time.Second, nil)
+	check("replica1")
+}
+
+func TestTimeout(t *testing.T) {
+	primary, _ := openFiles(t, 0)
+	d, err := Open(Config{Driver: dialect.SQLite, Primary: primary, Timeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	// The rows are read after Query returns, and before the timeout.
+	if got := name(t, context.Background(), d); got != "primary" {
+		t.Errorf("read went to %s", got)
+	}
+	const slow = `WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n) SELECT COUNT(*) FROM n`
+	var rows entsql.Rows
+	err = d.Query(context.Background(), slow, []interface{}{}, &rows)
+	if err == nil {
+		for rows.Next() {
+		}
+		err = rows.Err()
+		rows.Close()
+	}
+	if !errors.Is(err, context.DeadlineExceeded) && (err == nil || err.Error() != "interrupted") {
+		t.Errorf("endless query = %v, want it interrupted", err)
+	}
+}
+
+// TestTimeoutCanceled checks that the context of a statement is
+// canceled when its rows are closed, and not before.
+func TestTimeoutCanceled(t *testing.T) {
+	primary, _ := openFiles(t, 0)
+	d, err := Open(Config{Driver: dialect.SQLite, Primary: primary, Timeout: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	var rows entsql.Rows
+	if err := d.Query(context.Background(), "SELECT name FROM db", []interface{}{}, &rows); err != nil {
+		t.Fatal(err)
+	}
+	cr, ok := rows.ColumnScanner.(*cancelRows)
+	if !ok {
+		t.Fatalf("rows of %T, want them canceling the context", rows.ColumnScanner)
+	}
+	canceled := 0
+	cancel := cr.cancel
+	cr.cancel = func() { canceled++; cancel() }
+	var names []string
+	if err := entsql.ScanSlice(&rows, &names); err != nil || len(names) != 1 {
+		t.Errorf("names = %q, %v, want the one of the primary", names, err)
+	}
+	if canceled != 0 {
+		t.Error("context canceled before the rows are closed")
+	}
+	rows.Close()
+	if canceled != 1 {
+		t.Errorf("context canceled %d times, want once", canceled)
+	}
+
+	// The schema migration reads the catalog as *sql.Rows.
+	rows = entsql.Rows{}
+	if err := d.Query(context.Background(), "SELECT name FROM sqlite_master", []interface{}{}, &rows); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := rows.ColumnScanner.(*sql.Rows); !ok {
+		t.Errorf("rows of the catalog of %T, want *sql.Rows", rows.ColumnScanner)
+	}
+	rows.Close()
+}
+
+func TestCloseTwice(t *testing.T) {
+	primary, replicas := openFiles(t, 1)
+	d, err := Open(Config{
+		Driver:   dialect.SQLite,
+		Primary:  primary,
+		Replicas: replicas,
+		MaxLag:   time.Second,
+		Lag:      func(context.Context, *sql.DB) (time.Duration, error) { return 0, nil },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Close(); err != nil {
+		t.Errorf("second Close = %v, want nil", err)
+	}
+}
+
+func TestIsCatalog(t *testing.T) {
+	for q, want := range map[string]bool{
+		"SELECT name FROM sqlite_master":                  true,
+		"SELECT * FROM pragma_table_info('users')":        true,
+		"SELECT setting FROM pg_settings WHERE name = $1": true,
+		"SELECT * FROM INFORMATION_SCHEMA.COLUMNS":        true,
+		"SHOW server_version_num":                         true,
+		"PRAGMA foreign_keys":                             true,
+		"SELECT * FROM users WHERE name = 'information'":  false,
+	} {
+		if got := isCatalog(q); got != want {
+			t.Errorf("isCatalog(%q) = %v, want %v", q, got, want)
+		}
+	}
+}
+
+func TestIsRead(t *testing.T) {
+	for q, want := range map[string]bool{
+		"SELECT 1":                     true,
+		"  select * FROM users":        true,
+		"INSERT INTO users VALUES (1)": false,
+		"UPDATE users SET age = 1":     false,
+		"WITH x AS (DELETE FROM users RETURNING id) SELECT * FROM x": false,
+	} {
+		if got := isRead(q); got != want {
+			t.Errorf("isRead(%q) = %v, want %v", q, got, want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package rule
+
+import (
+	"context"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/privacy"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+)
+
+// FilterCarsByOwner is a query rule that limits
+// the cars a viewer can read to the ones they own.
+func FilterCarsByOwner() privacy.CarQueryRuleFunc {
+	return func(ctx context.Context, q *ent.CarQuery) error {
+		q.Where(car.HasOwnerWith(user.ID(viewerID(ctx))))
+		return privacy.Skip
+	}
+}
+
+// LimitCarMutationsToOwner is a mutation rule that lets viewers
+// update or delete only their own cars, and create cars only for themselves.
+func LimitCarMutationsToOwner() privacy.CarMutationRuleFunc {
+	return func(ctx context.Context, m *ent.CarMutation) error {
+		vid := viewerID(ctx)
+		switch {
+		case m.Op().Is(ent.OpCreate):
+			if id, ok := m.OwnerID(); ok && id != vid {
+				return denyMutation(m, "cars can only be created for the viewer")
+			}
+		case m.Op().Is(ent.OpUpdateOne | ent.OpDeleteOne):
+			id, _ := m.ID()
+			owned, err := m.Client().Car.Query().
+				Where(car.ID(id), car.HasOwnerWith(user.ID(vid))).
+				Exist(allow(ctx))
+			if err != nil {
+				return err
+			}
+			if !owned {
+				return denyMutation(m, "car is not owned by the viewer")
+			}
+		default:
+			// Bulk updates and deletes skip the cars of other users.
+			m.Where(car.HasOwnerWith(user.ID(vid)))
+		}
+		return privacy.Skip
+	}
+}
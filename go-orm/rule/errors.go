@@ -0,0 +1,36 @@
+package rule
+
+import (
+	"fmt"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/privacy"
+)
+
+// DeniedError is returned by the privacy rules when an operation is
+// not permitted for the viewer. It wraps privacy.Deny, so
+// errors.Is(err, privacy.Deny) holds for it as well.
+type DeniedError struct {
+	// Op is the denied operation. It is zero for queries.
+	Op ent.Op
+	// Type is the type of the entity, e.g. "Car".
+	Type string
+	// Reason explains why the operation was denied.
+	Reason string
+}
+
+func (e *DeniedError) Error() string {
+	if e.Op == 0 {
+		return fmt.Sprintf("rule: query on %s denied: %s", e.Type, e.Reason)
+	}
+	return fmt.Sprintf("rule: %s on %s denied: %s", e.Op, e.Type, e.Reason)
+}
+
+// Unwrap returns privacy.Deny.
+func (e *DeniedError) Unwrap() error {
+	return privacy.Deny
+}
+
+func denyMutation(m ent.Mutation, reason string) error {
+	return &DeniedError{Op: m.Op(), Type: m.Type(), Reason: reason}
+}
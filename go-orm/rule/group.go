@@ -0,0 +1,104 @@
+package rule
+
+import (
+	"context"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/group"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/hook"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/membership"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/privacy"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+	"github.com/anjanashankar9/go-learning/go-orm/viewer"
+)
+
+// FilterGroupsByMember is a query rule that limits the groups
+// a viewer can read to the ones they are a member of.
+func FilterGroupsByMember() privacy.GroupQueryRuleFunc {
+	return func(ctx context.Context, q *ent.GroupQuery) error {
+		q.Where(group.HasUsersWith(user.ID(viewerID(ctx))))
+		return privacy.Skip
+	}
+}
+
+// RequireGroupAdmin is a mutation rule that lets only the admins
+// of a group change it, which includes adding users to it.
+func RequireGroupAdmin() privacy.GroupMutationRuleFunc {
+	return func(ctx context.Context, m *ent.GroupMutation) error {
+		vid := viewerID(ctx)
+		switch {
+		case m.Op().Is(ent.OpCreate):
+			// The creator becomes the first admin, see AddCreatorAsAdmin.
+		case m.Op().Is(ent.OpUpdateOne | ent.OpDeleteOne):
+			id, _ := m.ID()
+			ok, err := isGroupAdmin(ctx, m.Client(), vid, id)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return denyMutation(m, "viewer is not an admin of the group")
+			}
+		default:
+			// Bulk updates and deletes skip the groups the viewer does not administer.
+			m.Where(group.HasMembershipsWith(
+				membership.UserID(vid),
+				membership.RoleEQ(membership.RoleAdmin),
+			))
+		}
+		return privacy.Skip
+	}
+}
+
+// AddCreatorAsAdmin is a hook that makes the viewer
+// creating a group its first admin.
+func AddCreatorAsAdmin() ent.Hook {
+	return hook.On(func(next ent.Mutator) ent.Mutator {
+		return hook.GroupFunc(func(ctx context.Context, m *ent.GroupMutation) (ent.Value, error) {
+			v := viewer.FromContext(ctx)
+			if v == nil || v.Admin {
+				return next.Mutate(ctx, m)
+			}
+			// The creator is added below with the admin role,
+			// so it is dropped from the plain members.
+			ids := m.UsersIDs()
+			m.ResetUsers()
+			for _, id := range ids {
+				if id != v.ID {
+					m.AddUserIDs(id)
+				}
+			}
+			value, err := next.Mutate(ctx, m)
+			if err != nil {
+				return nil, err
+			}
+			err = m.Client().Membership.Create().
+				SetGroupID(value.(*ent.Group).ID).
+				SetUserID(v.ID).
+				SetRole(membership.RoleAdmin).
+				Exec(allow(ctx))
+			if err != nil {
+				return nil, err
+			}
+			return value, nil
+		})
+	}, ent.OpCreate)
+}
+
+// isGroupAdmin reports whether the user is an admin of all the given groups.
+func isGroupAdmin(ctx context.Context, client *ent.Client, uid int, ids ...int) (bool, error) {
+	seen := make(map[int]bool)
+	for _, id := range ids {
+		seen[id] = true
+	}
+	n, err := client.Membership.Query().
+		Where(
+			membership.UserID(uid),
+			membership.GroupIDIn(ids...),
+			membership.RoleEQ(membership.RoleAdmin),
+		).
+		Count(allow(ctx))
+	if err != nil {
+		return false, err
+	}
+	return n == len(seen), nil
+}
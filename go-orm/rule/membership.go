@@ -0,0 +1,45 @@
+package rule
+
+import (
+	"context"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/group"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/membership"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/privacy"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+)
+
+// FilterMembershipsByGroup is a query rule that limits the memberships
+// a viewer can read to the ones of the groups they are a member of.
+func FilterMembershipsByGroup() privacy.MembershipQueryRuleFunc {
+	return func(ctx context.Context, q *ent.MembershipQuery) error {
+		q.Where(membership.HasGroupWith(group.HasUsersWith(user.ID(viewerID(ctx)))))
+		return privacy.Skip
+	}
+}
+
+// RequireMembershipGroupAdmin is a mutation rule that lets only
+// the admins of a group add, change or remove its memberships.
+func RequireMembershipGroupAdmin() privacy.MembershipMutationRuleFunc {
+	return func(ctx context.Context, m *ent.MembershipMutation) error {
+		vid := viewerID(ctx)
+		if m.Op().Is(ent.OpUpdate | ent.OpDelete) {
+			// Bulk updates and deletes skip the groups the viewer does not administer.
+			m.Where(membership.HasGroupWith(group.HasMembershipsWith(
+				membership.UserID(vid),
+				membership.RoleEQ(membership.RoleAdmin),
+			)))
+			return privacy.Skip
+		}
+		id, _ := m.GroupID()
+		ok, err := isGroupAdmin(ctx, m.Client(), vid, id)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return denyMutation(m, "viewer is not an admin of the group")
+		}
+		return privacy.Skip
+	}
+}
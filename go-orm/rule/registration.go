@@ -0,0 +1,61 @@
+package rule
+
+import (
+	"context"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/privacy"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/registration"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+)
+
+// FilterRegistrationsByOwner is a query rule that limits the registrations
+// a viewer can read to their own and to the history of the cars they own.
+func FilterRegistrationsByOwner() privacy.RegistrationQueryRuleFunc {
+	return func(ctx context.Context, q *ent.RegistrationQuery) error {
+		vid := viewerID(ctx)
+		q.Where(registration.Or(
+			registration.HasOwnerWith(user.ID(vid)),
+			registration.HasCarWith(car.HasOwnerWith(user.ID(vid))),
+		))
+		return privacy.Skip
+	}
+}
+
+// LimitRegistrationMutationsToCarOwner is a mutation rule that lets
+// only the current owner of a car change its registrations.
+func LimitRegistrationMutationsToCarOwner() privacy.RegistrationMutationRuleFunc {
+	return func(ctx context.Context, m *ent.RegistrationMutation) error {
+		vid := viewerID(ctx)
+		owned := car.HasOwnerWith(user.ID(vid))
+		switch {
+		case m.Op().Is(ent.OpCreate):
+			id, _ := m.CarID()
+			ok, err := m.Client().Car.Query().
+				Where(car.ID(id), owned).
+				Exist(allow(ctx))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return denyMutation(m, "car is not owned by the viewer")
+			}
+		case m.Op().Is(ent.OpUpdateOne | ent.OpDeleteOne):
+			id, _ := m.ID()
+			ok, err := m.Client().Registration.Query().
+				Where(registration.ID(id), registration.HasCarWith(owned)).
+				Exist(allow(ctx))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return denyMutation(m, "car is not owned by the viewer")
+			}
+		default:
+			// Bulk updates and deletes skip the cars of other users.
+			m.Where(registration.HasCarWith(owned))
+		}
+		return privacy.Skip
+	}
+}
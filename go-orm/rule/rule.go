@@ -0,0 +1,76 @@
+// Package rule holds the privacy rules used by the policies of the ent schema.
+// The viewer is taken from the context, see package viewer.
+package rule
+
+import (
+	"context"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/privacy"
+	"github.com/anjanashankar9/go-learning/go-orm/viewer"
+)
+
+// DenyIfNoViewer is a rule that denies every operation
+// if there is no viewer in the context.
+func DenyIfNoViewer() privacy.QueryMutationRule {
+	return noViewerRule{}
+}
+
+type noViewerRule struct{}
+
+func (noViewerRule) EvalQuery(ctx context.Context, q ent.Query) error {
+	if viewer.FromContext(ctx) == nil {
+		return &DeniedError{Type: queryType(q), Reason: "no viewer in context"}
+	}
+	return privacy.Skip
+}
+
+func (noViewerRule) EvalMutation(ctx context.Context, m ent.Mutation) error {
+	if viewer.FromContext(ctx) == nil {
+		return denyMutation(m, "no viewer in context")
+	}
+	return privacy.Skip
+}
+
+// AllowIfAdmin is a rule that allows every operation to admin viewers.
+func AllowIfAdmin() privacy.QueryMutationRule {
+	return privacy.ContextQueryMutationRule(func(ctx context.Context) error {
+		if v := viewer.FromContext(ctx); v != nil && v.Admin {
+			return privacy.Allow
+		}
+		return privacy.Skip
+	})
+}
+
+// allow returns a context in which the rules are not evaluated.
+// It is used by the rules themselves to look up ownership.
+func allow(ctx context.Context) context.Context {
+	return privacy.DecisionContext(ctx, privacy.Allow)
+}
+
+// viewerID returns the id of the viewer. The rules below
+// are only evaluated after DenyIfNoViewer, so it is always set.
+func viewerID(ctx context.Context) int {
+	return viewer.FromContext(ctx).ID
+}
+
+// queryType returns the type of the entities of the query,
+// for the denials of the rules applied to every schema.
+func queryType(q ent.Query) string {
+	switch q.(type) {
+	case *ent.CarQuery:
+		return ent.TypeCar
+	case *ent.GroupQuery:
+		return ent.TypeGroup
+	case *ent.MembershipQuery:
+		return ent.TypeMembership
+	case *ent.OutboxEventQuery:
+		return ent.TypeOutboxEvent
+	case *ent.RegistrationQuery:
+		return ent.TypeRegistration
+	case *ent.UserQuery:
+		return ent.TypeUser
+	default:
+		return "unknown"
+	}
+}
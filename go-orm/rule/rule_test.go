@@ -0,0 +1,227 @@
+package rule_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/membership"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/outboxevent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/privacy"
+	"github.com/anjanashankar9/go-learning/go-orm/rule"
+	"github.com/anjanashankar9/go-learning/go-orm/viewer"
+)
+
+// denied checks that err is a DeniedError of the operation and type.
+func denied(t *testing.T, what string, err error, op ent.Op, typ string) {
+	t.Helper()
+	var de *rule.DeniedError
+	if !errors.As(err, &de) || !errors.Is(err, privacy.Deny) {
+		t.Errorf("%s = %v, want denied", what, err)
+		return
+	}
+	if de.Op != op || de.Type != typ || de.Reason == "" {
+		t.Errorf("%s denied as %q on %q (%s), want %q on %q", what, de.Op, de.Type, de.Reason, op, typ)
+	}
+}
+
+// people holds the users of the tests, each owning a car: ada and bob
+// are in a group ada administers, and eve is in none.
+type people struct {
+	ada, bob, eve          *ent.User
+	adaCar, bobCar, eveCar *ent.Car
+	group                  *ent.Group
+}
+
+func seedPeople(t *testing.T, client *ent.Client) (people, context.Context) {
+	t.Helper()
+	admin := viewer.AdminContext(context.Background())
+	var p people
+	for _, u := range []struct {
+		user **ent.User
+		car  **ent.Car
+		name string
+	}{{&p.ada, &p.adaCar, "ada"}, {&p.bob, &p.bobCar, "bob"}, {&p.eve, &p.eveCar, "eve"}} {
+		*u.user = client.User.Create().SetName(u.name).SetAge(30).SaveX(admin)
+		*u.car = client.Car.Create().SetModel(u.name).SetRegisteredAt(time.Now()).SetOwner(*u.user).SaveX(admin)
+	}
+	p.group = client.Group.Create().SetName("friends").AddUsers(p.bob).SaveX(viewer.UserContext(admin, p.ada.ID))
+	return p, admin
+}
+
+func exist(_ bool, err error) error { return err }
+
+func TestDenyIfNoViewer(t *testing.T) {
+	client := openClient(t)
+	ctx := context.Background()
+	for _, tt := range []struct {
+		typ string
+		err error
+	}{
+		{ent.TypeCar, exist(client.Car.Query().Exist(ctx))},
+		{ent.TypeGroup, exist(client.Group.Query().Exist(ctx))},
+		{ent.TypeMembership, exist(client.Membership.Query().Exist(ctx))},
+		{ent.TypeOutboxEvent, exist(client.OutboxEvent.Query().Exist(ctx))},
+		{ent.TypeRegistration, exist(client.Registration.Query().Exist(ctx))},
+		{ent.TypeUser, exist(client.User.Query().Exist(ctx))},
+	} {
+		denied(t, "query on "+tt.typ, tt.err, 0, tt.typ)
+	}
+	err := client.User.Create().SetName("ada").SetAge(30).Exec(ctx)
+	denied(t, "create of a user", err, ent.OpCreate, ent.TypeUser)
+	var de *rule.DeniedError
+	if errors.As(err, &de); de == nil || de.Error() != "rule: OpCreate on User denied: no viewer in context" {
+		t.Errorf("error = %q", err)
+	}
+}
+
+func TestCarPolicy(t *testing.T) {
+	client := openClient(t)
+	p, admin := seedPeople(t, client)
+	ctx := viewer.UserContext(context.Background(), p.ada.ID)
+
+	if ids := client.Car.Query().IDsX(ctx); len(ids) != 1 || ids[0] != p.adaCar.ID {
+		t.Errorf("ada sees the cars %v, want only hers", ids)
+	}
+	if n := client.Car.Query().CountX(admin); n != 3 {
+		t.Errorf("admin sees %d cars, want 3", n)
+	}
+	if err := client.Car.UpdateOne(p.adaCar).SetModel("Tesla").Exec(ctx); err != nil {
+		t.Errorf("update of her car = %v", err)
+	}
+	err := client.Car.UpdateOne(p.bobCar).SetModel("Tesla").Exec(ctx)
+	denied(t, "update of bob's car", err, ent.OpUpdateOne, ent.TypeCar)
+	err = client.Car.Create().SetModel("Fiat").SetRegisteredAt(time.Now()).SetOwner(p.bob).Exec(ctx)
+	denied(t, "create of a car for bob", err, ent.OpCreate, ent.TypeCar)
+
+	// The bulk updates leave the cars of the others alone.
+	if n := client.Car.Update().SetModel("Ford").SaveX(ctx); n != 1 {
+		t.Errorf("ada updated %d cars, want 1", n)
+	}
+	if n := client.Car.Query().Where(car.ModelEQ("Ford")).CountX(admin); n != 1 {
+		t.Errorf("%d cars updated, want hers", n)
+	}
+}
+
+func TestUserPolicy(t *testing.T) {
+	client := openClient(t)
+	p, _ := seedPeople(t, client)
+
+	// ada and bob see each other through their group, eve only herself.
+	for _, tt := range []struct {
+		u    *ent.User
+		want int
+	}{{p.ada, 2}, {p.bob, 2}, {p.eve, 1}} {
+		if n := client.User.Query().CountX(viewer.UserContext(context.Background(), tt.u.ID)); n != tt.want {
+			t.Errorf("%s sees %d users, want %d", tt.u.Name, n, tt.want)
+		}
+	}
+	ctx := viewer.UserContext(context.Background(), p.eve.ID)
+	if err := client.User.UpdateOne(p.eve).SetAge(31).Exec(ctx); err != nil {
+		t.Errorf("update of herself = %v", err)
+	}
+	err := client.User.UpdateOne(p.ada).SetAge(31).Exec(ctx)
+	denied(t, "update of another user", err, ent.OpUpdateOne, ent.TypeUser)
+	err = client.User.UpdateOne(p.eve).AddGroups(p.group).Exec(ctx)
+	denied(t, "join of a group", err, ent.OpUpdateOne, ent.TypeUser)
+	err = client.User.UpdateOne(p.eve).AddCars(p.adaCar).Exec(ctx)
+	denied(t, "take of a car", err, ent.OpUpdateOne, ent.TypeUser)
+}
+
+func TestGroupPolicy(t *testing.T) {
+	client := openClient(t)
+	p, _ := seedPeople(t, client)
+	ada := viewer.UserContext(context.Background(), p.ada.ID)
+	bob := viewer.UserContext(context.Background(), p.bob.ID)
+	eve := viewer.UserContext(context.Background(), p.eve.ID)
+
+	if n := client.Group.Query().CountX(bob); n != 1 {
+		t.Errorf("bob sees %d groups, want his", n)
+	}
+	if n := client.Group.Query().CountX(eve); n != 0 {
+		t.Errorf("eve sees %d groups, want none", n)
+	}
+	if err := client.Group.UpdateOne(p.group).SetName("family").Exec(ada); err != nil {
+		t.Errorf("update by the group admin = %v", err)
+	}
+	err := client.Group.UpdateOne(p.group).SetName("enemies").Exec(bob)
+	denied(t, "update by a member", err, ent.OpUpdateOne, ent.TypeGroup)
+	if n := client.Group.Delete().ExecX(bob); n != 0 {
+		t.Errorf("bob deleted %d groups, want none", n)
+	}
+}
+
+func TestMembershipPolicy(t *testing.T) {
+	client := openClient(t)
+	p, admin := seedPeople(t, client)
+	ada := viewer.UserContext(context.Background(), p.ada.ID)
+	bob := viewer.UserContext(context.Background(), p.bob.ID)
+	eve := viewer.UserContext(context.Background(), p.eve.ID)
+
+	if n := client.Membership.Query().CountX(bob); n != 2 {
+		t.Errorf("bob sees %d memberships, want the 2 of his group", n)
+	}
+	if n := client.Membership.Query().CountX(eve); n != 0 {
+		t.Errorf("eve sees %d memberships, want none", n)
+	}
+	err := client.Membership.Create().SetGroup(p.group).SetUser(p.eve).Exec(bob)
+	denied(t, "membership added by a member", err, ent.OpCreate, ent.TypeMembership)
+	if err := client.Membership.Create().SetGroup(p.group).SetUser(p.eve).Exec(ada); err != nil {
+		t.Errorf("membership added by the group admin = %v", err)
+	}
+	m := client.Membership.Query().Where(membership.UserID(p.bob.ID)).OnlyX(admin)
+	err = client.Membership.UpdateOne(m).SetRole(membership.RoleAdmin).Exec(bob)
+	denied(t, "role changed by a member", err, ent.OpUpdateOne, ent.TypeMembership)
+	if n := client.Membership.Delete().ExecX(bob); n != 0 {
+		t.Errorf("bob deleted %d memberships, want none", n)
+	}
+	if n := client.Membership.Query().CountX(admin); n != 3 {
+		t.Errorf("%d memberships, want 3", n)
+	}
+	if err := client.Membership.UpdateOne(m).SetRole(membership.RoleAdmin).Exec(ada); err != nil {
+		t.Errorf("role changed by the group admin = %v", err)
+	}
+}
+
+func TestRegistrationPolicy(t *testing.T) {
+	client := openClient(t)
+	p, _ := seedPeople(t, client)
+	ada := viewer.UserContext(context.Background(), p.ada.ID)
+	bob := viewer.UserContext(context.Background(), p.bob.ID)
+
+	r, err := client.Registration.Create().SetPlate("ADA 1").SetRegion("UK").SetCar(p.adaCar).SetOwner(p.ada).Save(ada)
+	if err != nil {
+		t.Fatalf("registration of her car = %v", err)
+	}
+	err = client.Registration.Create().SetPlate("BOB 1").SetRegion("UK").SetCar(p.adaCar).SetOwner(p.bob).Exec(bob)
+	denied(t, "registration of another's car", err, ent.OpCreate, ent.TypeRegistration)
+	err = client.Registration.UpdateOne(r).SetPlate("BOB 1").Exec(bob)
+	denied(t, "update of another's registration", err, ent.OpUpdateOne, ent.TypeRegistration)
+	if n := client.Registration.Query().CountX(ada); n != 1 {
+		t.Errorf("ada sees %d registrations, want hers", n)
+	}
+	if n := client.Registration.Query().CountX(bob); n != 0 {
+		t.Errorf("bob sees %d registrations, want none", n)
+	}
+}
+
+func TestOutboxEventPolicy(t *testing.T) {
+	client := openClient(t)
+	p, admin := seedPeople(t, client)
+	ctx := viewer.UserContext(context.Background(), p.ada.ID)
+
+	e := client.OutboxEvent.Create().SetOp(outboxevent.OpCreate).SetEntity(ent.TypeUser).SetEntityID(p.ada.ID).SaveX(admin)
+	if n := client.OutboxEvent.Query().CountX(admin); n != 1 {
+		t.Errorf("admin sees %d events, want 1", n)
+	}
+	// The events are for the cdc package and the admins only.
+	if _, err := client.OutboxEvent.Query().All(ctx); !errors.Is(err, privacy.Deny) {
+		t.Errorf("query of a user = %v, want denied", err)
+	}
+	if err := client.OutboxEvent.UpdateOne(e).SetDeliveredAt(time.Now()).Exec(ctx); !errors.Is(err, privacy.Deny) {
+		t.Errorf("update of a user = %v, want denied", err)
+	}
+}
@@ -0,0 +1,220 @@
+package rule
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent/entql"
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/group"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/hook"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/membership"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/privacy"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/registration"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+	"github.com/anjanashankar9/go-learning/go-orm/tenant"
+	"github.com/anjanashankar9/go-learning/go-orm/viewer"
+)
+
+// TenantOf returns the tenant the viewer is bound to. It is the tenant
+// of the context or, if there is none, the default tenant. Admins without
+// a tenant are not bound to any, and false is returned for them.
+func TenantOf(ctx context.Context) (int, bool) {
+	if id, ok := tenant.FromContext(ctx); ok {
+		return id, true
+	}
+	if v := viewer.FromContext(ctx); v != nil && v.Admin {
+		return 0, false
+	}
+	return tenant.Default, true
+}
+
+// FilterTenant is a rule that limits the entities a viewer can read,
+// update and delete to the ones of their tenant.
+func FilterTenant() privacy.QueryMutationRule {
+	return privacy.FilterFunc(func(ctx context.Context, f privacy.Filter) error {
+		id, ok := TenantOf(ctx)
+		if !ok {
+			return privacy.Skip
+		}
+		tf, ok := f.(interface{ WhereTenantID(entql.IntP) })
+		if !ok {
+			return privacy.Denyf("rule: %T has no tenant", f)
+		}
+		tf.WhereTenantID(entql.IntEQ(id))
+		return privacy.Skip
+	})
+}
+
+// FilterMembershipsByTenant is a query rule that limits the memberships
+// a viewer can read to the ones of the users of their tenant.
+func FilterMembershipsByTenant() privacy.MembershipQueryRuleFunc {
+	return func(ctx context.Context, q *ent.MembershipQuery) error {
+		if id, ok := TenantOf(ctx); ok {
+			q.Where(membership.HasUserWith(user.TenantID(id)))
+		}
+		return privacy.Skip
+	}
+}
+
+// FilterRegistrationsByTenant is a query rule that limits the registrations
+// a viewer can read to the ones of the cars of their tenant.
+func FilterRegistrationsByTenant() privacy.RegistrationQueryRuleFunc {
+	return func(ctx context.Context, q *ent.RegistrationQuery) error {
+		if id, ok := TenantOf(ctx); ok {
+			q.Where(registration.HasCarWith(car.TenantID(id)))
+		}
+		return privacy.Skip
+	}
+}
+
+// tenantMutation is implemented by the mutations of the
+// schemas using the TenantMixin.
+type tenantMutation interface {
+	ent.Mutation
+	TenantID() (int, bool)
+	SetTenantID(int)
+}
+
+// SetTenant is a hook setting the tenant of the created entities to the
+// tenant of the viewer. Admins without a tenant may set any tenant, and
+// others may only set theirs.
+func SetTenant() ent.Hook {
+	return hook.On(func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			tm, ok := m.(tenantMutation)
+			if !ok {
+				return nil, fmt.Errorf("rule: unexpected mutation type %T", m)
+			}
+			id, bound := TenantOf(ctx)
+			if !bound {
+				return next.Mutate(ctx, m)
+			}
+			// The default tenant is already set if no other was.
+			if set, _ := tm.TenantID(); set != id && set != tenant.Default {
+				return nil, denyMutation(m, "entity of another tenant")
+			}
+			tm.SetTenantID(id)
+			return next.Mutate(ctx, m)
+		})
+	}, ent.OpCreate)
+}
+
+// tenantEdges holds, by entity type, the edges leading
+// to the entities of a tenant, and the type of these entities.
+var tenantEdges = map[string]map[string]string{
+	ent.TypeUser: {
+		user.EdgeCars:   ent.TypeCar,
+		user.EdgeGroups: ent.TypeGroup,
+	},
+	ent.TypeCar: {
+		car.EdgeOwner: ent.TypeUser,
+	},
+	ent.TypeGroup: {
+		group.EdgeUsers: ent.TypeUser,
+	},
+	ent.TypeMembership: {
+		membership.EdgeGroup: ent.TypeGroup,
+		membership.EdgeUser:  ent.TypeUser,
+	},
+	ent.TypeRegistration: {
+		registration.EdgeCar:   ent.TypeCar,
+		registration.EdgeOwner: ent.TypeUser,
+	},
+}
+
+// clientMutation is implemented by the generated mutations.
+type clientMutation interface {
+	ent.Mutation
+	Client() *ent.Client
+}
+
+// DenyCrossTenantEdges is a hook rejecting the mutations that add an edge
+// between entities of different tenants, like giving a car to a user of
+// another tenant.
+func DenyCrossTenantEdges() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			cm, ok := m.(clientMutation)
+			edges := tenantEdges[m.Type()]
+			if !ok || edges == nil || len(m.AddedEdges()) == 0 {
+				return next.Mutate(ctx, m)
+			}
+			tenants := make(map[int]bool)
+			if tm, ok := m.(tenantMutation); ok {
+				id, bound := TenantOf(ctx)
+				switch {
+				case m.Op().Is(ent.OpCreate):
+					id, _ = tm.TenantID()
+					tenants[id] = true
+				case bound:
+					// FilterTenant limits the changes to the viewer's tenant.
+					tenants[id] = true
+				default:
+					// Admins without a tenant may change entities of any tenant.
+					im, ok := m.(interface {
+						IDs(context.Context) ([]int, error)
+					})
+					if !ok {
+						return nil, fmt.Errorf("rule: unexpected mutation type %T", m)
+					}
+					ids, err := im.IDs(allow(ctx))
+					if err != nil {
+						return nil, err
+					}
+					if err := tenantsOf(ctx, cm.Client(), m.Type(), ids, tenants); err != nil {
+						return nil, err
+					}
+				}
+			}
+			for _, name := range m.AddedEdges() {
+				typ, ok := edges[name]
+				if !ok {
+					continue
+				}
+				var ids []int
+				for _, v := range m.AddedIDs(name) {
+					ids = append(ids, v.(int))
+				}
+				if err := tenantsOf(ctx, cm.Client(), typ, ids, tenants); err != nil {
+					return nil, err
+				}
+			}
+			if len(tenants) > 1 {
+				return nil, denyMutation(m, "edge between entities of different tenants")
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+}
+
+// tenantsOf adds the tenants of the entities of the given type
+// and ids to the set.
+func tenantsOf(ctx context.Context, client *ent.Client, typ string, ids []int, set map[int]bool) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	ctx = allow(ctx)
+	var (
+		tenants []int
+		err     error
+	)
+	switch typ {
+	case ent.TypeUser:
+		tenants, err = client.User.Query().Where(user.IDIn(ids...)).Unique(true).Select(user.FieldTenantID).Ints(ctx)
+	case ent.TypeCar:
+		tenants, err = client.Car.Query().Where(car.IDIn(ids...)).Unique(true).Select(car.FieldTenantID).Ints(ctx)
+	case ent.TypeGroup:
+		tenants, err = client.Group.Query().Where(group.IDIn(ids...)).Unique(true).Select(group.FieldTenantID).Ints(ctx)
+	default:
+		return fmt.Errorf("rule: %s has no tenant", typ)
+	}
+	if err != nil {
+		return err
+	}
+	for _, id := range tenants {
+		set[id] = true
+	}
+	return nil
+}
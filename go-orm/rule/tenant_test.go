@@ -0,0 +1,164 @@
+package rule_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/enttest"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/privacy"
+	_ "github.com/anjanashankar9/go-learning/go-orm/ent/runtime"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+	"github.com/anjanashankar9/go-learning/go-orm/search"
+	"github.com/anjanashankar9/go-learning/go-orm/tenant"
+	"github.com/anjanashankar9/go-learning/go-orm/viewer"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openClient(t *testing.T) *ent.Client {
+	client := enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name()))
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// seed creates a user owning a car in each of the tenants, as an admin
+// bound to the tenant, and returns them by tenant.
+func seed(t *testing.T, client *ent.Client, tenants ...int) (map[int]*ent.User, map[int]*ent.Car) {
+	t.Helper()
+	users, cars := make(map[int]*ent.User), make(map[int]*ent.Car)
+	for _, tid := range tenants {
+		ctx := tenant.NewContext(viewer.AdminContext(context.Background()), tid)
+		users[tid] = client.User.Create().SetName("a8m").SetAge(30).SaveX(ctx)
+		cars[tid] = client.Car.Create().SetModel("Tesla").SetRegisteredAt(time.Now()).SetOwner(users[tid]).SaveX(ctx)
+	}
+	return users, cars
+}
+
+func TestTenantIsolation(t *testing.T) {
+	client := openClient(t)
+	users, cars := seed(t, client, tenant.Default, 1, 2)
+	admin := viewer.AdminContext(context.Background())
+	for tid, u := range users {
+		if u.TenantID != tid || cars[tid].TenantID != tid {
+			t.Errorf("user and car created in tenant %d have tenants %d and %d", tid, u.TenantID, cars[tid].TenantID)
+		}
+	}
+
+	// Admins without a tenant see all the tenants, the others theirs.
+	if n := client.User.Query().CountX(admin); n != 3 {
+		t.Errorf("admin sees %d users, want 3", n)
+	}
+	for name, ctx := range map[string]context.Context{
+		"admin of tenant 1": tenant.NewContext(admin, 1),
+		"user of tenant 1":  tenant.NewContext(viewer.UserContext(context.Background(), users[1].ID), 1),
+	} {
+		ids := client.Car.Query().IDsX(ctx)
+		if len(ids) != 1 || ids[0] != cars[1].ID {
+			t.Errorf("%s sees the cars %v, want [%d]", name, ids, cars[1].ID)
+		}
+	}
+	// A user without a tenant is in the default one.
+	uctx := viewer.UserContext(context.Background(), users[tenant.Default].ID)
+	if ids := client.User.Query().IDsX(uctx); len(ids) != 1 || ids[0] != users[tenant.Default].ID {
+		t.Errorf("user of the default tenant sees the users %v", ids)
+	}
+
+	// The updates and deletes of a tenant leave the others alone.
+	ctx := tenant.NewContext(admin, 1)
+	if n := client.Car.Update().SetModel("Ford").SaveX(ctx); n != 1 {
+		t.Errorf("admin of tenant 1 updated %d cars, want 1", n)
+	}
+	if err := client.Car.UpdateOne(cars[2]).SetModel("Ford").Exec(ctx); !ent.IsNotFound(err) {
+		t.Errorf("update of a car of tenant 2 = %v, want not found", err)
+	}
+	if n := client.Car.Delete().ExecX(tenant.NewContext(admin, 2)); n != 1 {
+		t.Errorf("admin of tenant 2 deleted %d cars, want 1", n)
+	}
+	if n := client.Car.Query().Where(car.ModelEQ("Ford")).CountX(admin); n != 1 {
+		t.Errorf("%d cars updated, want the one of tenant 1", n)
+	}
+	if n := client.Car.Query().CountX(admin); n != 2 {
+		t.Errorf("%d cars left, want 2", n)
+	}
+	if n := client.User.Delete().Where(user.Name("a8m")).ExecX(tenant.NewContext(admin, 2)); n != 1 {
+		t.Errorf("admin of tenant 2 deleted %d users, want 1", n)
+	}
+	if n := client.User.Query().CountX(admin); n != 2 {
+		t.Errorf("%d users left, want 2", n)
+	}
+}
+
+func TestTenantCreate(t *testing.T) {
+	client := openClient(t)
+	users, _ := seed(t, client, 1, 2)
+	admin := viewer.AdminContext(context.Background())
+
+	// Only admins without a tenant create entities of any tenant.
+	u := client.User.Create().SetName("nati").SetAge(28).SetTenantID(2).SaveX(admin)
+	if u.TenantID != 2 {
+		t.Errorf("user created in tenant %d, want 2", u.TenantID)
+	}
+	err := client.User.Create().SetName("nati").SetAge(28).SetTenantID(2).Exec(tenant.NewContext(admin, 1))
+	if !errors.Is(err, privacy.Deny) {
+		t.Errorf("create in another tenant = %v, want denied", err)
+	}
+
+	// No edge links entities of different tenants, whoever adds it.
+	for name, ctx := range map[string]context.Context{
+		"admin":             admin,
+		"admin of tenant 1": tenant.NewContext(admin, 1),
+	} {
+		err := client.Car.Create().SetModel("Fiat").SetRegisteredAt(time.Now()).SetOwner(users[2]).SetTenantID(1).Exec(ctx)
+		if !errors.Is(err, privacy.Deny) {
+			t.Errorf("%s: car of tenant 1 given to a user of tenant 2 = %v, want denied", name, err)
+		}
+	}
+	c := client.Car.Query().Where(car.TenantID(1)).OnlyX(admin)
+	if err := client.Car.UpdateOne(c).SetOwner(users[2]).Exec(admin); !errors.Is(err, privacy.Deny) {
+		t.Errorf("admin: owner of tenant 2 for a car of tenant 1 = %v, want denied", err)
+	}
+	if err := client.User.UpdateOne(users[2]).AddCars(c).Exec(admin); !errors.Is(err, privacy.Deny) {
+		t.Errorf("admin: car of tenant 1 for a user of tenant 2 = %v, want denied", err)
+	}
+}
+
+func TestTenantSearch(t *testing.T) {
+	client := openClient(t)
+	idx := search.NewMemory()
+	client.Use(idx.Hook())
+	users, cars := seed(t, client, tenant.Default, 1, 2)
+	admin := viewer.AdminContext(context.Background())
+
+	// The users and cars of all the tenants are named alike.
+	for name, ctx := range map[string]context.Context{
+		"admin":             admin,
+		"admin of tenant 1": tenant.NewContext(admin, 1),
+		"user of tenant 1":  tenant.NewContext(viewer.UserContext(context.Background(), users[1].ID), 1),
+		"user of tenant 0":  viewer.UserContext(context.Background(), users[tenant.Default].ID),
+	} {
+		results, err := idx.Search(ctx, search.Query{Text: "tesla a8m"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make(map[string]bool)
+		for _, r := range results {
+			got[fmt.Sprint(r.Type, r.ID)] = true
+		}
+		want := 6
+		tid, bound := tenant.FromContext(ctx)
+		if v := viewer.FromContext(ctx); !v.Admin || bound {
+			want = 2
+			if !got[fmt.Sprint(ent.TypeUser, users[tid].ID)] || !got[fmt.Sprint(ent.TypeCar, cars[tid].ID)] {
+				t.Errorf("%s found %v, want the user and car of tenant %d", name, results, tid)
+			}
+		}
+		if len(results) != want {
+			t.Errorf("%s found %d results, want %d", name, len(results), want)
+		}
+	}
+}
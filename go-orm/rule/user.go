@@ -0,0 +1,62 @@
+package rule
+
+import (
+	"context"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/group"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/privacy"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+)
+
+// FilterUsersByGroup is a query rule that limits the users a viewer
+// can read to themselves and the members of the groups they are in.
+func FilterUsersByGroup() privacy.UserQueryRuleFunc {
+	return func(ctx context.Context, q *ent.UserQuery) error {
+		vid := viewerID(ctx)
+		q.Where(user.Or(
+			user.ID(vid),
+			user.HasGroupsWith(group.HasUsersWith(user.ID(vid))),
+		))
+		return privacy.Skip
+	}
+}
+
+// LimitUserMutationsToSelf is a mutation rule that lets viewers update
+// or delete only themselves. Adding a user to a group is left to the
+// admins of the group, and only cars without another owner can be added.
+func LimitUserMutationsToSelf() privacy.UserMutationRuleFunc {
+	return func(ctx context.Context, m *ent.UserMutation) error {
+		vid := viewerID(ctx)
+		switch {
+		case m.Op().Is(ent.OpUpdateOne | ent.OpDeleteOne):
+			if id, _ := m.ID(); id != vid {
+				return denyMutation(m, "users can only change themselves")
+			}
+		case m.Op().Is(ent.OpUpdate | ent.OpDelete):
+			m.Where(user.ID(vid))
+		}
+		if ids := m.GroupsIDs(); len(ids) > 0 {
+			ok, err := isGroupAdmin(ctx, m.Client(), vid, ids...)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return denyMutation(m, "only group admins may add users to a group")
+			}
+		}
+		if ids := m.CarsIDs(); len(ids) > 0 {
+			taken, err := m.Client().Car.Query().
+				Where(car.IDIn(ids...), car.HasOwnerWith(user.IDNEQ(vid))).
+				Exist(allow(ctx))
+			if err != nil {
+				return err
+			}
+			if taken {
+				return denyMutation(m, "car is owned by another user")
+			}
+		}
+		return privacy.Skip
+	}
+}
@@ -0,0 +1,50 @@
+//go:build ignore
+
+// gen writes schemas.go, the list of the schemas schemadoc documents:
+// all the ones of the schema package, found the way ent finds them.
+//
+//	go run gen.go [-schema path] [-o file]
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+
+	"entgo.io/ent/entc/load"
+)
+
+var (
+	path = flag.String("schema", "../ent/schema", "path of the schema package")
+	out  = flag.String("o", "schemas.go", "output file")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("schemadoc/gen: ")
+	flag.Parse()
+
+	spec, err := (&load.Config{Path: *path}).Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by schemadoc/gen.go, DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package main\n\nimport (\n\t\"entgo.io/ent\"\n\t%q\n)\n\n", spec.PkgPath)
+	fmt.Fprintf(&b, "// schemas lists the schemas documented, in the order of their names.\n")
+	fmt.Fprintf(&b, "var schemas = []ent.Interface{\n")
+	for _, s := range spec.Schemas {
+		fmt.Fprintf(&b, "\tschema.%s{},\n", s.Name)
+	}
+	fmt.Fprintf(&b, "}\n")
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// Type describes an entity type of the schema.
+type Type struct {
+	Name   string
+	Fields []*Field
+	Edges  []*Edge
+	// ID holds the fields of a composite id. It is nil
+	// for the types with the default int id.
+	ID []string
+}
+
+// Field describes a field of a Type.
+type Field struct {
+	*field.Descriptor
+	// Mixin is the name of the mixin the field comes from, if any.
+	Mixin string
+}
+
+// Edge describes an edge of a Type.
+type Edge struct {
+	*edge.Descriptor
+	// Rel is the relation of the edge, from the owner: O2O, O2M, M2O or M2M.
+	Rel string
+	// Ref is the edge going the other way, if any.
+	Ref *Edge
+}
+
+// load returns the types of the schemas, with the relations of their
+// edges resolved. The schemas are listed in schemas.go, generated from
+// the schema package by gen.go.
+func load() ([]*Type, error) {
+	types := make(map[string]*Type)
+	var ts []*Type
+	for _, s := range schemas {
+		t := &Type{Name: reflect.TypeOf(s).Name()}
+		for _, m := range s.Mixin() {
+			for _, f := range m.Fields() {
+				t.Fields = append(t.Fields, &Field{Descriptor: f.Descriptor(), Mixin: reflect.TypeOf(m).Name()})
+			}
+		}
+		for _, f := range s.Fields() {
+			t.Fields = append(t.Fields, &Field{Descriptor: f.Descriptor()})
+		}
+		for _, e := range s.Edges() {
+			t.Edges = append(t.Edges, &Edge{Descriptor: e.Descriptor()})
+		}
+		for _, a := range s.Annotations() {
+			if a, ok := a.(*field.Annotation); ok && len(a.ID) > 0 {
+				t.ID = a.ID
+			}
+		}
+		for _, f := range t.Fields {
+			if f.Err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", t.Name, f.Name, f.Err)
+			}
+		}
+		types[t.Name] = t
+		ts = append(ts, t)
+	}
+	for _, t := range ts {
+		for _, e := range t.Edges {
+			if err := resolve(types, t, e); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return ts, nil
+}
+
+// resolve sets the relation of the edge e of t, the way ent does.
+func resolve(types map[string]*Type, t *Type, e *Edge) error {
+	target, ok := types[e.Type]
+	if !ok {
+		return fmt.Errorf("%s.%s: unknown type %q", t.Name, e.Name, e.Type)
+	}
+	if e.Inverse {
+		for _, ref := range target.Edges {
+			if !ref.Inverse && ref.Name == e.RefName && ref.Type == t.Name {
+				e.Ref = ref
+			}
+		}
+		if e.Ref == nil {
+			return fmt.Errorf("%s.%s: missing edge %s.%s", t.Name, e.Name, target.Name, e.RefName)
+		}
+		switch a, b := e.Ref.Unique, e.Unique; {
+		case a && b:
+			e.Rel = "O2O"
+		case !a && b:
+			e.Rel = "M2O"
+		case a && !b:
+			e.Rel = "O2M"
+		default:
+			e.Rel = "M2M"
+		}
+		return nil
+	}
+	for _, ref := range target.Edges {
+		// The inverse edge refers to an edge of t by its name, which
+		// the edges of other types may have too.
+		if ref.Inverse && ref.RefName == e.Name && ref.Type == t.Name {
+			e.Ref = ref
+		}
+	}
+	switch {
+	case e.Ref != nil && e.Unique && e.Ref.Unique:
+		e.Rel = "O2O"
+	case e.Ref != nil && e.Unique:
+		e.Rel = "M2O"
+	case e.Ref != nil && e.Ref.Unique:
+		e.Rel = "O2M"
+	case e.Ref != nil:
+		e.Rel = "M2M"
+	// Edges without an inverse.
+	case target == t && e.Unique:
+		e.Rel = "O2O"
+	case target == t:
+		e.Rel = "M2M"
+	case e.Unique:
+		e.Rel = "M2O"
+	default:
+		e.Rel = "O2M"
+	}
+	return nil
+}
+
+// TypeName returns the type of the field, with the values of enums.
+func (f *Field) TypeName() string {
+	if f.Info.Type == field.TypeEnum {
+		values := make([]string, len(f.Enums))
+		for i, e := range f.Enums {
+			values[i] = e.V
+		}
+		return "enum(" + strings.Join(values, ", ") + ")"
+	}
+	return f.Info.String()
+}
+
+// DefaultValue describes the default value of the field,
+// or returns "" if it has none.
+func (f *Field) DefaultValue() string {
+	switch d := f.Default.(type) {
+	case nil:
+		return ""
+	case string:
+		return fmt.Sprintf("%q", d)
+	default:
+		if v := reflect.ValueOf(d); v.Kind() == reflect.Func {
+			return funcName(v) + "()"
+		}
+		return fmt.Sprint(d)
+	}
+}
+
+// funcSuffix matches the suffix of the closures, like ".func1".
+var funcSuffix = regexp.MustCompile(`(\.func\d+)+$`)
+
+// ValidatorNames returns the names of the validators of the field. The
+// validators of the field package, like MinLen, are named after the method
+// adding them. Their arguments are not known. The others are "custom".
+func (f *Field) ValidatorNames() []string {
+	var names []string
+	for _, v := range f.Validators {
+		name := funcSuffix.ReplaceAllString(funcName(reflect.ValueOf(v)), "")
+		if !strings.HasPrefix(name, "entgo.io/ent/schema/field.") {
+			names = append(names, "custom")
+			continue
+		}
+		names = append(names, name[strings.LastIndex(name, ".")+1:])
+	}
+	return names
+}
+
+// Attributes returns the options set on the field.
+func (f *Field) Attributes() []string {
+	var attrs []string
+	for _, a := range []struct {
+		set  bool
+		name string
+	}{
+		{f.Optional, "optional"},
+		{f.Nillable, "nillable"},
+		{f.Unique, "unique"},
+		{f.Immutable, "immutable"},
+		{f.Sensitive, "sensitive"},
+		{f.Mixin != "", "from " + f.Mixin},
+	} {
+		if a.set {
+			attrs = append(attrs, a.name)
+		}
+	}
+	return attrs
+}
+
+func funcName(v reflect.Value) string {
+	if fn := runtime.FuncForPC(v.Pointer()); fn != nil {
+		return fn.Name()
+	}
+	return "func"
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLoadEdges(t *testing.T) {
+	types, err := load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string)
+	for _, typ := range types {
+		for _, e := range typ.Edges {
+			ref := ""
+			if e.Ref != nil {
+				ref = e.Type + "." + e.Ref.Name
+			}
+			got[typ.Name+"."+e.Name] = fmt.Sprintf("%s %s", e.Rel, ref)
+		}
+	}
+	// Car and User both have an edge named registrations, and the owner
+	// edge of Registration refers to the one of User only.
+	for edge, want := range map[string]string{
+		"Car.registrations":  "O2M Registration.car",
+		"User.registrations": "O2M Registration.owner",
+		"Registration.car":   "M2O Car.registrations",
+		"Registration.owner": "M2O User.registrations",
+		"Car.owner":          "M2O User.cars",
+		"User.groups":        "M2M Group.users",
+	} {
+		if got[edge] != want {
+			t.Errorf("%s = %q, want %q", edge, got[edge], want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// schemadoc documents the ent schema of go-orm. It writes an entity
+// relationship diagram as Graphviz DOT (schema.dot) and as Mermaid
+// (schema.mmd), and a Markdown data dictionary (schema.md) listing the
+// fields with their types, defaults and validators, and the edges with
+// their cardinality.
+//
+//	schemadoc [-out dir]
+//
+// It runs with go generate, after the ent code generation, and documents
+// the schemas listed in schemas.go. go generate writes that file first,
+// with gen.go, from the schemas of the schema package.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+var out = flag.String("out", ".", "output directory")
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("schemadoc: ")
+	flag.Parse()
+
+	types, err := load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.MkdirAll(*out, 0755); err != nil {
+		log.Fatal(err)
+	}
+	for _, f := range []struct {
+		name  string
+		write func(io.Writer, []*Type)
+	}{
+		{"schema.dot", writeDOT},
+		{"schema.mmd", writeMermaid},
+		{"schema.md", writeMarkdown},
+	} {
+		if err := create(filepath.Join(*out, f.name), func(w io.Writer) { f.write(w, types) }); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// create writes the file at path with write.
+func create(path string, write func(io.Writer)) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	write(w)
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
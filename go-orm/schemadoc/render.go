@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"entgo.io/ent/schema/field"
+)
+
+// writeDOT writes the types as a Graphviz graph, one node per type
+// listing its fields, and one arrow per edge pair.
+func writeDOT(w io.Writer, types []*Type) {
+	fmt.Fprintln(w, "digraph ent {")
+	fmt.Fprintln(w, "\trankdir=LR;")
+	fmt.Fprintln(w, "\tnode [shape=plaintext, fontname=\"Helvetica\"];")
+	fmt.Fprintln(w, "\tedge [fontname=\"Helvetica\", fontsize=10];")
+	for _, t := range types {
+		fmt.Fprintf(w, "\t%s [label=<<table border=\"0\" cellborder=\"1\" cellspacing=\"0\">", t.Name)
+		fmt.Fprintf(w, "<tr><td bgcolor=\"lightgrey\"><b>%s</b></td></tr>", t.Name)
+		if t.ID == nil {
+			fmt.Fprint(w, "<tr><td align=\"left\">id int</td></tr>")
+		}
+		for _, f := range t.Fields {
+			fmt.Fprintf(w, "<tr><td align=\"left\">%s %s</td></tr>", f.Name, html.EscapeString(f.TypeName()))
+		}
+		fmt.Fprintln(w, "</table>>];")
+	}
+	for _, t := range types {
+		for _, e := range assocEdges(t) {
+			fmt.Fprintf(w, "\t%s -> %s [label=\"%s\"];\n", t.Name, e.Type, edgeLabel(e))
+		}
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// writeMermaid writes the types as a Mermaid entity relationship diagram.
+func writeMermaid(w io.Writer, types []*Type) {
+	fmt.Fprintln(w, "erDiagram")
+	for _, t := range types {
+		fmt.Fprintf(w, "    %s {\n", t.Name)
+		if t.ID == nil {
+			fmt.Fprintln(w, "        int id PK")
+		}
+		for _, f := range t.Fields {
+			key := ""
+			if contains(t.ID, f.Name) {
+				key = " PK"
+			}
+			fmt.Fprintf(w, "        %s %s%s\n", mermaidType(f), f.Name, key)
+		}
+		fmt.Fprintln(w, "    }")
+	}
+	for _, t := range types {
+		for _, e := range assocEdges(t) {
+			left, right := cardinality(e)
+			fmt.Fprintf(w, "    %s %s--%s %s : %q\n", t.Name, left, right, e.Type, edgeLabel(e))
+		}
+	}
+}
+
+// writeMarkdown writes the data dictionary of the types.
+func writeMarkdown(w io.Writer, types []*Type) {
+	fmt.Fprintln(w, "# Data dictionary")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Generated from `ent/schema` by `schemadoc`. Do not edit.")
+	for _, t := range types {
+		fmt.Fprintf(w, "\n## %s\n\n", t.Name)
+		if t.ID != nil {
+			fmt.Fprintf(w, "Identified by (%s).\n\n", strings.Join(t.ID, ", "))
+		}
+		fmt.Fprintln(w, "| Field | Type | Default | Validators | Attributes |")
+		fmt.Fprintln(w, "|-------|------|---------|------------|------------|")
+		if t.ID == nil {
+			fmt.Fprintln(w, "| id | int | | | primary key |")
+		}
+		for _, f := range t.Fields {
+			fmt.Fprintf(w, "| %s | %s | %s | %s | %s |\n", f.Name, cell(f.TypeName()), cell(f.DefaultValue()),
+				strings.Join(f.ValidatorNames(), ", "), strings.Join(f.Attributes(), ", "))
+		}
+		if len(t.Edges) == 0 {
+			continue
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "| Edge | Type | Relation | Attributes |")
+		fmt.Fprintln(w, "|------|------|----------|------------|")
+		for _, e := range t.Edges {
+			fmt.Fprintf(w, "| %s | %s | %s | %s |\n", e.Name, e.Type, e.Rel, strings.Join(edgeAttributes(e), ", "))
+		}
+	}
+}
+
+// assocEdges returns the edges of t that are not inverse edges, so that
+// the diagrams draw each pair of edges once.
+func assocEdges(t *Type) []*Edge {
+	var edges []*Edge
+	for _, e := range t.Edges {
+		if !e.Inverse {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+func edgeLabel(e *Edge) string {
+	label := e.Name
+	if e.Ref != nil {
+		label += "/" + e.Ref.Name
+	}
+	return label
+}
+
+func edgeAttributes(e *Edge) []string {
+	var attrs []string
+	switch {
+	case e.Inverse:
+		attrs = append(attrs, fmt.Sprintf("inverse of %s.%s", e.Type, e.RefName))
+	case e.Ref != nil:
+		attrs = append(attrs, fmt.Sprintf("inverse %s.%s", e.Type, e.Ref.Name))
+	}
+	if e.Required {
+		attrs = append(attrs, "required")
+	}
+	if e.Field != "" {
+		attrs = append(attrs, "field "+e.Field)
+	}
+	if e.Through != nil {
+		attrs = append(attrs, fmt.Sprintf("through %s (%s)", e.Through.N, e.Through.T))
+	}
+	return attrs
+}
+
+// cardinality returns the Mermaid markers of the two ends of the edge:
+// how many owners an entity of the edge type has, and the other way.
+func cardinality(e *Edge) (string, string) {
+	refRequired := e.Ref != nil && e.Ref.Required
+	var left, right string
+	switch e.Rel {
+	case "O2O", "O2M":
+		left = "|o"
+		if refRequired {
+			left = "||"
+		}
+	default:
+		left = "}o"
+	}
+	switch e.Rel {
+	case "O2O", "M2O":
+		right = "o|"
+		if e.Required {
+			right = "||"
+		}
+	default:
+		right = "o{"
+	}
+	return left, right
+}
+
+// mermaidType returns the type of the field as a single word,
+// which is what Mermaid accepts.
+func mermaidType(f *Field) string {
+	switch f.Info.Type {
+	case field.TypeTime:
+		return "time"
+	case field.TypeJSON:
+		return "json"
+	case field.TypeEnum:
+		return "enum"
+	case field.TypeBytes:
+		return "bytes"
+	case field.TypeUUID:
+		return "uuid"
+	default:
+		return f.Info.Type.String()
+	}
+}
+
+// cell escapes the pipes of a Markdown table cell.
+func cell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
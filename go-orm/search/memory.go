@@ -0,0 +1,279 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/privacy"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+	"github.com/anjanashankar9/go-learning/go-orm/rule"
+)
+
+// Memory is a Searcher keeping an inverted index of trigrams in memory.
+// Fill it with Load and keep it in sync with the database by adding
+// its Hook to the client:
+//
+//	idx := search.NewMemory()
+//	client.Use(idx.Hook())
+//	if err := idx.Load(ctx, client); err != nil {
+//		return err
+//	}
+type Memory struct {
+	threshold float64
+	// fields holds the searchable fields by entity type.
+	fields map[string][]string
+
+	mu sync.RWMutex
+	// docs holds the text of the searchable fields by document,
+	// and tenants the tenant of each.
+	docs    map[doc]map[string]string
+	tenants map[doc]int
+	// grams holds the documents containing each trigram.
+	grams map[string]map[doc]bool
+}
+
+// doc identifies an indexed entity.
+type doc struct {
+	typ string
+	id  int
+}
+
+// NewMemory returns an empty Memory index.
+func NewMemory() *Memory {
+	m := &Memory{
+		threshold: DefaultThreshold,
+		fields:    make(map[string][]string),
+		docs:      make(map[doc]map[string]string),
+		tenants:   make(map[doc]int),
+		grams:     make(map[string]map[doc]bool),
+	}
+	for _, s := range searchables() {
+		m.fields[s.typ] = append(m.fields[s.typ], s.fields...)
+	}
+	return m
+}
+
+// Load indexes all the searchable entities of the database.
+func (m *Memory) Load(ctx context.Context, client *ent.Client) error {
+	for typ := range m.fields {
+		vs, err := load(allow(ctx), client, typ, nil)
+		if err != nil {
+			return err
+		}
+		for _, v := range vs {
+			m.index(typ, v)
+		}
+	}
+	return nil
+}
+
+// Search implements the Searcher interface.
+func (m *Memory) Search(ctx context.Context, q Query) ([]Result, error) {
+	qgrams := trigrams(q.Text)
+	tid, bound := rule.TenantOf(ctx)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	candidates := make(map[doc]bool)
+	for g := range qgrams {
+		for d := range m.grams[g] {
+			if (q.Type == "" || q.Type == d.typ) && (!bound || m.tenants[d] == tid) {
+				candidates[d] = true
+			}
+		}
+	}
+	var results []Result
+	for d := range candidates {
+		var best Result
+		for field, text := range m.docs[d] {
+			if s := score(text, q.Text); s > best.Score {
+				best = Result{Type: d.typ, ID: d.id, Field: field, Text: text, Score: s}
+			}
+		}
+		if best.Score >= m.threshold {
+			best.Highlight = highlight(best.Text, q.Text, m.threshold)
+			results = append(results, best)
+		}
+	}
+	return rank(results, q.Limit), nil
+}
+
+// mutation is implemented by all the generated mutations.
+type mutation interface {
+	ent.Mutation
+	ID() (int, bool)
+	IDs(context.Context) ([]int, error)
+	Client() *ent.Client
+}
+
+// Hook returns a hook keeping the index in sync with the mutations of
+// the searchable entities. Mutations made in a transaction are applied
+// to the index once it commits, provided the transaction is in their
+// context (see ent.NewTxContext). The Tx of the mutation itself cannot
+// be used, since its commit hooks are not those of the caller's Tx.
+func (m *Memory) Hook() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, em ent.Mutation) (ent.Value, error) {
+			mu, ok := em.(mutation)
+			if !ok || m.fields[em.Type()] == nil {
+				return next.Mutate(ctx, em)
+			}
+			typ := mu.Type()
+			// The ids of the entities matched by bulk updates and
+			// deletes are not known afterwards, so get them first,
+			// as the viewer: the mutation changes none of the others.
+			bulk := mu.Op().Is(ent.OpUpdate | ent.OpDelete | ent.OpDeleteOne)
+			var ids []int
+			if bulk {
+				var err error
+				if ids, err = mu.IDs(ctx); err != nil {
+					return nil, err
+				}
+			}
+			v, err := next.Mutate(ctx, mu)
+			if err != nil {
+				return nil, err
+			}
+			if bulk && len(ids) == 0 {
+				return v, nil // nothing changed
+			}
+			// Creates and single updates return the entity.
+			apply := func() { m.index(typ, v) }
+			if bulk {
+				// Read the matched entities back, since the privacy
+				// rules may have kept some of them from changing. They
+				// are read past the rules, which may hide them from the
+				// viewer now, but not from the index.
+				vs, err := load(allow(ctx), mu.Client(), typ, ids)
+				if err != nil {
+					return nil, err
+				}
+				apply = func() {
+					for _, id := range ids {
+						m.remove(doc{typ, id})
+					}
+					for _, v := range vs {
+						m.index(typ, v)
+					}
+				}
+			}
+			if tx := ent.TxFromContext(ctx); tx != nil {
+				tx.OnCommit(func(next ent.Committer) ent.Committer {
+					return ent.CommitFunc(func(ctx context.Context, tx *ent.Tx) error {
+						if err := next.Commit(ctx, tx); err != nil {
+							return err
+						}
+						apply()
+						return nil
+					})
+				})
+			} else {
+				apply()
+			}
+			return v, nil
+		})
+	}
+}
+
+// index adds the entity v, a *ent.User or a *ent.Car, to the index.
+func (m *Memory) index(typ string, v interface{}) {
+	id, _ := fieldValue(v, "id").(int)
+	d := doc{typ, id}
+	texts := make(map[string]string)
+	for _, f := range m.fields[typ] {
+		if s, ok := fieldValue(v, f).(string); ok {
+			texts[f] = s
+		}
+	}
+	m.remove(d)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.docs[d] = texts
+	m.tenants[d], _ = fieldValue(v, tenantField).(int)
+	for _, text := range texts {
+		for g := range trigrams(text) {
+			if m.grams[g] == nil {
+				m.grams[g] = make(map[doc]bool)
+			}
+			m.grams[g][d] = true
+		}
+	}
+}
+
+// remove deletes the document from the index.
+func (m *Memory) remove(d doc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, text := range m.docs[d] {
+		for g := range trigrams(text) {
+			delete(m.grams[g], d)
+			if len(m.grams[g]) == 0 {
+				delete(m.grams, g)
+			}
+		}
+	}
+	delete(m.docs, d)
+	delete(m.tenants, d)
+}
+
+// load returns the entities of the given type with the given ids,
+// or all of them if ids is nil.
+func load(ctx context.Context, client *ent.Client, typ string, ids []int) ([]interface{}, error) {
+	var vs []interface{}
+	switch typ {
+	case ent.TypeUser:
+		q := client.User.Query()
+		if ids != nil {
+			q.Where(user.IDIn(ids...))
+		}
+		us, err := q.All(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range us {
+			vs = append(vs, u)
+		}
+	case ent.TypeCar:
+		q := client.Car.Query()
+		if ids != nil {
+			q.Where(car.IDIn(ids...))
+		}
+		cs, err := q.All(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range cs {
+			vs = append(vs, c)
+		}
+	default:
+		return nil, fmt.Errorf("search: unexpected type %q", typ)
+	}
+	return vs, nil
+}
+
+// fieldValue returns the value of the field of the entity v. Fields
+// are looked up by their JSON name, which is the name in the schema.
+func fieldValue(v interface{}, name string) interface{} {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		tag := strings.Split(rt.Field(i).Tag.Get("json"), ",")[0]
+		if tag == name {
+			return rv.Field(i).Interface()
+		}
+	}
+	return nil
+}
+
+// allow returns a context in which the privacy rules are not evaluated,
+// since the index covers all the entities.
+func allow(ctx context.Context) context.Context {
+	return privacy.DecisionContext(ctx, privacy.Allow)
+}
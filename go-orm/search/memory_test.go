@@ -0,0 +1,87 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/enttest"
+	_ "github.com/anjanashankar9/go-learning/go-orm/ent/runtime"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+	"github.com/anjanashankar9/go-learning/go-orm/viewer"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openClient(t *testing.T) *ent.Client {
+	client := enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name()))
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// found returns the texts of the results of the search.
+func found(t *testing.T, m *Memory, typ, text string) []string {
+	t.Helper()
+	results, err := m.Search(context.Background(), Query{Text: text, Type: typ})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var texts []string
+	for _, r := range results {
+		texts = append(texts, r.Text)
+	}
+	return texts
+}
+
+func TestMemoryHook(t *testing.T) {
+	client := openClient(t)
+	idx := NewMemory()
+	client.Use(idx.Hook())
+	ctx := viewer.AdminContext(context.Background())
+	a8m := client.User.Create().SetName("a8m").SetAge(30).SaveX(ctx)
+	nati := client.User.Create().SetName("nati").SetAge(28).SaveX(ctx)
+	client.Car.Create().SetModel("Tesla").SetRegisteredAt(time.Now()).SetOwner(a8m).ExecX(ctx)
+	client.Car.Create().SetModel("Tesla Roadster").SetRegisteredAt(time.Now()).SetOwner(nati).ExecX(ctx)
+	if got := found(t, idx, ent.TypeCar, "tesla"); len(got) != 2 {
+		t.Fatalf("found %q, want the 2 Teslas", got)
+	}
+
+	// An update matching nothing indexes nothing.
+	if n := client.User.Update().Where(user.Name("nobody")).SetAge(1).SaveX(ctx); n != 0 {
+		t.Fatalf("updated %d users, want 0", n)
+	}
+	if len(idx.docs) != 4 {
+		t.Errorf("%d documents after an empty update, want 4: %v", len(idx.docs), idx.docs)
+	}
+
+	// A user renames the Teslas, which are theirs only for one.
+	uctx := viewer.UserContext(context.Background(), a8m.ID)
+	client.Car.Update().Where(car.ModelHasPrefix("Tesla")).SetModel("Ford").ExecX(uctx)
+	if got := found(t, idx, ent.TypeCar, "ford"); len(got) != 1 {
+		t.Errorf("found %q, want 1 Ford", got)
+	}
+	if got := found(t, idx, ent.TypeCar, "tesla roadster"); len(got) != 1 || got[0] != "Tesla Roadster" {
+		t.Errorf("found %q, want the Tesla Roadster", got)
+	}
+
+	// Bulk deletes.
+	client.User.Delete().Where(user.Name("nati")).ExecX(ctx)
+	if got := found(t, idx, ent.TypeUser, "nati"); len(got) != 0 {
+		t.Errorf("found %q after the delete, want nothing", got)
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	for _, tt := range []struct{ text, query, want string }{
+		{"Tesla Model S", "tesla", "<b>Tesla</b> Model S"},
+		{"<script>Tesla</script>", "tesla", "&lt;script&gt;<b>Tesla</b>&lt;/script&gt;"},
+		{"Tom & Jerry's", "jerry", "Tom &amp; <b>Jerry</b>&#39;s"},
+	} {
+		if got := highlight(tt.text, tt.query, DefaultThreshold); got != tt.want {
+			t.Errorf("highlight(%q, %q) = %q, want %q", tt.text, tt.query, got, tt.want)
+		}
+	}
+}
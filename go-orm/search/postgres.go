@@ -0,0 +1,114 @@
+package search
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/anjanashankar9/go-learning/go-orm/rule"
+)
+
+// Postgres is a Searcher running the search in a PostgreSQL database.
+// Its indexes are created by Migrate, after the ent schema migration.
+type Postgres struct {
+	db        *sql.DB
+	threshold float64
+}
+
+// NewPostgres returns a Postgres searcher over db.
+func NewPostgres(db *sql.DB) *Postgres {
+	return &Postgres{db: db, threshold: DefaultThreshold}
+}
+
+// Migrate enables pg_trgm and creates the trigram and tsvector
+// indexes of the searchable fields, if they do not exist.
+func (p *Postgres) Migrate(ctx context.Context) error {
+	stmts := []string{"CREATE EXTENSION IF NOT EXISTS pg_trgm"}
+	for _, s := range searchables() {
+		for _, f := range s.fields {
+			stmts = append(stmts,
+				fmt.Sprintf("CREATE INDEX IF NOT EXISTS %[1]s_%[2]s_trgm ON %[1]s USING GIN (%[2]s gin_trgm_ops)", s.table, f),
+				fmt.Sprintf("CREATE INDEX IF NOT EXISTS %[1]s_%[2]s_tsv ON %[1]s USING GIN (to_tsvector('%[3]s', %[2]s))", s.table, f, s.config),
+			)
+		}
+	}
+	for _, stmt := range stmts {
+		if _, err := p.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("search: migrate: %w", err)
+		}
+	}
+	return nil
+}
+
+// Search implements the Searcher interface. A field matches when it is
+// similar enough to the query, or when its words match the query words.
+func (p *Postgres) Search(ctx context.Context, q Query) ([]Result, error) {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	tid, bound := rule.TenantOf(ctx)
+	var results []Result
+	for _, s := range searchables() {
+		if q.Type != "" && q.Type != s.typ {
+			continue
+		}
+		for _, f := range s.fields {
+			rs, err := p.search(ctx, s, f, q.Text, limit, tid, bound)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, rs...)
+		}
+	}
+	return rank(best(results), limit), nil
+}
+
+// search returns the rows of the table whose field matches text,
+// of the tenant tid if bound.
+func (p *Postgres) search(ctx context.Context, s searchable, field, text string, limit, tid int, bound bool) ([]Result, error) {
+	args := []interface{}{text, limit}
+	var scope string
+	if bound {
+		args = append(args, tid)
+		scope = " AND " + tenantField + " = $3"
+	}
+	query := fmt.Sprintf(`SELECT id, %[2]s, GREATEST(similarity(%[2]s, $1), word_similarity($1, %[2]s)) AS score
+FROM %[1]s
+WHERE ($1 <%% %[2]s OR to_tsvector('%[3]s', %[2]s) @@ plainto_tsquery('%[3]s', $1))%[4]s
+ORDER BY score DESC, id
+LIMIT $2`, s.table, field, s.config, scope)
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("search: querying %s: %w", s.table, err)
+	}
+	defer rows.Close()
+	var results []Result
+	for rows.Next() {
+		r := Result{Type: s.typ, Field: field}
+		if err := rows.Scan(&r.ID, &r.Text, &r.Score); err != nil {
+			return nil, err
+		}
+		r.Highlight = highlight(r.Text, text, p.threshold)
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// best keeps the best result of each entity matched on several fields.
+func best(results []Result) []Result {
+	index := make(map[doc]int)
+	var out []Result
+	for _, r := range results {
+		k := doc{r.Type, r.ID}
+		if i, ok := index[k]; ok {
+			if r.Score > out[i].Score {
+				out[i] = r
+			}
+			continue
+		}
+		index[k] = len(out)
+		out = append(out, r)
+	}
+	return out
+}
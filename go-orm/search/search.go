@@ -0,0 +1,120 @@
+// Package search implements full-text search with typo tolerance
+// over the fields marked with the schema.Search annotation, which
+// are the names of the Users and the models of the Cars.
+//
+// There are two Searchers. Postgres runs the search in the database,
+// using pg_trgm and tsvector indexes. Memory keeps an inverted index
+// of trigrams in memory and works with any database, SQLite included.
+// Both rank the results the same way, by trigram similarity.
+//
+// The results are limited to the tenant of the viewer, as by
+// rule.FilterTenant. The other privacy rules are not applied: load
+// the entities through the ent client to apply them.
+package search
+
+import (
+	"context"
+	"sort"
+
+	entschema "entgo.io/ent/schema"
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/schema"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+)
+
+// DefaultLimit is the number of results returned when Query.Limit is not set.
+const DefaultLimit = 10
+
+// DefaultThreshold is the similarity below which texts do not match.
+// It is the default of pg_trgm.
+const DefaultThreshold = 0.3
+
+// Searcher is the interface implemented by the search backends.
+type Searcher interface {
+	// Search returns the results matching q, best first.
+	Search(ctx context.Context, q Query) ([]Result, error)
+}
+
+// Query is a search request.
+type Query struct {
+	// Text is the text searched for.
+	Text string
+	// Type limits the search to an entity type, ent.TypeUser or ent.TypeCar.
+	// All the searchable types are searched if it is empty.
+	Type string
+	// Limit is the maximum number of results.
+	Limit int
+}
+
+// Result is an entity matching a query.
+type Result struct {
+	// Type and ID identify the entity.
+	Type string
+	ID   int
+	// Field is the field that matched, and Text its value.
+	Field string
+	Text  string
+	// Score is the similarity between the query and Text, from 0 to 1.
+	Score float64
+	// Highlight is Text, HTML-escaped, with the matching words
+	// wrapped in <b></b>.
+	Highlight string
+}
+
+// tenantField is the field of the tenant of the searchable
+// entities, which all use the TenantMixin.
+const tenantField = user.FieldTenantID
+
+// searchable describes the annotated fields of an entity type.
+type searchable struct {
+	typ    string
+	table  string
+	fields []string
+	config string
+}
+
+// searchables returns the searchable types, read from the schema annotations.
+func searchables() []searchable {
+	var ss []searchable
+	for _, s := range []struct {
+		typ    string
+		table  string
+		schema interface{ Annotations() []entschema.Annotation }
+	}{
+		{ent.TypeUser, user.Table, schema.User{}},
+		{ent.TypeCar, car.Table, schema.Car{}},
+	} {
+		for _, a := range s.schema.Annotations() {
+			if a, ok := a.(schema.Search); ok {
+				config := a.Config
+				if config == "" {
+					config = "simple"
+				}
+				ss = append(ss, searchable{typ: s.typ, table: s.table, fields: a.Fields, config: config})
+			}
+		}
+	}
+	return ss
+}
+
+// rank sorts the results best first and applies the limit.
+func rank(results []Result, limit int) []Result {
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.ID < b.ID
+	})
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
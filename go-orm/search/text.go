@@ -0,0 +1,116 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// words returns the lower-cased words of s. Like pg_trgm,
+// anything that is not a letter or a digit separates words.
+func words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), isSeparator)
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// trigrams returns the trigrams of the words of s, computed the way
+// pg_trgm does: each word is padded with two spaces in front and one
+// at the end, so "cat" gives "  c", " ca", "cat" and "at ".
+func trigrams(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range words(s) {
+		r := []rune("  " + w + " ")
+		for i := 0; i+3 <= len(r); i++ {
+			set[string(r[i:i+3])] = true
+		}
+	}
+	return set
+}
+
+// similarity returns the ratio of the trigrams a and b share
+// to the trigrams of both, as pg_trgm's similarity function does.
+func similarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for g := range a {
+		if b[g] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// score returns how well text matches the query. It is the best of the
+// similarity of the whole texts and the average similarity of each query
+// word to its closest word in text, so a short query still matches one
+// word of a long text.
+func score(text, query string) float64 {
+	best := similarity(trigrams(text), trigrams(query))
+	qws, tws := words(query), words(text)
+	if len(qws) == 0 || len(tws) == 0 {
+		return best
+	}
+	var sum float64
+	for _, qw := range qws {
+		sum += closest(trigrams(qw), tws)
+	}
+	if avg := sum / float64(len(qws)); avg > best {
+		best = avg
+	}
+	return best
+}
+
+// closest returns the highest similarity between q and one of the words.
+func closest(q map[string]bool, words []string) float64 {
+	var best float64
+	for _, w := range words {
+		if s := similarity(q, trigrams(w)); s > best {
+			best = s
+		}
+	}
+	return best
+}
+
+// highlight wraps the words of text that are similar to a word of the
+// query, by at least threshold, in <b></b>. The rest of the text is
+// HTML-escaped, so that the result is safe to embed in a page.
+func highlight(text, query string, threshold float64) string {
+	var qgrams []map[string]bool
+	for _, qw := range words(query) {
+		qgrams = append(qgrams, trigrams(qw))
+	}
+	var b strings.Builder
+	rs := []rune(text)
+	for i := 0; i < len(rs); {
+		if isSeparator(rs[i]) {
+			b.WriteString(html.EscapeString(string(rs[i])))
+			i++
+			continue
+		}
+		j := i
+		for j < len(rs) && !isSeparator(rs[j]) {
+			j++
+		}
+		w := string(rs[i:j])
+		esc := html.EscapeString(w)
+		match := false
+		for _, q := range qgrams {
+			if similarity(q, trigrams(w)) >= threshold {
+				match = true
+				break
+			}
+		}
+		if match {
+			b.WriteString("<b>" + esc + "</b>")
+		} else {
+			b.WriteString(esc)
+		}
+		i = j
+	}
+	return b.String()
+}
@@ -4,11 +4,16 @@ import (
 	"context"
 	"fmt"
 	"github.com/anjanashankar9/go-learning/go-orm/ent/car"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/membership"
 	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+	"github.com/anjanashankar9/go-learning/go-orm/ownership"
+	"github.com/anjanashankar9/go-learning/go-orm/pagination"
 	"log"
 	"time"
 
 	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	_ "github.com/anjanashankar9/go-learning/go-orm/ent/runtime"
+	"github.com/anjanashankar9/go-learning/go-orm/viewer"
 
 	_ "github.com/lib/pq"
 )
@@ -23,6 +28,22 @@ func main() {
 	if err := client.Schema.Create(context.Background()); err != nil {
 		log.Fatalf("failed creating schema resources: %v", err)
 	}
+	// Every query and mutation is checked against the privacy
+	// policies of the schema, so it needs a viewer to act as.
+	ctx := viewer.AdminContext(context.Background())
+	a8m, err := CreateCars(ctx, client)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := TransferCars(ctx, client, a8m); err != nil {
+		log.Fatal(err)
+	}
+	if err := CreateGroups(viewer.UserContext(context.Background(), a8m.ID), client, a8m); err != nil {
+		log.Fatal(err)
+	}
+	if err := PaginateUsers(ctx, client); err != nil {
+		log.Fatal(err)
+	}
 }
 
 func CreateUser(ctx context.Context, client *ent.Client) (*ent.User, error) {
@@ -122,3 +143,98 @@ func QueryCarUsers(ctx context.Context, a8m *ent.User) error {
 	}
 	return nil
 }
+
+func TransferCars(ctx context.Context, client *ent.Client, a8m *ent.User) error {
+	tesla, err := a8m.QueryCars().
+		Where(car.Model("Tesla")).
+		Only(ctx)
+	if err != nil {
+		return fmt.Errorf("failed querying user cars: %w", err)
+	}
+	// Register the car to its first owner.
+	registered := time.Now().AddDate(-2, 0, 0)
+	if _, err := ownership.Transfer(ctx, client, tesla.ID, a8m.ID, "KA01AB1234", "KA", registered); err != nil {
+		return fmt.Errorf("failed registering car: %w", err)
+	}
+	// Then sell it to a new owner a year later.
+	neo, err := client.User.
+		Create().
+		SetAge(25).
+		SetName("neo").
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed creating user: %w", err)
+	}
+	sold := registered.AddDate(1, 0, 0)
+	if _, err := ownership.Transfer(ctx, client, tesla.ID, neo.ID, "MH02CD5678", "MH", sold); err != nil {
+		return fmt.Errorf("failed transferring car: %w", err)
+	}
+	// Ask who owned the car half way through each registration.
+	for _, t := range []time.Time{registered.AddDate(0, 6, 0), sold.AddDate(0, 6, 0)} {
+		owner, err := ownership.OwnerAt(ctx, client, tesla.ID, t)
+		if err != nil {
+			return fmt.Errorf("failed querying car owner: %w", err)
+		}
+		log.Printf("car %q owner at %s: %q\n", tesla.Model, t.Format("2006-01-02"), owner.Name)
+	}
+	history, err := ownership.History(ctx, client, tesla.ID)
+	if err != nil {
+		return fmt.Errorf("failed querying car history: %w", err)
+	}
+	for _, r := range history {
+		log.Printf("car %q registered as %s to %q from %s\n", tesla.Model, r.Plate, r.Edges.Owner.Name, r.ValidFrom.Format("2006-01-02"))
+	}
+	return nil
+}
+
+func CreateGroups(ctx context.Context, client *ent.Client, a8m *ent.User) error {
+	// The viewer creating the group becomes its admin.
+	gh, err := client.Group.
+		Create().
+		SetName("GitHub").
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed creating group: %w", err)
+	}
+	// The admin can then add members to it.
+	neo, err := client.User.
+		Query().
+		Where(user.Name("neo")).
+		Only(viewer.AdminContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed querying user: %w", err)
+	}
+	if err := client.Group.UpdateOne(gh).AddUsers(neo).Exec(ctx); err != nil {
+		return fmt.Errorf("failed adding group member: %w", err)
+	}
+	members, err := gh.QueryMemberships().
+		WithUser().
+		Where(membership.RoleEQ(membership.RoleMember)).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed querying group members: %w", err)
+	}
+	for _, m := range members {
+		log.Printf("group %q member %q joined at %s\n", gh.Name, m.Edges.User.Name, m.JoinedAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func PaginateUsers(ctx context.Context, client *ent.Client) error {
+	// Walk all the users by name, 10 at a time,
+	// loading the cars of each page along with it.
+	args := pagination.Args{Limit: 10}
+	for {
+		page, err := pagination.Paginate(ctx, client.User.Query().WithCars(), pagination.UserByName, args)
+		if err != nil {
+			return fmt.Errorf("failed paginating users: %w", err)
+		}
+		for _, u := range page.Items {
+			log.Printf("user %q has %d cars\n", u.Name, len(u.Edges.Cars))
+		}
+		if !page.HasNext {
+			return nil
+		}
+		args.After = page.EndCursor
+	}
+}
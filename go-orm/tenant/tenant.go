@@ -0,0 +1,27 @@
+// Package tenant carries the tenant of the caller through a context.Context.
+// The User, Car and Group entities belong to a tenant, and the privacy rules
+// of the ent schema keep the callers inside theirs.
+package tenant
+
+import (
+	"context"
+)
+
+// Default is the tenant of the data created before tenants were introduced,
+// and of the viewers whose context holds no tenant. Admin viewers without a
+// tenant are not bound to one and see the data of all the tenants.
+const Default = 0
+
+type tenantCtxKey struct{}
+
+// FromContext returns the tenant id stored inside a context,
+// and whether there is one.
+func FromContext(ctx context.Context) (int, bool) {
+	id, ok := ctx.Value(tenantCtxKey{}).(int)
+	return id, ok
+}
+
+// NewContext returns a new context with the given tenant id attached.
+func NewContext(parent context.Context, id int) context.Context {
+	return context.WithValue(parent, tenantCtxKey{}, id)
+}
@@ -0,0 +1,144 @@
+// Package txn runs functions as a unit of work inside an ent transaction.
+//
+// WithTx begins the transaction, commits it if the function succeeds and
+// rolls it back if the function fails or panics. Transactions aborted by
+// Postgres because of a serialization failure or a deadlock are retried.
+package txn
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/lib/pq"
+)
+
+// Postgres error codes of the failures that are worth retrying.
+// See https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+// Defaults used when the corresponding Option is not given.
+const (
+	DefaultMaxRetries = 3
+	DefaultBackoff    = 10 * time.Millisecond
+)
+
+type options struct {
+	tx         sql.TxOptions
+	maxRetries int
+	backoff    time.Duration
+	retryable  func(error) bool
+}
+
+// Option configures WithTx.
+type Option func(*options)
+
+// Isolation sets the isolation level of the transaction.
+func Isolation(level sql.IsolationLevel) Option {
+	return func(o *options) {
+		o.tx.Isolation = level
+	}
+}
+
+// ReadOnly makes the transaction read-only.
+func ReadOnly() Option {
+	return func(o *options) {
+		o.tx.ReadOnly = true
+	}
+}
+
+// MaxRetries sets how many times a failed transaction is retried.
+// Zero, or a negative n, disables retries.
+func MaxRetries(n int) Option {
+	return func(o *options) {
+		if n < 0 {
+			n = 0
+		}
+		o.maxRetries = n
+	}
+}
+
+// Backoff sets the delay before the first retry.
+// The delay doubles with every following retry.
+func Backoff(d time.Duration) Option {
+	return func(o *options) {
+		o.backoff = d
+	}
+}
+
+// RetryIf replaces IsRetryable as the test deciding
+// which errors cause the transaction to be retried.
+func RetryIf(f func(error) bool) Option {
+	return func(o *options) {
+		o.retryable = f
+	}
+}
+
+// IsRetryable reports whether err is a Postgres serialization
+// failure or deadlock, after which the transaction can be retried.
+func IsRetryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == serializationFailure || pqErr.Code == deadlockDetected
+}
+
+// WithTx calls fn with a new transaction and commits it if fn returns nil.
+// If fn returns an error or panics the transaction is rolled back, and a
+// panic is returned as an error. When the error is retryable, the whole
+// transaction is run again, so fn must not have side effects outside of tx.
+func WithTx(ctx context.Context, client *ent.Client, fn func(tx *ent.Tx) error, opts ...Option) error {
+	o := options{
+		maxRetries: DefaultMaxRetries,
+		backoff:    DefaultBackoff,
+		retryable:  IsRetryable,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	delay := o.backoff
+	for attempt := 0; ; attempt++ {
+		err := run(ctx, client, fn, &o.tx)
+		if err == nil || attempt >= o.maxRetries || !o.retryable(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: giving up retrying: %v", err, ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func run(ctx context.Context, client *ent.Client, fn func(tx *ent.Tx) error, opts *sql.TxOptions) (err error) {
+	tx, err := client.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("txn: panic in transaction: %v", p)
+			if rerr := tx.Rollback(); rerr != nil {
+				err = fmt.Errorf("%w: rolling back transaction: %v", err, rerr)
+			}
+		}
+	}()
+	if err := fn(tx); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("%w: rolling back transaction: %v", err, rerr)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
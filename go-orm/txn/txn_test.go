@@ -0,0 +1,156 @@
+package txn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anjanashankar9/go-learning/go-orm/ent"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/enttest"
+	_ "github.com/anjanashankar9/go-learning/go-orm/ent/runtime"
+	"github.com/anjanashankar9/go-learning/go-orm/ent/user"
+	"github.com/anjanashankar9/go-learning/go-orm/viewer"
+	"github.com/lib/pq"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openClient(t *testing.T) *ent.Client {
+	client := enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name()))
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// createUser returns a function for WithTx creating the user, then
+// failing with the errors of fail, one per attempt, until there are none.
+func createUser(name string, attempts *int, fail ...error) func(tx *ent.Tx) error {
+	return func(tx *ent.Tx) error {
+		ctx := viewer.AdminContext(context.Background())
+		if _, err := tx.User.Create().SetName(name).SetAge(30).Save(ctx); err != nil {
+			return err
+		}
+		n := *attempts
+		*attempts++
+		if n < len(fail) {
+			return fail[n]
+		}
+		return nil
+	}
+}
+
+func countUsers(t *testing.T, client *ent.Client, name string) int {
+	t.Helper()
+	n, err := client.User.Query().Where(user.Name(name)).Count(viewer.AdminContext(context.Background()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestWithTxRetries(t *testing.T) {
+	client := openClient(t)
+	ctx := viewer.AdminContext(context.Background())
+	for _, code := range []pq.ErrorCode{serializationFailure, deadlockDetected} {
+		name := "user" + string(code)
+		var attempts int
+		err := WithTx(ctx, client, createUser(name, &attempts, &pq.Error{Code: code}, fmt.Errorf("wrapped: %w", &pq.Error{Code: code})),
+			Backoff(time.Millisecond))
+		if err != nil {
+			t.Fatalf("%s: WithTx: %v", code, err)
+		}
+		if attempts != 3 {
+			t.Errorf("%s: %d attempts, want 3", code, attempts)
+		}
+		// The failed attempts are rolled back: only the last one is committed.
+		if n := countUsers(t, client, name); n != 1 {
+			t.Errorf("%s: %d users, want 1", code, n)
+		}
+	}
+}
+
+func TestWithTxGivesUp(t *testing.T) {
+	client := openClient(t)
+	ctx := viewer.AdminContext(context.Background())
+	retryable := &pq.Error{Code: serializationFailure}
+	var attempts int
+	err := WithTx(ctx, client, createUser("a", &attempts, retryable, retryable, retryable),
+		MaxRetries(2), Backoff(time.Millisecond))
+	if !errors.Is(err, retryable) || attempts != 3 {
+		t.Errorf("WithTx = %v after %d attempts, want %v after 3", err, attempts, retryable)
+	}
+	if n := countUsers(t, client, "a"); n != 0 {
+		t.Errorf("%d users, want 0", n)
+	}
+
+	// A negative MaxRetries disables the retries, like zero.
+	for _, n := range []int{0, -1} {
+		attempts = 0
+		err = WithTx(ctx, client, createUser("a", &attempts, retryable, retryable), MaxRetries(n), Backoff(time.Millisecond))
+		if !errors.Is(err, retryable) || attempts != 1 {
+			t.Errorf("MaxRetries(%d): WithTx = %v after %d attempts, want %v after 1", n, err, attempts, retryable)
+		}
+	}
+
+	// Not retryable.
+	other := &pq.Error{Code: "23505"}
+	attempts = 0
+	if err := WithTx(ctx, client, createUser("b", &attempts, other), Backoff(time.Millisecond)); !errors.Is(err, other) || attempts != 1 {
+		t.Errorf("WithTx = %v after %d attempts, want %v after 1", err, attempts, other)
+	}
+
+	// Retrying all the errors, until the context is done.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	attempts = 0
+	err = WithTx(ctx, client, func(*ent.Tx) error {
+		attempts++
+		cancel()
+		return retryable
+	}, RetryIf(func(error) bool { return true }), Backoff(time.Hour))
+	if !errors.Is(err, retryable) || !strings.Contains(err.Error(), context.Canceled.Error()) || attempts != 1 {
+		t.Errorf("WithTx = %v after %d attempts, want %v and the context canceled after 1", err, attempts, retryable)
+	}
+}
+
+func TestWithTxRollback(t *testing.T) {
+	client := openClient(t)
+	ctx := viewer.AdminContext(context.Background())
+	failure := errors.New("failure")
+	var attempts int
+	if err := WithTx(ctx, client, createUser("a", &attempts, failure)); err != failure {
+		t.Errorf("WithTx = %v, want %v", err, failure)
+	}
+	if n := countUsers(t, client, "a"); n != 0 {
+		t.Errorf("%d users after rollback, want 0", n)
+	}
+}
+
+func TestWithTxPanic(t *testing.T) {
+	client := openClient(t)
+	ctx := viewer.AdminContext(context.Background())
+	var attempts int
+	create := createUser("a", &attempts)
+	err := WithTx(ctx, client, func(tx *ent.Tx) error {
+		if err := create(tx); err != nil {
+			return err
+		}
+		panic("boom")
+	})
+	if err == nil || !strings.Contains(err.Error(), "panic in transaction: boom") {
+		t.Errorf("WithTx = %v, want the panic", err)
+	}
+	if n := countUsers(t, client, "a"); n != 0 {
+		t.Errorf("%d users after panic, want 0", n)
+	}
+	// The connection is usable again.
+	attempts = 0
+	if err := WithTx(ctx, client, createUser("b", &attempts)); err != nil {
+		t.Fatal(err)
+	}
+	if n := countUsers(t, client, "b"); n != 1 {
+		t.Errorf("%d users, want 1", n)
+	}
+}
@@ -0,0 +1,40 @@
+// Package viewer carries the identity of the caller through a context.Context.
+// The privacy rules of the ent schema use it to decide what the caller may
+// read and change.
+package viewer
+
+import (
+	"context"
+)
+
+// Viewer describes the user on whose behalf the client is used.
+type Viewer struct {
+	// ID is the id of the User acting.
+	ID int
+	// Admin viewers bypass all the privacy rules.
+	// It is meant for system tasks, like migrations and seeding.
+	Admin bool
+}
+
+type viewerCtxKey struct{}
+
+// FromContext returns the Viewer stored inside a context, or nil if there isn't one.
+func FromContext(ctx context.Context) *Viewer {
+	v, _ := ctx.Value(viewerCtxKey{}).(*Viewer)
+	return v
+}
+
+// NewContext returns a new context with the given Viewer attached.
+func NewContext(parent context.Context, v *Viewer) context.Context {
+	return context.WithValue(parent, viewerCtxKey{}, v)
+}
+
+// UserContext returns a new context acting as the user with the given id.
+func UserContext(parent context.Context, id int) context.Context {
+	return NewContext(parent, &Viewer{ID: id})
+}
+
+// AdminContext returns a new context acting as an admin.
+func AdminContext(parent context.Context) context.Context {
+	return NewContext(parent, &Viewer{Admin: true})
+}